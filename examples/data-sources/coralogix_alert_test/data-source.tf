@@ -0,0 +1,41 @@
+data "coralogix_alert_test" "payment_failures" {
+  definition = yamlencode({
+    name     = "payment failures by region"
+    priority = "P2"
+    group_by = ["region"]
+    type_definition = {
+      logs_threshold = {
+        logs_filter = {
+          simple_filter = {
+            lucene_query = "message:failed"
+            label_filters = {
+              application_name = [{ value = "checkout" }]
+              severities       = ["Error", "Critical"]
+            }
+          }
+        }
+        rules = [{
+          condition = {
+            threshold      = 1
+            time_window    = "10_MINUTES"
+            condition_type = "MORE_THAN"
+          }
+        }]
+      }
+    }
+  })
+
+  logs = [
+    jsonencode({ applicationName = "checkout", subsystemName = "api", severity = 5, message = "payment failed", region = "eu" }),
+    jsonencode({ applicationName = "checkout", subsystemName = "api", severity = "Error", message = "payment failed", region = "eu" }),
+    jsonencode({ applicationName = "checkout", subsystemName = "api", severity = 5, message = "payment failed", region = "us" }),
+    jsonencode({ applicationName = "checkout", subsystemName = "api", severity = 3, message = "payment succeeded", region = "eu" }),
+  ]
+}
+
+check "payment_failures_alert" {
+  assert {
+    condition     = data.coralogix_alert_test.payment_failures.fires
+    error_message = "The alert does not fire for the sample failures."
+  }
+}
@@ -0,0 +1,11 @@
+data "coralogix_dashboard_queries" "service" {
+  id = coralogix_dashboard.service.id
+}
+
+data "coralogix_dashboard_queries" "from_file" {
+  content_json = file("./dashboard.json")
+}
+
+output "promql_queries" {
+  value = [for q in data.coralogix_dashboard_queries.service.queries : q.query if q.query_language == "promql"]
+}
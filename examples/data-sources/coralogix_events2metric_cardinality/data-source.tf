@@ -0,0 +1,40 @@
+resource "coralogix_events2metric" "http_errors" {
+  name = "http_errors"
+  logs_query = {
+    lucene       = "status:[500 TO 599]"
+    applications = ["api"]
+    severities   = ["Error"]
+  }
+  metric_labels = {
+    pod    = "kubernetes.pod_name"
+    path   = "http.path"
+    status = "status"
+  }
+  metric_fields = {
+    errors = {
+      source_field = "status"
+    }
+  }
+  permutations = {
+    limit = 30000
+  }
+}
+
+# Counts the label permutations in the logs of the last 24 hours matched by the query,
+# and warns while planning when they exceed the limit.
+data "coralogix_events2metric_cardinality" "http_errors" {
+  metric_labels      = coralogix_events2metric.http_errors.metric_labels
+  logs_query         = coralogix_events2metric.http_errors.logs_query
+  permutations_limit = 30000
+  lookback           = "24h"
+}
+
+# Counts the label permutations in sample logs, without calling Coralogix.
+data "coralogix_events2metric_cardinality" "http_errors_samples" {
+  metric_labels      = coralogix_events2metric.http_errors.metric_labels
+  permutations_limit = 30000
+  samples = [
+    jsonencode({ kubernetes = { pod_name = "api-1" }, http = { path = "/orders" }, status = 500 }),
+    jsonencode({ kubernetes = { pod_name = "api-2" }, http = { path = "/orders/42" }, status = 503 }),
+  ]
+}
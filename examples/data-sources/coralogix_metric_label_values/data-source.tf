@@ -0,0 +1,34 @@
+# Lists the services reporting HTTP requests in the last 24 hours.
+data "coralogix_metric_label_values" "services" {
+  label    = "service"
+  match    = "http_requests_total{env=\"prod\"}"
+  regex    = "checkout|payments|orders-.*"
+  lookback = "24h"
+}
+
+# Creates an error rate alert per service.
+resource "coralogix_alert" "error_rate" {
+  for_each = toset(data.coralogix_metric_label_values.services.values)
+
+  name     = "${each.value} error rate"
+  priority = "P2"
+
+  type_definition = {
+    metric_threshold = {
+      metric_filter = {
+        promql = "sum(rate(http_requests_total{service=\"${each.value}\", code=~\"5..\"}[5m])) / sum(rate(http_requests_total{service=\"${each.value}\"}[5m]))"
+      }
+      rules = [{
+        condition = {
+          threshold      = 0.05
+          for_over_pct   = 0
+          of_the_last    = "10m"
+          condition_type = "MORE_THAN"
+        }
+      }]
+      missing_values = {
+        replace_with_zero = true
+      }
+    }
+  }
+}
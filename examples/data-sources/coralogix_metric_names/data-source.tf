@@ -0,0 +1,10 @@
+# Lists the HTTP metrics reported in the last 24 hours by the production services.
+data "coralogix_metric_names" "http" {
+  regex    = "http_.*"
+  match    = "{env=\"prod\"}"
+  lookback = "24h"
+}
+
+output "http_metrics" {
+  value = data.coralogix_metric_names.http.names
+}
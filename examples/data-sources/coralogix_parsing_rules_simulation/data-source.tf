@@ -0,0 +1,50 @@
+resource "coralogix_parsing_rules" "access_logs" {
+  name         = "access logs"
+  applications = ["nginx"]
+  rule_subgroups = [
+    {
+      rules = [
+        {
+          parse = {
+            name               = "access log"
+            source_field       = "text"
+            destination_field  = "text"
+            regular_expression = "^(?<method>[A-Z]+) (?<path>\\S+) (?<status>\\d{3})$"
+          }
+        }
+      ]
+    },
+    {
+      rules = [
+        {
+          block = {
+            name                      = "health checks"
+            source_field              = "text.path"
+            regular_expression        = "^/healthz$"
+            block_all_matching_blocks = true
+          }
+        }
+      ]
+    }
+  ]
+}
+
+data "coralogix_parsing_rules_simulation" "access_logs" {
+  rule_group       = jsonencode(coralogix_parsing_rules.access_logs)
+  application_name = "nginx"
+  logs = [
+    "GET /orders 200",
+    "GET /healthz 200",
+  ]
+}
+
+check "access_logs_golden" {
+  assert {
+    condition     = data.coralogix_parsing_rules_simulation.access_logs.results[0].text == jsonencode({ method = "GET", path = "/orders", status = "200" })
+    error_message = "The access log rule no longer parses request lines."
+  }
+  assert {
+    condition     = data.coralogix_parsing_rules_simulation.access_logs.results[1].blocked
+    error_message = "Health checks are no longer blocked."
+  }
+}
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+resource "coralogix_alert" "checkout_errors" {
+  name = "Checkout errors during business hours"
+  schedule = {
+    active_on = provider::coralogix::business_hours_schedule("Europe/Berlin", ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"], "09:00", "18:00", plantimestamp())
+  }
+  type_definition = {
+    logs_immediate = {
+      logs_filter = {
+        simple_filter = {
+          lucene_query = "service:checkout AND level:error"
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+locals {
+  checkout_overview = provider::coralogix::grafana_to_dashboard_json(file("${path.module}/grafana/checkout-overview.json"))
+}
+
+resource "coralogix_dashboard" "checkout_overview" {
+  content_json = local.checkout_overview.content_json
+}
+
+check "checkout_overview_conversion" {
+  assert {
+    condition     = length(local.checkout_overview.warnings) == 0
+    error_message = "Parts of the Grafana dashboard were not converted:\n${join("\n", local.checkout_overview.warnings)}"
+  }
+}
@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+variable "severity" {
+  type    = string
+  default = "critical"
+}
+
+resource "coralogix_alert" "checkout_errors" {
+  name     = "Checkout errors"
+  priority = provider::coralogix::priority_from_severity(var.severity)
+  type_definition = {
+    logs_immediate = {
+      logs_filter = {
+        simple_filter = {
+          lucene_query = "service:checkout AND level:error"
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,58 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+variable "services" {
+  type    = list(string)
+  default = ["checkout", "billing", "shipping"]
+}
+
+resource "coralogix_dashboard_widget_template" "request_rate" {
+  name  = "golden-signals-request-rate"
+  title = "{{ .service }} requests"
+  parameters = {
+    service = {}
+  }
+  definition = {
+    line_chart = {
+      query_definitions = [
+        {
+          query = {
+            metrics = {
+              promql_query = "sum(rate(http_requests_total{service=\"{{ .service }}\"}[5m]))"
+            }
+          }
+        }
+      ]
+    }
+  }
+}
+
+resource "coralogix_dashboard" "services" {
+  name = "service request rates"
+  layout = {
+    sections = [
+      {
+        rows = [
+          {
+            height = 19
+            widgets = [
+              for service in var.services :
+              provider::coralogix::render_dashboard_widget(coralogix_dashboard_widget_template.request_rate, { service = service })
+            ]
+          }
+        ]
+      }
+    ]
+  }
+}
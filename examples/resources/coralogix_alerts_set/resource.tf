@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+resource "coralogix_alerts_set" "service_alerts" {
+  yaml_content = file("./alerts.yaml")
+}
+
+output "error_alert_id" {
+  value = coralogix_alerts_set.service_alerts.alerts["checkout-errors"]
+}
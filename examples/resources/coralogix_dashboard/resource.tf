@@ -616,6 +616,24 @@ resource "coralogix_dashboard" "dashboard" {
         }
       }
     },
+    {
+      name         = "catalog_service"
+      display_name = "Catalog service"
+      source = {
+        # Options follow the service catalog enrichment below.
+        csv = {
+          content      = coralogix_data_enrichments.service_catalog.custom.custom_enrichment_data.contents
+          value_column = "service"
+          label_column = "team"
+          all_option   = { include_all = true }
+        }
+      }
+      value = {
+        multi_string = {
+          all = {}
+        }
+      }
+    },
     {
       name         = "service"
       display_name = "Service"
@@ -839,6 +857,21 @@ resource "coralogix_dashboard" "dashboard" {
   }
 }
 
+resource "coralogix_data_enrichments" "service_catalog" {
+  custom = {
+    custom_enrichment_data = {
+      name        = "service-catalog"
+      description = "team of every service"
+      contents    = "service,team\ncheckout,payments\ncatalog,search\n"
+    }
+    fields = [{
+      name                = "subsystemName"
+      enriched_field_name = "service_catalog"
+      selected_columns    = ["team"]
+    }]
+  }
+}
+
 resource "coralogix_dashboards_folder" "example" {
   name = "example"
 }
@@ -973,3 +1006,52 @@ resource "coralogix_dashboard" "dashboard_from_json_with_folder" {
     id = coralogix_dashboards_folder.example.id
   }
 }
+
+# Layout computed from a flat list of widgets.
+resource "coralogix_dashboard" "dashboard_with_auto_layout" {
+  name = "portal monitoring overview"
+  auto_layout = {
+    widgets = [
+      {
+        title = "Requests"
+        size  = "large"
+        definition = {
+          line_chart = {
+            query_definitions = [{
+              query = {
+                metrics = {
+                  promql_query = "sum(rate(http_requests_total[5m]))"
+                }
+              }
+            }]
+          }
+        }
+      },
+      {
+        title = "Errors"
+        size  = "large"
+        definition = {
+          line_chart = {
+            query_definitions = [{
+              query = {
+                metrics = {
+                  promql_query = "sum(rate(http_requests_total{code=~\"5..\"}[5m]))"
+                }
+              }
+            }]
+          }
+        }
+      },
+      {
+        section = "Notes"
+        size    = "full"
+        height  = 10
+        definition = {
+          markdown = {
+            markdown_text = "See the [runbook](https://example.com/runbook)."
+          }
+        }
+      },
+    ]
+  }
+}
@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+variable "release" {
+  type    = string
+  default = "2025.03"
+}
+
+data "coralogix_dashboard" "curated" {
+  id = "0a7b1c2d3e4f5a6b7c8d9"
+}
+
+# Record a version of the dashboard for every release and keep the dashboard
+# at version 3, undoing edits made in the Coralogix UI.
+resource "coralogix_dashboard_version" "curated" {
+  dashboard_id  = data.coralogix_dashboard.curated.id
+  keep_versions = 20
+  triggers = {
+    release = var.release
+  }
+  rollback_to = 3
+}
+
+output "versions" {
+  value = [for version in coralogix_dashboard_version.curated.versions : "${version.version} recorded at ${version.recorded_at}"]
+}
@@ -0,0 +1,80 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+resource "coralogix_dashboard_widget_template" "latency" {
+  name  = "golden-signals-latency"
+  title = "{{ .service }} p{{ .quantile }} latency"
+  parameters = {
+    service = {
+      description = "Value of the service label."
+    }
+    quantile = {
+      default = "0.99"
+    }
+  }
+  definition = {
+    line_chart = {
+      query_definitions = [
+        {
+          query = {
+            metrics = {
+              promql_query = "histogram_quantile({{ .quantile }}, sum by (le) (rate(http_request_duration_seconds_bucket{service=\"{{ .service }}\"}[5m])))"
+            }
+          }
+          unit = "seconds"
+        }
+      ]
+    }
+  }
+}
+
+resource "coralogix_dashboard_widget_template" "errors" {
+  name  = "golden-signals-errors"
+  title = "{{ .service }} errors"
+  parameters = {
+    service = {}
+  }
+  definition = {
+    line_chart = {
+      query_definitions = [
+        {
+          query = {
+            metrics = {
+              promql_query = "sum(rate(http_requests_total{service=\"{{ .service }}\", code=~\"5..\"}[5m]))"
+            }
+          }
+        }
+      ]
+    }
+  }
+}
+
+resource "coralogix_dashboard" "checkout" {
+  name = "checkout golden signals"
+  layout = {
+    sections = [
+      {
+        rows = [
+          {
+            height = 19
+            widgets = [
+              provider::coralogix::render_dashboard_widget(coralogix_dashboard_widget_template.latency, { service = "checkout" }),
+              provider::coralogix::render_dashboard_widget(coralogix_dashboard_widget_template.errors, { service = "checkout" }),
+            ]
+          }
+        ]
+      }
+    ]
+  }
+}
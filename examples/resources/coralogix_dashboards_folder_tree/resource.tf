@@ -0,0 +1,48 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+resource "coralogix_dashboards_folder_tree" "platform" {
+  paths = [
+    "platform/k8s/nodes",
+    "platform/k8s/pods",
+    "platform/databases",
+  ]
+}
+
+resource "coralogix_dashboard" "nodes" {
+  name = "Nodes"
+  folder = {
+    path = "platform/k8s/nodes"
+  }
+  layout = {
+    sections = [{
+      rows = [{
+        height = 19
+        widgets = [{
+          definition = {
+            markdown = {
+              markdown_text = "Node overview"
+            }
+          }
+        }]
+      }]
+    }]
+  }
+
+  depends_on = [coralogix_dashboards_folder_tree.platform]
+}
+
+output "nodes_folder_id" {
+  value = coralogix_dashboards_folder_tree.platform.folders["platform/k8s/nodes"].id
+}
@@ -52,6 +52,9 @@ resource "coralogix_data_enrichments" "custom_enrichment" {
       name        = "my-custom-enrichment"
       description = "description"
       contents    = "local_id,instance_type\nfoo1,t2.micro\nfoo2,t2.micro\nfoo3,t2.micro\nbar1,m3.large\n"
+      # The table is checked at plan time against the declared columns and key column.
+      columns    = ["local_id", "instance_type"]
+      key_column = "local_id"
     }
     fields = [{
       name                = "coralogix.metadata.IPAddress"
@@ -71,3 +74,14 @@ resource "coralogix_data_enrichments" "test" {
     fields = []
   }
 }
+
+resource "coralogix_data_enrichments" "large_table" {
+  custom = {
+    custom_enrichment_data = {
+      name        = "ip to team"
+      description = "Only the SHA-256, size and row count of the file are kept in the state"
+      source_file = "${path.module}/date-to-day-of-the-week.csv"
+    }
+    fields = []
+  }
+}
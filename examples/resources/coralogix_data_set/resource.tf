@@ -24,4 +24,10 @@ resource "coralogix_data_set" "data_set2" {
   uploaded_file {
     path = "./date-to-day-of-the-week.csv"
   }
-}
\ No newline at end of file
+}
+
+resource "coralogix_data_set" "data_set3" {
+  name        = "custom enrichment data 3"
+  description = "description"
+  source_file = "./date-to-day-of-the-week.csv"
+}
@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+resource "coralogix_events2metrics_set" "catalog" {
+  yaml_content = file("./metrics.yaml")
+}
+
+output "request_latency_id" {
+  value = coralogix_events2metrics_set.catalog.events2metrics["nginx_request_latency"]
+}
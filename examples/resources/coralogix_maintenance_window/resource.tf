@@ -0,0 +1,52 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+# Mute the checkout alerts for 30 minutes from the time of the deploy.
+resource "coralogix_maintenance_window" "checkout_deploy" {
+  name        = "checkout deploy"
+  description = "Mute checkout alerts during a deploy"
+  selector = {
+    service = "checkout"
+  }
+  start_time = plantimestamp()
+  duration   = "30m"
+
+  lifecycle {
+    ignore_changes = [start_time]
+  }
+}
+
+# Mute every alert firing for the eu-west-1 region during a failover drill.
+resource "coralogix_maintenance_window" "failover_drill" {
+  name = "eu-west-1 failover drill"
+  entity_selector = {
+    region = "eu-west-1"
+  }
+  start_time = "2025-09-01T08:00:00Z"
+  end_time   = "2025-09-01T10:00:00Z"
+}
+
+# Mute the alerts of the database team every Saturday from 22:30 to 00:30 UTC.
+resource "coralogix_maintenance_window" "weekly_maintenance" {
+  name = "database maintenance"
+  selector = {
+    team = "database"
+  }
+  start_time = "2025-07-01T00:00:00Z"
+  duration   = "2h"
+  recurrence = {
+    cron  = "30 22 * * SAT"
+    until = "2026-01-01T00:00:00Z"
+  }
+}
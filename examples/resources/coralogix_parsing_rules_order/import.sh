@@ -0,0 +1,2 @@
+# Import the order of rule groups, listing their IDs in order
+terraform import coralogix_parsing_rules_order.platform <first-rule-group-id>,<second-rule-group-id>
@@ -0,0 +1,51 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+# Rule groups managed by different teams leave their order unset.
+resource "coralogix_parsing_rules" "block_health_checks" {
+  name = "Block health checks"
+  rule_subgroups = [{
+    rules = [{
+      block = {
+        name               = "Block health checks"
+        source_field       = "text"
+        regular_expression = "GET /healthz"
+        keep_blocked_logs  = false
+      }
+    }]
+  }]
+}
+
+resource "coralogix_parsing_rules" "parse_nginx" {
+  name         = "Parse nginx"
+  applications = ["nginx"]
+  rule_subgroups = [{
+    rules = [{
+      parse = {
+        name               = "Parse access logs"
+        source_field       = "text"
+        destination_field  = "text"
+        regular_expression = "(?P<remote_addr>\\S+) - \\S+ \\[(?P<time>[^\\]]+)\\] \"(?P<request>[^\"]*)\" (?P<status>\\d+)"
+      }
+    }]
+  }]
+}
+
+# Blocks the health checks before parsing the nginx logs, ahead of every other rule group.
+resource "coralogix_parsing_rules_order" "platform" {
+  rule_group_ids = [
+    coralogix_parsing_rules.block_health_checks.id,
+    coralogix_parsing_rules.parse_nginx.id,
+  ]
+}
@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    coralogix = {
+      version = "~> 3.0"
+      source  = "coralogix/coralogix"
+    }
+  }
+}
+
+provider "coralogix" {
+  #api_key = "<add your api key here or add env variable CORALOGIX_API_KEY>"
+  #env = "<add the environment you want to work at or add env variable CORALOGIX_ENV>"
+}
+
+resource "coralogix_prometheus_alert_rules" "checkout" {
+  yaml_content = file("./prometheus-rule.yaml")
+}
+
+resource "coralogix_prometheus_alert_rules" "nodes" {
+  yaml_content = <<EOT
+groups:
+  - name: nodes
+    rules:
+      - alert: NodeFilesystemAlmostFull
+        expr: node_filesystem_avail_bytes / node_filesystem_size_bytes < 0.1
+        for: 30m
+        labels:
+          level: page
+        annotations:
+          description: Less than 10% of the filesystem is free.
+EOT
+  severity_label      = "level"
+  severity_priorities = {
+    page   = "P1"
+    ticket = "P3"
+  }
+}
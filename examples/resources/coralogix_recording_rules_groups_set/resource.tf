@@ -14,6 +14,7 @@ provider "coralogix" {
 
 resource "coralogix_recording_rules_groups_set" "recording_rules_group" {
   yaml_content = file("./rule-group-set.yaml")
+  tests_yaml   = file("./tests.yaml")
 }
 
 resource "coralogix_recording_rules_groups_set" "recording_rules_groups_set_explicit" {
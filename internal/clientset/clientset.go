@@ -89,9 +89,13 @@ type ClientSet struct {
 	aiApplications        *aiapplications.AIApplicationsServiceAPIService
 	aiEvaluations         *aievaluations.AIEvaluationsServiceAPIService
 	grafana               *GrafanaClient
+	metrics               *MetricsClient
+	dataprime             *DataprimeClient
 	groups                *GroupsClient
 	teamGroups            *teamGroupss.TeamGroupsManagementServiceAPIService
 	teams                 *teamsservice.TeamsServiceAPIService
+
+	queryValidation QueryValidation
 }
 
 func (c *ClientSet) ParsingRuleGroups() *prgs.RuleGroupsServiceAPIService {
@@ -138,6 +142,23 @@ func (c *ClientSet) Grafana() *GrafanaClient {
 	return c.grafana
 }
 
+func (c *ClientSet) Metrics() *MetricsClient {
+	return c.metrics
+}
+
+func (c *ClientSet) Dataprime() *DataprimeClient {
+	return c.dataprime
+}
+
+// QueryValidation returns the dashboard query checks configured on the provider.
+func (c *ClientSet) QueryValidation() QueryValidation {
+	return c.queryValidation
+}
+
+func (c *ClientSet) SetQueryValidation(queryValidation QueryValidation) {
+	c.queryValidation = queryValidation
+}
+
 func (c *ClientSet) RecordingRuleGroupsSets() *recRuless.RecordingRulesServiceAPIService {
 	return c.recordingRuleGroups
 }
@@ -289,6 +310,8 @@ func NewClientSet(region string, apiKey string, grpcTarget string) *ClientSet {
 		customDataEnrichments: cs.CustomEnrichments(),
 		alertScheduler:        cs.AlertScheduler(),
 		grafana:               NewGrafanaClient(apikeyCPC),
+		metrics:               NewMetricsClient(apikeyCPC),
+		dataprime:             NewDataprimeClient(apikeyCPC),
 		groups:                NewGroupsClient(region, apiKey),
 		teamGroups:            cs.Groups(),
 		teams:                 cs.Teams(),
@@ -0,0 +1,104 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset/rest"
+)
+
+// DataprimeClient runs queries on the DataPrime query API.
+type DataprimeClient struct {
+	client *rest.Client
+}
+
+type dataprimeQueryRequest struct {
+	Query    string                 `json:"query"`
+	Metadata dataprimeQueryMetadata `json:"metadata"`
+}
+
+type dataprimeQueryMetadata struct {
+	Syntax    string `json:"syntax"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+// dataprimeQueryMessage is a line of the response, which streams one JSON
+// object per line.
+type dataprimeQueryMessage struct {
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+	Result *struct {
+		Results []struct {
+			UserData string `json:"userData"`
+		} `json:"results"`
+	} `json:"result"`
+}
+
+// Query runs a DataPrime query over [start, end) and returns the user data of
+// its results.
+func (d DataprimeClient) Query(ctx context.Context, query string, start, end time.Time) ([]map[string]any, error) {
+	body, err := json.Marshal(dataprimeQueryRequest{
+		Query: query,
+		Metadata: dataprimeQueryMetadata{
+			Syntax:    "QUERY_SYNTAX_DATAPRIME",
+			StartDate: start.UTC().Format(time.RFC3339),
+			EndDate:   end.UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	bodyResp, err := d.client.Post(ctx, "/api/v1/dataprime/query", "application/json", string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []map[string]any
+	for _, line := range strings.Split(bodyResp, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var message dataprimeQueryMessage
+		if err := json.Unmarshal([]byte(line), &message); err != nil {
+			return nil, err
+		}
+		if message.Error != nil {
+			return nil, fmt.Errorf("DataPrime query failed: %s", message.Error.Message)
+		}
+		if message.Result == nil {
+			continue
+		}
+		for _, result := range message.Result.Results {
+			var userData map[string]any
+			if err := json.Unmarshal([]byte(result.UserData), &userData); err != nil {
+				return nil, fmt.Errorf("invalid DataPrime result %q: %w", result.UserData, err)
+			}
+			results = append(results, userData)
+		}
+	}
+	return results, nil
+}
+
+func NewDataprimeClient(c *CallPropertiesCreator) *DataprimeClient {
+	targetUrl := "https://" + strings.Replace(c.targetUrl, "grpc", "http", 1)
+	return &DataprimeClient{client: rest.NewRestClient(targetUrl, c.apiKey)}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientset
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset/rest"
+)
+
+func TestDataprimeClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request dataprimeQueryRequest
+		if r.URL.Path != "/api/v1/dataprime/query" || json.NewDecoder(r.Body).Decode(&request) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if strings.Contains(request.Query, "invalid") {
+			_, _ = w.Write([]byte(`{"error":{"message":"unknown keyword"}}`))
+			return
+		}
+		if request.Metadata.StartDate != "2025-03-01T00:00:00Z" || request.Metadata.EndDate != "2025-03-02T00:00:00Z" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"queryId":{"queryId":"1"}}
+{"result":{"results":[{"userData":"{\"pod\":\"a\"}"},{"userData":"{\"pod\":\"b\"}"}]}}
+{"result":{"results":[{"userData":"{\"pod\":\"c\"}"}]}}
+`))
+	}))
+	defer server.Close()
+
+	client := &DataprimeClient{client: rest.NewRestClient(server.URL, "api-key")}
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	results, err := client.Query(context.Background(), "source logs | distinct $d.pod as pod", start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]any{{"pod": "a"}, {"pod": "b"}, {"pod": "c"}}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Query() = %v, want %v", results, want)
+	}
+	if _, err := client.Query(context.Background(), "invalid", start, start); err == nil || !strings.Contains(err.Error(), "unknown keyword") {
+		t.Errorf("Query(invalid) error = %v", err)
+	}
+}
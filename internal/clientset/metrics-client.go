@@ -0,0 +1,183 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset/rest"
+)
+
+// MetricsMetadataSource lists the metrics known to Coralogix and their labels.
+type MetricsMetadataSource interface {
+	MetricNames(ctx context.Context) ([]string, error)
+	LabelNames(ctx context.Context, metric string) ([]string, error)
+}
+
+// QueryValidation configures the plan time checks of dashboard queries.
+// Metadata is nil when metric and label names are not verified.
+type QueryValidation struct {
+	Enabled  bool
+	Metadata MetricsMetadataSource
+}
+
+// MetricsClient reads metric metadata from the Prometheus compatible metrics API.
+type MetricsClient struct {
+	client *rest.Client
+}
+
+type metricsAPIResponse struct {
+	Status string   `json:"status"`
+	Data   []string `json:"data"`
+	Error  string   `json:"error"`
+}
+
+// MetricSeriesFilter narrows the label values to the series matching the
+// Match selectors between Start and End. Zero values don't filter.
+type MetricSeriesFilter struct {
+	Match []string
+	Start time.Time
+	End   time.Time
+}
+
+func (m MetricsClient) MetricNames(ctx context.Context) ([]string, error) {
+	return m.LabelValues(ctx, "__name__", MetricSeriesFilter{})
+}
+
+// LabelValues lists the values of label, the metric names for __name__.
+func (m MetricsClient) LabelValues(ctx context.Context, label string, filter MetricSeriesFilter) ([]string, error) {
+	query := url.Values{}
+	for _, match := range filter.Match {
+		query.Add("match[]", match)
+	}
+	if !filter.Start.IsZero() {
+		query.Set("start", filter.Start.UTC().Format(time.RFC3339))
+	}
+	if !filter.End.IsZero() {
+		query.Set("end", filter.End.UTC().Format(time.RFC3339))
+	}
+
+	path := "/metrics/api/v1/label/" + url.PathEscape(label) + "/values"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return m.get(ctx, path)
+}
+
+func (m MetricsClient) LabelNames(ctx context.Context, metric string) ([]string, error) {
+	return m.get(ctx, "/metrics/api/v1/labels?"+url.Values{"match[]": {metric}}.Encode())
+}
+
+func (m MetricsClient) get(ctx context.Context, path string) ([]string, error) {
+	bodyResp, err := m.client.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response metricsAPIResponse
+	if err := json.Unmarshal([]byte(bodyResp), &response); err != nil {
+		return nil, err
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("metrics API returned status %q: %s", response.Status, response.Error)
+	}
+	return response.Data, nil
+}
+
+func NewMetricsClient(c *CallPropertiesCreator) *MetricsClient {
+	targetUrl := "https://" + strings.Replace(c.targetUrl, "grpc", "http", 1)
+	return &MetricsClient{client: rest.NewRestClient(targetUrl, c.apiKey)}
+}
+
+// FileMetricsMetadataSource reads metric metadata from a JSON file mapping
+// metric names to their label names, e.g. {"up": ["job", "instance"]}.
+type FileMetricsMetadataSource struct {
+	metrics map[string][]string
+}
+
+func NewFileMetricsMetadataSource(path string) (*FileMetricsMetadataSource, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics map[string][]string
+	if err := json.Unmarshal(content, &metrics); err != nil {
+		return nil, fmt.Errorf("metrics metadata file %s must map metric names to label names: %w", path, err)
+	}
+	return &FileMetricsMetadataSource{metrics: metrics}, nil
+}
+
+func (f FileMetricsMetadataSource) MetricNames(_ context.Context) ([]string, error) {
+	names := make([]string, 0, len(f.metrics))
+	for name := range f.metrics {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func (f FileMetricsMetadataSource) LabelNames(_ context.Context, metric string) ([]string, error) {
+	return f.metrics[metric], nil
+}
+
+// CachedMetricsMetadataSource remembers the answers of source for the
+// lifetime of the provider, so every dashboard of a plan doesn't list the
+// metrics again.
+type CachedMetricsMetadataSource struct {
+	source  MetricsMetadataSource
+	mutex   sync.Mutex
+	metrics []string
+	labels  map[string][]string
+}
+
+func NewCachedMetricsMetadataSource(source MetricsMetadataSource) *CachedMetricsMetadataSource {
+	return &CachedMetricsMetadataSource{source: source, labels: map[string][]string{}}
+}
+
+func (c *CachedMetricsMetadataSource) MetricNames(ctx context.Context) ([]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.metrics == nil {
+		metrics, err := c.source.MetricNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.metrics = metrics
+	}
+	return c.metrics, nil
+}
+
+func (c *CachedMetricsMetadataSource) LabelNames(ctx context.Context, metric string) ([]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if labels, ok := c.labels[metric]; ok {
+		return labels, nil
+	}
+	labels, err := c.source.LabelNames(ctx, metric)
+	if err != nil {
+		return nil, err
+	}
+	c.labels[metric] = labels
+	return labels, nil
+}
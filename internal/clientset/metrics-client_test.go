@@ -0,0 +1,118 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientset
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset/rest"
+)
+
+func TestMetricsClient(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case r.URL.Path == "/metrics/api/v1/label/__name__/values":
+			_, _ = w.Write([]byte(`{"status":"success","data":["up","http_requests_total"]}`))
+		case r.URL.Path == "/metrics/api/v1/labels" && r.URL.Query().Get("match[]") == "up":
+			_, _ = w.Write([]byte(`{"status":"success","data":["__name__","instance","job"]}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"error","error":"bad match"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewCachedMetricsMetadataSource(&MetricsClient{client: rest.NewRestClient(server.URL, "api-key")})
+	ctx := context.Background()
+	for range 2 {
+		metrics, err := client.MetricNames(ctx)
+		if err != nil || !reflect.DeepEqual(metrics, []string{"up", "http_requests_total"}) {
+			t.Fatalf("MetricNames() = %q, %v", metrics, err)
+		}
+		labels, err := client.LabelNames(ctx, "up")
+		if err != nil || !reflect.DeepEqual(labels, []string{"__name__", "instance", "job"}) {
+			t.Fatalf("LabelNames(up) = %q, %v", labels, err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want the second lookups to be cached", requests)
+	}
+	if _, err := client.LabelNames(ctx, "missing"); err == nil {
+		t.Error("expected an error for an error response")
+	}
+}
+
+func TestMetricsClientLabelValues(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/metrics/api/v1/label/job/values" ||
+			!reflect.DeepEqual(query["match[]"], []string{`up{env="prod"}`, "http_requests_total"}) ||
+			query.Get("start") != "2025-01-01T00:00:00Z" || query.Get("end") != "2025-01-02T00:00:00Z" {
+			_, _ = w.Write([]byte(`{"status":"error","error":"unexpected request ` + r.URL.String() + `"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":["api","db"]}`))
+	}))
+	defer server.Close()
+
+	client := MetricsClient{client: rest.NewRestClient(server.URL, "api-key")}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	values, err := client.LabelValues(context.Background(), "job", MetricSeriesFilter{
+		Match: []string{`up{env="prod"}`, "http_requests_total"},
+		Start: start,
+		End:   start.Add(24 * time.Hour),
+	})
+	if err != nil || !reflect.DeepEqual(values, []string{"api", "db"}) {
+		t.Fatalf("LabelValues(job) = %q, %v", values, err)
+	}
+}
+
+func TestFileMetricsMetadataSource(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	if err := os.WriteFile(path, []byte(`{"up": ["job", "instance"], "http_requests_total": ["code"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source, err := NewFileMetricsMetadataSource(path)
+	if err != nil {
+		t.Fatalf("NewFileMetricsMetadataSource() returned error %v", err)
+	}
+	if metrics, _ := source.MetricNames(context.Background()); !reflect.DeepEqual(metrics, []string{"http_requests_total", "up"}) {
+		t.Errorf("MetricNames() = %q", metrics)
+	}
+	if labels, _ := source.LabelNames(context.Background(), "up"); !reflect.DeepEqual(labels, []string{"job", "instance"}) {
+		t.Errorf("LabelNames(up) = %q", labels)
+	}
+
+	if err := os.WriteFile(path, []byte(`["up"]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileMetricsMetadataSource(path); err == nil {
+		t.Error("expected an error for a file that is not a map")
+	}
+}
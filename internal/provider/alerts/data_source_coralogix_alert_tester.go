@@ -0,0 +1,754 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	alertschema "github.com/coralogix/terraform-provider-coralogix/internal/provider/alerts/alert_schema"
+	alerttypes "github.com/coralogix/terraform-provider-coralogix/internal/provider/alerts/alert_types"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ datasource.DataSource = &AlertTestDataSource{}
+
+	// alertTestSeverities maps the numeric severities of the Coralogix
+	// ingestion API to the label_filters.severities values.
+	alertTestSeverities = map[string]string{
+		"1": "Debug",
+		"2": "Unspecified",
+		"3": "Info",
+		"4": "Warning",
+		"5": "Error",
+		"6": "Critical",
+	}
+
+	// alertTestGroupByLabels resolves the group_by keys of the log labels.
+	alertTestGroupByLabels = map[string]func(alertTestLog) string{
+		"coralogix.metadata.applicationName": func(log alertTestLog) string { return log.application },
+		"coralogix.metadata.subsystemName":   func(log alertTestLog) string { return log.subsystem },
+		"coralogix.metadata.severity":        func(log alertTestLog) string { return log.severity },
+	}
+)
+
+func NewAlertTestDataSource() datasource.DataSource {
+	return &AlertTestDataSource{}
+}
+
+type AlertTestDataSource struct{}
+
+type AlertTestDataSourceModel struct {
+	Definition             types.String `tfsdk:"definition"`
+	Logs                   types.List   `tfsdk:"logs"`   // []types.String
+	Groups                 types.List   `tfsdk:"groups"` // []map[string]string
+	Fires                  types.Bool   `tfsdk:"fires"`
+	MatchedLogs            types.List   `tfsdk:"matched_logs"`             // []types.Int64
+	DenominatorMatchedLogs types.List   `tfsdk:"denominator_matched_logs"` // []types.Int64
+	Results                types.List   `tfsdk:"results"`                  // []AlertTestResultModel
+}
+
+type AlertTestResultModel struct {
+	Group         types.Map     `tfsdk:"group"` // map[string]string
+	Value         types.Float64 `tfsdk:"value"`
+	ConditionType types.String  `tfsdk:"condition_type"`
+	Threshold     types.Float64 `tfsdk:"threshold"`
+	Priority      types.String  `tfsdk:"priority"`
+	Fires         types.Bool    `tfsdk:"fires"`
+}
+
+func alertTestResultAttr() map[string]attr.Type {
+	return map[string]attr.Type{
+		"group":          types.MapType{ElemType: types.StringType},
+		"value":          types.Float64Type,
+		"condition_type": types.StringType,
+		"threshold":      types.Float64Type,
+		"priority":       types.StringType,
+		"fires":          types.BoolType,
+	}
+}
+
+func (d *AlertTestDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_test"
+}
+
+func (d *AlertTestDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Evaluates a `logs_immediate`, `logs_threshold` or `logs_ratio_threshold` alert definition against sample JSON logs, without calling Coralogix. " +
+			"It reports which logs the Lucene query and `label_filters` match, the count or ratio of every `group_by` group and which rules would fire. " +
+			"Every group of `logs` and `groups` is evaluated, with a count of 0 when no log matches it. " +
+			"All logs are counted in one evaluation, so the rules' `time_window` is not used. " +
+			"The Lucene query is evaluated locally and approximates the Coralogix query engine: terms and phrases match words of a value case-insensitively, " +
+			"`*` and `?` wildcards, `/regex/`, `[a TO b]` ranges, `field:>=n` comparisons, `_exists_:field`, `AND`, `OR`, `NOT`, `+`, `-` and parentheses are supported, and `OR` is the implicit operator.",
+		Attributes: map[string]schema.Attribute{
+			"definition": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "YAML or JSON alert definition using the `coralogix_alert` attributes, like an entry of `coralogix_alerts_set`. " +
+					"Schema defaults apply to attributes the definition leaves out.",
+			},
+			"logs": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "JSON log lines. Each line is an object searched by the Lucene query. " +
+					"Its `applicationName`, `subsystemName` and `severity` keys are the labels `label_filters` match; `severity` is a name or a number from 1 (Debug) to 6 (Critical). " +
+					"`group_by` keys are fields of the object, and `coralogix.metadata.applicationName`, `coralogix.metadata.subsystemName` and `coralogix.metadata.severity` resolve to the labels.",
+			},
+			"groups": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+				MarkdownDescription: "Groups to evaluate in addition to the groups of `logs`, as a value by `group_by` key, with an empty string for the keys they leave out. " +
+					"Use them for groups without any sample log, such as a service that went silent, so `LESS_THAN` rules are evaluated on a count of 0.",
+			},
+			"fires": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether any rule fires for any group.",
+			},
+			"matched_logs": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "Indexes in `logs` of the logs the filter matches. For `logs_ratio_threshold`, the logs the numerator matches.",
+			},
+			"denominator_matched_logs": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "Indexes in `logs` of the logs the denominator of a `logs_ratio_threshold` alert matches. Null for other alert types.",
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Result of every rule for every group, ordered by rule and then by group.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group": schema.MapAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Value of every `group_by` key for the group, an empty string when logs don't have the key. Empty without `group_by`.",
+						},
+						"value": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of matched logs in the group, or the ratio for `logs_ratio_threshold`. Null when the denominator is zero.",
+						},
+						"condition_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Condition type of the rule, or `IMMEDIATE` for `logs_immediate`.",
+						},
+						"threshold": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Threshold of the rule, 0 for `logs_immediate`.",
+						},
+						"priority": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Priority of the rule override, or of the alert.",
+						},
+						"fires": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the rule fires for the group.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AlertTestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *AlertTestDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alert, diags := parseAlertTestDefinition(ctx, data.Definition.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	var lines []string
+	resp.Diagnostics.Append(data.Logs.ElementsAs(ctx, &lines, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	logs, diags := parseAlertTestLogs(lines)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	var groups []map[string]string
+	if !data.Groups.IsNull() {
+		resp.Diagnostics.Append(data.Groups.ElementsAs(ctx, &groups, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	evaluation, diags := evaluateAlertTest(ctx, alert, logs, groups)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(flattenAlertTestEvaluation(ctx, data, evaluation)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+type alertTestLog struct {
+	body                             map[string]any
+	application, subsystem, severity string
+}
+
+type alertTestLabelFilter struct {
+	value, operation string
+}
+
+type alertTestFilter struct {
+	query        luceneQuery
+	applications []alertTestLabelFilter
+	subsystems   []alertTestLabelFilter
+	severities   []string
+}
+
+type alertTestRule struct {
+	conditionType string
+	threshold     float64
+	priority      string
+}
+
+type alertTestResult struct {
+	group         map[string]string
+	value         *float64
+	conditionType string
+	threshold     float64
+	priority      string
+	fires         bool
+}
+
+type alertTestEvaluation struct {
+	matched            []int
+	denominatorMatched []int
+	results            []alertTestResult
+}
+
+// parseAlertTestDefinition decodes a YAML or JSON definition against the
+// coralogix_alert schema, the same way coralogix_alerts_set decodes an entry.
+func parseAlertTestDefinition(ctx context.Context, content string) (*alerttypes.AlertResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var definition map[string]any
+	if err := yaml.Unmarshal([]byte(content), &definition); err != nil {
+		diags.AddAttributeError(path.Root("definition"), "Error on unmarshal definition", err.Error())
+		return nil, diags
+	}
+	encoded, err := json.Marshal(definition)
+	if err != nil {
+		diags.AddAttributeError(path.Root("definition"), "Invalid definition", err.Error())
+		return nil, diags
+	}
+
+	var alert alerttypes.AlertResourceModel
+	if d := utils.DecodeJSONWithSchema(ctx, alertschema.V3(), encoded, &alert); d.HasError() {
+		for _, e := range d.Errors() {
+			diags.AddAttributeError(path.Root("definition"), e.Summary(), e.Detail())
+		}
+		return nil, diags
+	}
+	return &alert, nil
+}
+
+func parseAlertTestLogs(lines []string) ([]alertTestLog, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	logs := make([]alertTestLog, 0, len(lines))
+	for i, line := range lines {
+		var body map[string]any
+		if err := json.Unmarshal([]byte(line), &body); err != nil || body == nil {
+			diags.AddAttributeError(path.Root("logs").AtListIndex(i), "Invalid log", fmt.Sprintf("log %d must be a JSON object", i))
+			continue
+		}
+		log := alertTestLog{body: body}
+		log.application, _ = body["applicationName"].(string)
+		log.subsystem, _ = body["subsystemName"].(string)
+		if severity, ok := body["severity"]; ok && severity != nil {
+			log.severity = alertTestSeverity(luceneValueString(severity))
+			if log.severity == "" {
+				diags.AddAttributeError(path.Root("logs").AtListIndex(i), "Invalid log",
+					fmt.Sprintf("log %d has severity %v. Valid values: 1 to 6 or %q", i, severity, alerttypes.ValidLogSeverities))
+			}
+		}
+		logs = append(logs, log)
+	}
+	return logs, diags
+}
+
+func alertTestSeverity(severity string) string {
+	if name, ok := alertTestSeverities[severity]; ok {
+		return name
+	}
+	switch strings.ToLower(severity) {
+	case "verbose":
+		return "Unspecified"
+	case "warn":
+		return "Warning"
+	}
+	for _, name := range alerttypes.ValidLogSeverities {
+		if strings.EqualFold(name, severity) {
+			return name
+		}
+	}
+	return ""
+}
+
+func evaluateAlertTest(ctx context.Context, alert *alerttypes.AlertResourceModel, logs []alertTestLog, groups []map[string]string) (*alertTestEvaluation, diag.Diagnostics) {
+	var typeDefinition alerttypes.AlertTypeDefinitionModel
+	if diags := alert.TypeDefinition.As(ctx, &typeDefinition, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return nil, diags
+	}
+
+	var groupBy []string
+	if diags := alert.GroupBy.ElementsAs(ctx, &groupBy, false); diags.HasError() {
+		return nil, diags
+	}
+	seeds, diags := alertTestSeedGroups(groupBy, logs, groups)
+	if diags.HasError() {
+		return nil, diags
+	}
+	priority := alert.Priority.ValueString()
+
+	switch {
+	case !utils.ObjIsNullOrUnknown(typeDefinition.LogsImmediate):
+		var immediate alerttypes.LogsImmediateModel
+		if diags := typeDefinition.LogsImmediate.As(ctx, &immediate, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return nil, diags
+		}
+		filter, diags := expandAlertTestFilter(ctx, immediate.LogsFilter)
+		if diags.HasError() {
+			return nil, diags
+		}
+		matched := filter.matchingLogs(logs)
+		rules := []alertTestRule{{conditionType: "IMMEDIATE", priority: priority}}
+		return &alertTestEvaluation{
+			matched: matched,
+			results: evaluateAlertTestCounts(rules, groupBy, logs, matched, seeds),
+		}, nil
+
+	case !utils.ObjIsNullOrUnknown(typeDefinition.LogsThreshold):
+		var threshold alerttypes.LogsThresholdModel
+		if diags := typeDefinition.LogsThreshold.As(ctx, &threshold, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return nil, diags
+		}
+		filter, diags := expandAlertTestFilter(ctx, threshold.LogsFilter)
+		if diags.HasError() {
+			return nil, diags
+		}
+		var ruleModels []alerttypes.LogsThresholdRuleModel
+		if diags := threshold.Rules.ElementsAs(ctx, &ruleModels, false); diags.HasError() {
+			return nil, diags
+		}
+		rules := make([]alertTestRule, 0, len(ruleModels))
+		for _, ruleModel := range ruleModels {
+			var condition alerttypes.LogsThresholdConditionModel
+			if diags := ruleModel.Condition.As(ctx, &condition, basetypes.ObjectAsOptions{}); diags.HasError() {
+				return nil, diags
+			}
+			rule, diags := expandAlertTestRule(ctx, condition.ConditionType, condition.Threshold, ruleModel.Override, priority)
+			if diags.HasError() {
+				return nil, diags
+			}
+			rules = append(rules, rule)
+		}
+		matched := filter.matchingLogs(logs)
+		return &alertTestEvaluation{
+			matched: matched,
+			results: evaluateAlertTestCounts(sortAlertTestRules(rules), groupBy, logs, matched, seeds),
+		}, nil
+
+	case !utils.ObjIsNullOrUnknown(typeDefinition.LogsRatioThreshold):
+		var ratio alerttypes.LogsRatioThresholdModel
+		if diags := typeDefinition.LogsRatioThreshold.As(ctx, &ratio, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return nil, diags
+		}
+		numerator, diags := expandAlertTestFilter(ctx, ratio.Numerator)
+		if diags.HasError() {
+			return nil, diags
+		}
+		denominator, diags := expandAlertTestFilter(ctx, ratio.Denominator)
+		if diags.HasError() {
+			return nil, diags
+		}
+		var ruleModels []alerttypes.LogsRatioThresholdRuleModel
+		if diags := ratio.Rules.ElementsAs(ctx, &ruleModels, false); diags.HasError() {
+			return nil, diags
+		}
+		rules := make([]alertTestRule, 0, len(ruleModels))
+		for _, ruleModel := range ruleModels {
+			var condition alerttypes.LogsRatioConditionModel
+			if diags := ruleModel.Condition.As(ctx, &condition, basetypes.ObjectAsOptions{}); diags.HasError() {
+				return nil, diags
+			}
+			rule, diags := expandAlertTestRule(ctx, condition.ConditionType, condition.Threshold, ruleModel.Override, priority)
+			if diags.HasError() {
+				return nil, diags
+			}
+			rules = append(rules, rule)
+		}
+		evaluation := &alertTestEvaluation{
+			matched:            numerator.matchingLogs(logs),
+			denominatorMatched: denominator.matchingLogs(logs),
+		}
+		evaluation.results = evaluateAlertTestRatios(sortAlertTestRules(rules), groupBy, ratio.GroupByFor.ValueString(),
+			ratio.IgnoreInfinity.ValueBool(), logs, evaluation.matched, evaluation.denominatorMatched, seeds)
+		return evaluation, nil
+	}
+
+	diags.AddAttributeError(path.Root("definition"), "Unsupported alert type",
+		"coralogix_alert_test evaluates logs_immediate, logs_threshold and logs_ratio_threshold alerts only.")
+	return nil, diags
+}
+
+func expandAlertTestFilter(ctx context.Context, filter types.Object) (*alertTestFilter, diag.Diagnostics) {
+	result := &alertTestFilter{query: luceneMatchAll{}}
+	if utils.ObjIsNullOrUnknown(filter) {
+		return result, nil
+	}
+	var filterModel alerttypes.AlertsLogsFilterModel
+	if diags := filter.As(ctx, &filterModel, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return nil, diags
+	}
+	if utils.ObjIsNullOrUnknown(filterModel.SimpleFilter) {
+		return result, nil
+	}
+	var simpleFilter alerttypes.SimpleFilterModel
+	if diags := filterModel.SimpleFilter.As(ctx, &simpleFilter, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return nil, diags
+	}
+
+	var diags diag.Diagnostics
+	query, err := parseLuceneQuery(simpleFilter.LuceneQuery.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("definition"), "Invalid lucene_query",
+			fmt.Sprintf("%q cannot be evaluated: %s", simpleFilter.LuceneQuery.ValueString(), err))
+		return nil, diags
+	}
+	result.query = query
+
+	if utils.ObjIsNullOrUnknown(simpleFilter.LabelFilters) {
+		return result, nil
+	}
+	var labelFilters alerttypes.LabelFiltersModel
+	if diags := simpleFilter.LabelFilters.As(ctx, &labelFilters, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return nil, diags
+	}
+	for _, labels := range []struct {
+		set    types.Set
+		target *[]alertTestLabelFilter
+	}{
+		{labelFilters.ApplicationName, &result.applications},
+		{labelFilters.SubsystemName, &result.subsystems},
+	} {
+		var models []alerttypes.LabelFilterTypeModel
+		if diags := labels.set.ElementsAs(ctx, &models, false); diags.HasError() {
+			return nil, diags
+		}
+		for _, model := range models {
+			*labels.target = append(*labels.target, alertTestLabelFilter{value: model.Value.ValueString(), operation: model.Operation.ValueString()})
+		}
+	}
+	if diags := labelFilters.Severities.ElementsAs(ctx, &result.severities, false); diags.HasError() {
+		return nil, diags
+	}
+	return result, nil
+}
+
+func expandAlertTestRule(ctx context.Context, conditionType types.String, threshold types.Float64, override types.Object, priority string) (alertTestRule, diag.Diagnostics) {
+	if !utils.ObjIsNullOrUnknown(override) {
+		var overrideModel alerttypes.AlertOverrideModel
+		if diags := override.As(ctx, &overrideModel, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return alertTestRule{}, diags
+		}
+		if !overrideModel.Priority.IsNull() && !overrideModel.Priority.IsUnknown() {
+			priority = overrideModel.Priority.ValueString()
+		}
+	}
+	return alertTestRule{
+		conditionType: conditionType.ValueString(),
+		threshold:     threshold.ValueFloat64(),
+		priority:      priority,
+	}, nil
+}
+
+func sortAlertTestRules(rules []alertTestRule) []alertTestRule {
+	slices.SortFunc(rules, func(a, b alertTestRule) int {
+		return cmp.Or(cmp.Compare(a.conditionType, b.conditionType), cmp.Compare(a.threshold, b.threshold), cmp.Compare(a.priority, b.priority))
+	})
+	return rules
+}
+
+func (f *alertTestFilter) matchingLogs(logs []alertTestLog) []int {
+	matched := []int{}
+	for i, log := range logs {
+		if f.matches(log) {
+			matched = append(matched, i)
+		}
+	}
+	return matched
+}
+
+func (f *alertTestFilter) matches(log alertTestLog) bool {
+	if !alertTestLabelMatches(f.applications, log.application) || !alertTestLabelMatches(f.subsystems, log.subsystem) {
+		return false
+	}
+	if len(f.severities) > 0 && !slices.Contains(f.severities, log.severity) {
+		return false
+	}
+	return f.query.matches(log.body)
+}
+
+// alertTestLabelMatches reports whether a label matches any of the filters,
+// which is true when there are none.
+func alertTestLabelMatches(filters []alertTestLabelFilter, label string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, filter := range filters {
+		var matched bool
+		switch filter.operation {
+		case "INCLUDES":
+			matched = strings.Contains(label, filter.value)
+		case "STARTS_WITH":
+			matched = strings.HasPrefix(label, filter.value)
+		case "ENDS_WITH":
+			matched = strings.HasSuffix(label, filter.value)
+		default:
+			matched = label == filter.value
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func alertTestGroup(groupBy []string, log alertTestLog) map[string]string {
+	group := make(map[string]string, len(groupBy))
+	for _, key := range groupBy {
+		if label, ok := alertTestGroupByLabels[key]; ok {
+			group[key] = label(log)
+			continue
+		}
+		group[key] = ""
+		for _, value := range luceneFieldValues(log.body, key) {
+			if value != nil {
+				group[key] = luceneValueString(value)
+				break
+			}
+		}
+	}
+	return group
+}
+
+func alertTestGroupKey(groupBy []string, group map[string]string) string {
+	values := make([]string, 0, len(groupBy))
+	for _, key := range groupBy {
+		values = append(values, strconv.Quote(group[key]))
+	}
+	return strings.Join(values, ",")
+}
+
+// alertTestSeedGroups returns the groups that are evaluated even when no log
+// matches them: the groups of every log, and the configured groups. Without
+// group_by there are none.
+func alertTestSeedGroups(groupBy []string, logs []alertTestLog, groups []map[string]string) ([]map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(groupBy) == 0 {
+		if len(groups) > 0 {
+			diags.AddAttributeError(path.Root("groups"), "Invalid groups", "groups can only be set for alerts with group_by.")
+		}
+		return nil, diags
+	}
+
+	seeds := make([]map[string]string, 0, len(logs)+len(groups))
+	for _, log := range logs {
+		seeds = append(seeds, alertTestGroup(groupBy, log))
+	}
+	for i, configured := range groups {
+		group := make(map[string]string, len(groupBy))
+		for _, key := range groupBy {
+			group[key] = configured[key]
+		}
+		for _, key := range slices.Sorted(maps.Keys(configured)) {
+			if !slices.Contains(groupBy, key) {
+				diags.AddAttributeError(path.Root("groups").AtListIndex(i), "Invalid groups", fmt.Sprintf("%q is not a group_by key of the alert.", key))
+			}
+		}
+		seeds = append(seeds, group)
+	}
+	return seeds, diags
+}
+
+// countAlertTestGroups counts the logs at indexes by group, starting from a
+// count of 0 for the seed groups. Without group_by there is a single group,
+// counted even when no log matches.
+func countAlertTestGroups(groupBy []string, logs []alertTestLog, indexes []int, seeds []map[string]string) (map[string]map[string]string, map[string]float64) {
+	groups, counts := map[string]map[string]string{}, map[string]float64{}
+	if len(groupBy) == 0 {
+		groups[""] = map[string]string{}
+		counts[""] = float64(len(indexes))
+		return groups, counts
+	}
+	for _, group := range seeds {
+		key := alertTestGroupKey(groupBy, group)
+		groups[key] = group
+		counts[key] = 0
+	}
+	for _, i := range indexes {
+		group := alertTestGroup(groupBy, logs[i])
+		key := alertTestGroupKey(groupBy, group)
+		groups[key] = group
+		counts[key]++
+	}
+	return groups, counts
+}
+
+func evaluateAlertTestCounts(rules []alertTestRule, groupBy []string, logs []alertTestLog, matched []int, seeds []map[string]string) []alertTestResult {
+	groups, counts := countAlertTestGroups(groupBy, logs, matched, seeds)
+	results := []alertTestResult{}
+	for _, rule := range rules {
+		for _, key := range slices.Sorted(maps.Keys(groups)) {
+			count := counts[key]
+			results = append(results, alertTestResult{
+				group:         groups[key],
+				value:         &count,
+				conditionType: rule.conditionType,
+				threshold:     rule.threshold,
+				priority:      rule.priority,
+				fires:         alertTestConditionFires(rule, &count, false),
+			})
+		}
+	}
+	return results
+}
+
+func evaluateAlertTestRatios(rules []alertTestRule, groupBy []string, groupByFor string, ignoreInfinity bool, logs []alertTestLog, numerator, denominator []int, seeds []map[string]string) []alertTestResult {
+	numeratorGroupBy, denominatorGroupBy := groupBy, groupBy
+	switch groupByFor {
+	case "Numerator Only":
+		denominatorGroupBy = nil
+	case "Denominator Only":
+		numeratorGroupBy = nil
+	}
+	numeratorGroups, numeratorCounts := countAlertTestGroups(numeratorGroupBy, logs, numerator, seeds)
+	denominatorGroups, denominatorCounts := countAlertTestGroups(denominatorGroupBy, logs, denominator, seeds)
+
+	// The side that is not grouped divides, or is divided by, every group
+	// of the other side.
+	groups := maps.Clone(numeratorGroups)
+	maps.Copy(groups, denominatorGroups)
+	if len(groupBy) > 0 && (len(numeratorGroupBy) == 0 || len(denominatorGroupBy) == 0) {
+		delete(groups, "")
+	}
+
+	results := []alertTestResult{}
+	for _, rule := range rules {
+		for _, key := range slices.Sorted(maps.Keys(groups)) {
+			numeratorKey, denominatorKey := key, key
+			if len(numeratorGroupBy) == 0 {
+				numeratorKey = ""
+			}
+			if len(denominatorGroupBy) == 0 {
+				denominatorKey = ""
+			}
+
+			var value *float64
+			if denominatorCounts[denominatorKey] != 0 {
+				ratio := numeratorCounts[numeratorKey] / denominatorCounts[denominatorKey]
+				value = &ratio
+			}
+			results = append(results, alertTestResult{
+				group:         groups[key],
+				value:         value,
+				conditionType: rule.conditionType,
+				threshold:     rule.threshold,
+				priority:      rule.priority,
+				fires:         alertTestConditionFires(rule, value, ignoreInfinity || numeratorCounts[numeratorKey] == 0),
+			})
+		}
+	}
+	return results
+}
+
+// alertTestConditionFires evaluates a rule on a value, where a nil value is an
+// infinite ratio that fires MORE_THAN rules unless ignored.
+func alertTestConditionFires(rule alertTestRule, value *float64, ignoreInfinity bool) bool {
+	if value == nil {
+		return rule.conditionType == "MORE_THAN" && !ignoreInfinity
+	}
+	switch rule.conditionType {
+	case "IMMEDIATE":
+		return *value > 0
+	case "LESS_THAN":
+		return *value < rule.threshold
+	default:
+		return *value > rule.threshold
+	}
+}
+
+func flattenAlertTestEvaluation(ctx context.Context, data *AlertTestDataSourceModel, evaluation *alertTestEvaluation) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	data.MatchedLogs, d = types.ListValueFrom(ctx, types.Int64Type, evaluation.matched)
+	diags.Append(d...)
+	data.DenominatorMatchedLogs = types.ListNull(types.Int64Type)
+	if evaluation.denominatorMatched != nil {
+		data.DenominatorMatchedLogs, d = types.ListValueFrom(ctx, types.Int64Type, evaluation.denominatorMatched)
+		diags.Append(d...)
+	}
+
+	fires := false
+	results := make([]AlertTestResultModel, 0, len(evaluation.results))
+	for _, result := range evaluation.results {
+		group, d := types.MapValueFrom(ctx, types.StringType, result.group)
+		diags.Append(d...)
+		results = append(results, AlertTestResultModel{
+			Group:         group,
+			Value:         types.Float64PointerValue(result.value),
+			ConditionType: types.StringValue(result.conditionType),
+			Threshold:     types.Float64Value(result.threshold),
+			Priority:      types.StringValue(result.priority),
+			Fires:         types.BoolValue(result.fires),
+		})
+		fires = fires || result.fires
+	}
+	data.Fires = types.BoolValue(fires)
+	data.Results, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: alertTestResultAttr()}, results)
+	diags.Append(d...)
+	return diags
+}
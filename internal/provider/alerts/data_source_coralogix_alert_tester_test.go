@@ -0,0 +1,226 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+var alertTestLogs = []string{
+	`{"applicationName": "checkout", "subsystemName": "api", "severity": 5, "message": "payment failed", "region": "eu"}`,
+	`{"applicationName": "checkout", "subsystemName": "api", "severity": "Error", "message": "payment failed", "region": "us"}`,
+	`{"applicationName": "checkout", "subsystemName": "worker", "severity": 5, "message": "payment failed", "region": "eu"}`,
+	`{"applicationName": "checkout-staging", "subsystemName": "api", "severity": 5, "message": "payment failed", "region": "eu"}`,
+	`{"applicationName": "checkout", "subsystemName": "api", "severity": 3, "message": "payment succeeded", "region": "eu"}`,
+	`{"applicationName": "checkout", "subsystemName": "api", "severity": "info", "message": "payment succeeded", "region": "us"}`,
+}
+
+func evaluateAlertTestDefinition(t *testing.T, definition string, groups ...map[string]string) *alertTestEvaluation {
+	t.Helper()
+	ctx := context.Background()
+	alert, diags := parseAlertTestDefinition(ctx, definition)
+	if diags.HasError() {
+		t.Fatalf("parseAlertTestDefinition returned diagnostics: %v", diags)
+	}
+	logs, diags := parseAlertTestLogs(alertTestLogs)
+	if diags.HasError() {
+		t.Fatalf("parseAlertTestLogs returned diagnostics: %v", diags)
+	}
+	evaluation, diags := evaluateAlertTest(ctx, alert, logs, groups)
+	if diags.HasError() {
+		t.Fatalf("evaluateAlertTest returned diagnostics: %v", diags)
+	}
+	return evaluation
+}
+
+func TestEvaluateAlertTestLogsImmediate(t *testing.T) {
+	evaluation := evaluateAlertTestDefinition(t, `
+name: payment failures
+priority: P2
+type_definition:
+  logs_immediate:
+    logs_filter:
+      simple_filter:
+        lucene_query: "message:failed"
+        label_filters:
+          application_name:
+            - value: checkout
+          subsystem_name:
+            - value: ap
+              operation: STARTS_WITH
+          severities: [Error]
+`)
+	if want := []int{0, 1}; !reflect.DeepEqual(evaluation.matched, want) {
+		t.Errorf("matched = %v, want %v", evaluation.matched, want)
+	}
+	if len(evaluation.results) != 1 || !evaluation.results[0].fires || *evaluation.results[0].value != 2 || evaluation.results[0].priority != "P2" {
+		t.Errorf("results = %+v, want a single firing result with value 2 and priority P2", evaluation.results)
+	}
+}
+
+func TestEvaluateAlertTestLogsThreshold(t *testing.T) {
+	evaluation := evaluateAlertTestDefinition(t, `
+name: payment failures by region
+group_by: [region]
+type_definition:
+  logs_threshold:
+    logs_filter:
+      simple_filter:
+        lucene_query: "failed"
+    rules:
+      - condition:
+          threshold: 2
+          time_window: 10_MINUTES
+          condition_type: MORE_THAN
+        override:
+          priority: P1
+`)
+	if want := []int{0, 1, 2, 3}; !reflect.DeepEqual(evaluation.matched, want) {
+		t.Errorf("matched = %v, want %v", evaluation.matched, want)
+	}
+	want := []alertTestResult{
+		{group: map[string]string{"region": "eu"}, conditionType: "MORE_THAN", threshold: 2, priority: "P1", fires: true},
+		{group: map[string]string{"region": "us"}, conditionType: "MORE_THAN", threshold: 2, priority: "P1", fires: false},
+	}
+	if len(evaluation.results) != len(want) {
+		t.Fatalf("results = %+v, want %d results", evaluation.results, len(want))
+	}
+	for i, result := range evaluation.results {
+		result.value = nil
+		if !reflect.DeepEqual(result, want[i]) {
+			t.Errorf("results[%d] = %+v, want %+v", i, result, want[i])
+		}
+	}
+}
+
+func TestEvaluateAlertTestLogsThresholdSilentGroups(t *testing.T) {
+	evaluation := evaluateAlertTestDefinition(t, `
+name: payment worker silent by region
+group_by: [region]
+type_definition:
+  logs_threshold:
+    logs_filter:
+      simple_filter:
+        label_filters:
+          subsystem_name:
+            - value: worker
+    rules:
+      - condition:
+          threshold: 1
+          time_window: 10_MINUTES
+          condition_type: LESS_THAN
+`, map[string]string{"region": "ap"})
+	want := map[string]bool{"ap": true, "eu": false, "us": true}
+	if len(evaluation.results) != len(want) {
+		t.Fatalf("results = %+v, want %d results", evaluation.results, len(want))
+	}
+	for _, result := range evaluation.results {
+		if fires, ok := want[result.group["region"]]; !ok || result.fires != fires {
+			t.Errorf("result = %+v, want fires = %v", result, fires)
+		}
+	}
+}
+
+func TestEvaluateAlertTestLogsRatioThreshold(t *testing.T) {
+	evaluation := evaluateAlertTestDefinition(t, `
+name: payment failure ratio
+group_by: [region]
+type_definition:
+  logs_ratio_threshold:
+    numerator:
+      simple_filter:
+        lucene_query: "failed"
+    denominator:
+      simple_filter:
+        lucene_query: "message:payment"
+    rules:
+      - condition:
+          threshold: 0.6
+          time_window: 10_MINUTES
+          condition_type: MORE_THAN
+`)
+	if want := []int{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(evaluation.denominatorMatched, want) {
+		t.Errorf("denominatorMatched = %v, want %v", evaluation.denominatorMatched, want)
+	}
+	if len(evaluation.results) != 2 {
+		t.Fatalf("results = %+v, want 2 results", evaluation.results)
+	}
+	eu, us := evaluation.results[0], evaluation.results[1]
+	if eu.group["region"] != "eu" || *eu.value != 0.75 || !eu.fires {
+		t.Errorf("eu result = %+v, want a firing ratio of 0.75", eu)
+	}
+	if us.group["region"] != "us" || *us.value != 0.5 || us.fires {
+		t.Errorf("us result = %+v, want a ratio of 0.5 that does not fire", us)
+	}
+}
+
+func TestEvaluateAlertTestUnsupportedType(t *testing.T) {
+	ctx := context.Background()
+	alert, diags := parseAlertTestDefinition(ctx, `
+name: metric
+type_definition:
+  metric_threshold:
+    metric_filter:
+      promql: up
+`)
+	if diags.HasError() {
+		t.Fatalf("parseAlertTestDefinition returned diagnostics: %v", diags)
+	}
+	if _, diags := evaluateAlertTest(ctx, alert, nil, nil); !diags.HasError() {
+		t.Error("expected an error for a metric_threshold alert")
+	}
+}
+
+func TestParseAlertTestLogs(t *testing.T) {
+	logs, diags := parseAlertTestLogs([]string{`{"severity": 2}`, `{"severity": "WARN"}`, `{}`})
+	if diags.HasError() {
+		t.Fatalf("parseAlertTestLogs returned diagnostics: %v", diags)
+	}
+	for i, want := range []string{"Unspecified", "Warning", ""} {
+		if logs[i].severity != want {
+			t.Errorf("logs[%d].severity = %q, want %q", i, logs[i].severity, want)
+		}
+	}
+
+	for _, line := range []string{`[]`, `null`, `{"severity": 7}`, `not json`} {
+		if _, diags := parseAlertTestLogs([]string{line}); !diags.HasError() {
+			t.Errorf("expected an error for log %s", line)
+		}
+	}
+}
+
+func TestAlertTestLabelMatches(t *testing.T) {
+	filters := []alertTestLabelFilter{{value: "check", operation: "STARTS_WITH"}, {value: "-prod", operation: "ENDS_WITH"}}
+	for label, want := range map[string]bool{
+		"checkout":     true,
+		"billing-prod": true,
+		"billing":      false,
+	} {
+		if got := alertTestLabelMatches(filters, label); got != want {
+			t.Errorf("alertTestLabelMatches(%q) = %v, want %v", label, got, want)
+		}
+	}
+	if !alertTestLabelMatches([]alertTestLabelFilter{{value: "out", operation: "INCLUDES"}}, "checkout") {
+		t.Error("INCLUDES should match a substring")
+	}
+	if alertTestLabelMatches([]alertTestLabelFilter{{value: "check", operation: "IS"}}, "checkout") {
+		t.Error("IS should only match the whole label")
+	}
+	if !alertTestLabelMatches(nil, "anything") {
+		t.Error("no filters should match every label")
+	}
+}
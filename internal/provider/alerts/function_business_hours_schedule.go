@@ -0,0 +1,168 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	// Embedded so IANA time zones resolve on hosts without a zoneinfo database.
+	_ "time/tzdata"
+
+	alertschema "github.com/coralogix/terraform-provider-coralogix/internal/provider/alerts/alert_schema"
+	alerttypes "github.com/coralogix/terraform-provider-coralogix/internal/provider/alerts/alert_types"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &BusinessHoursScheduleFunction{}
+
+func NewBusinessHoursScheduleFunction() function.Function {
+	return &BusinessHoursScheduleFunction{}
+}
+
+type BusinessHoursScheduleFunction struct{}
+
+func (f *BusinessHoursScheduleFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "business_hours_schedule"
+}
+
+func (f *BusinessHoursScheduleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds an alert schedule.active_on object from an IANA time zone.",
+		MarkdownDescription: "Builds the `schedule.active_on` object of `coralogix_alert` for business hours in an IANA time zone, such as `Europe/Berlin`. " +
+			"`utc_offset` is the offset the time zone has at `at`, or its standard time offset when `at` is omitted. " +
+			"A fixed offset cannot follow daylight saving time: pass `plantimestamp()` as `at` to follow the time zone, so the next plan after a change of offset updates the alert, " +
+			"or omit it or pass a fixed timestamp to keep the same offset all year.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "timezone",
+				MarkdownDescription: "IANA time zone name, for example `America/New_York`.",
+			},
+			function.ListParameter{
+				Name:                "days_of_week",
+				ElementType:         types.StringType,
+				MarkdownDescription: fmt.Sprintf("Days of the week, matched case-insensitively. Valid values: %q.", alerttypes.ValidDaysOfWeek),
+			},
+			function.StringParameter{
+				Name:                "start_time",
+				MarkdownDescription: "Start of the business hours in the time zone, in 24h `15:04` format.",
+			},
+			function.StringParameter{
+				Name:                "end_time",
+				MarkdownDescription: "End of the business hours in the time zone, in 24h `15:04` format.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "at",
+			MarkdownDescription: "Optional RFC 3339 timestamp to compute the UTC offset at, for example `plantimestamp()`. At most one can be passed.",
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: alertschema.AlertScheduleActiveOnAttr(),
+		},
+	}
+}
+
+func (f *BusinessHoursScheduleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		timezone, startTime, endTime string
+		daysOfWeek, at               []string
+	)
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &timezone, &daysOfWeek, &startTime, &endTime, &at))
+	if resp.Error != nil {
+		return
+	}
+
+	activeOn, funcErr := businessHoursActiveOn(timezone, daysOfWeek, startTime, endTime, at...)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	value, diags := types.ObjectValueFrom(ctx, alertschema.AlertScheduleActiveOnAttr(), activeOn)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, value))
+}
+
+func businessHoursActiveOn(timezone string, daysOfWeek []string, startTime, endTime string, at ...string) (*alerttypes.ActiveOnModel, *function.FuncError) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" || strings.EqualFold(timezone, "local") {
+		return nil, function.NewArgumentFuncError(0, fmt.Sprintf("%q is not an IANA time zone", timezone))
+	}
+
+	days := make([]string, 0, len(daysOfWeek))
+	for _, day := range daysOfWeek {
+		canonical := ""
+		for _, valid := range alerttypes.ValidDaysOfWeek {
+			if strings.EqualFold(day, valid) {
+				canonical = valid
+			}
+		}
+		if canonical == "" {
+			return nil, function.NewArgumentFuncError(1, fmt.Sprintf("%q is not a day of the week. Valid values: %q", day, alerttypes.ValidDaysOfWeek))
+		}
+		days = append(days, canonical)
+	}
+
+	for i, value := range []string{startTime, endTime} {
+		if _, err := time.Parse(TIME_FORMAT, value); err != nil || len(value) != len(TIME_FORMAT) {
+			return nil, function.NewArgumentFuncError(int64(2+i), fmt.Sprintf("%q is not a 24h time like 15:04 with a leading zero", value))
+		}
+	}
+
+	var utcOffset string
+	switch len(at) {
+	case 0:
+		utcOffset = standardUTCOffset(location)
+	case 1:
+		atTime, err := time.Parse(time.RFC3339, at[0])
+		if err != nil {
+			return nil, function.NewArgumentFuncError(4, fmt.Sprintf("%q is not an RFC 3339 timestamp", at[0]))
+		}
+		utcOffset = atTime.In(location).Format("-0700")
+	default:
+		return nil, function.NewArgumentFuncError(4, "at most one timestamp can be passed as at")
+	}
+
+	daysValue, _ := types.SetValueFrom(context.Background(), types.StringType, days)
+	return &alerttypes.ActiveOnModel{
+		DaysOfWeek: daysValue,
+		StartTime:  types.StringValue(startTime),
+		EndTime:    types.StringValue(endTime),
+		UtcOffset:  types.StringValue(utcOffset),
+	}, nil
+}
+
+// standardUTCOffset returns the standard time offset of location in the
+// current year. Daylight saving time moves clocks forward in the summer of
+// either hemisphere, so the standard offset is the smaller of the offsets in
+// January and July. Unlike the offset at the current time, it doesn't change
+// between plan and apply.
+func standardUTCOffset(location *time.Location) string {
+	year := time.Now().In(location).Year()
+	january := time.Date(year, time.January, 1, 12, 0, 0, 0, location)
+	july := time.Date(year, time.July, 1, 12, 0, 0, 0, location)
+	_, januaryOffset := january.Zone()
+	_, julyOffset := july.Zone()
+	if julyOffset < januaryOffset {
+		return july.Format("-0700")
+	}
+	return january.Format("-0700")
+}
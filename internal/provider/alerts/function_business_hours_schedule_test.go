@@ -0,0 +1,100 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"testing"
+)
+
+func TestBusinessHoursActiveOn(t *testing.T) {
+	winter := "2025-01-15T12:00:00Z"
+
+	cases := []struct {
+		name      string
+		timezone  string
+		at        string
+		wantError bool
+		want      string
+	}{
+		{name: "winter time", timezone: "Europe/Berlin", at: winter, want: "+0100"},
+		{name: "summer time", timezone: "Europe/Berlin", at: "2025-07-01T12:00:00Z", want: "+0200"},
+		{name: "negative offset", timezone: "America/New_York", at: winter, want: "-0500"},
+		{name: "half hour offset", timezone: "Asia/Kolkata", at: winter, want: "+0530"},
+		{name: "utc", timezone: "UTC", at: winter, want: "+0000"},
+		{name: "unknown time zone", timezone: "Mars/Olympus_Mons", at: winter, wantError: true},
+		{name: "local time zone", timezone: "Local", at: winter, wantError: true},
+		{name: "invalid at", timezone: "UTC", at: "yesterday", wantError: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			activeOn, err := businessHoursActiveOn(tc.timezone, []string{"Monday"}, "09:00", "17:00", tc.at)
+			if tc.wantError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("businessHoursActiveOn returned an error: %s", err.Text)
+			}
+			if got := activeOn.UtcOffset.ValueString(); got != tc.want {
+				t.Errorf("UtcOffset = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBusinessHoursActiveOnWithoutAt(t *testing.T) {
+	cases := map[string]string{
+		"Europe/Berlin":    "+0100",
+		"America/New_York": "-0500",
+		"Australia/Sydney": "+1000",
+		"Asia/Kolkata":     "+0530",
+	}
+	for timezone, want := range cases {
+		activeOn, err := businessHoursActiveOn(timezone, []string{"Monday"}, "09:00", "17:00")
+		if err != nil {
+			t.Fatalf("businessHoursActiveOn(%s) returned an error: %s", timezone, err.Text)
+		}
+		if got := activeOn.UtcOffset.ValueString(); got != want {
+			t.Errorf("UtcOffset(%s) = %q, want the standard time offset %q", timezone, got, want)
+		}
+	}
+}
+
+func TestBusinessHoursActiveOnArguments(t *testing.T) {
+	at := "2025-01-15T12:00:00Z"
+
+	activeOn, err := businessHoursActiveOn("UTC", []string{"monday", "FRIDAY"}, "09:00", "17:00", at)
+	if err != nil {
+		t.Fatalf("businessHoursActiveOn returned an error: %s", err.Text)
+	}
+	if got := activeOn.DaysOfWeek.String(); got != `["Monday","Friday"]` {
+		t.Errorf("DaysOfWeek = %s, want the canonical day names", got)
+	}
+
+	if _, err := businessHoursActiveOn("UTC", []string{"Someday"}, "09:00", "17:00", at); err == nil {
+		t.Error("expected an error for an unknown day")
+	}
+	if _, err := businessHoursActiveOn("UTC", []string{"Monday"}, "9:00", "17:00", at); err == nil {
+		t.Error("expected an error for a start time without a leading zero")
+	}
+	if _, err := businessHoursActiveOn("UTC", []string{"Monday"}, "09:00", "25:00", at); err == nil {
+		t.Error("expected an error for an invalid end time")
+	}
+	if _, err := businessHoursActiveOn("UTC", []string{"Monday"}, "09:00", "17:00", at, at); err == nil {
+		t.Error("expected an error for two timestamps")
+	}
+}
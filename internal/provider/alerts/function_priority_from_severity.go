@@ -0,0 +1,107 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	alerttypes "github.com/coralogix/terraform-provider-coralogix/internal/provider/alerts/alert_types"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = &PriorityFromSeverityFunction{}
+
+	// severityPriorities maps severity names to alert priorities, for the
+	// priority_from_severity function and the default severity_priorities of
+	// coralogix_prometheus_alert_rules.
+	severityPriorities = map[string]string{
+		"emergency":     "P1",
+		"fatal":         "P1",
+		"critical":      "P1",
+		"alert":         "P1",
+		"error":         "P2",
+		"high":          "P2",
+		"major":         "P2",
+		"warning":       "P3",
+		"warn":          "P3",
+		"medium":        "P3",
+		"low":           "P4",
+		"minor":         "P4",
+		"notice":        "P4",
+		"info":          "P5",
+		"informational": "P5",
+		"debug":         "P5",
+		"verbose":       "P5",
+	}
+)
+
+const severityPrioritiesDescription = "`emergency`, `fatal`, `critical` and `alert` map to P1, `error`, `high` and `major` to P2, `warning`, `warn` and `medium` to P3, " +
+	"`low`, `minor` and `notice` to P4, and `info`, `informational`, `debug` and `verbose` to P5"
+
+func NewPriorityFromSeverityFunction() function.Function {
+	return &PriorityFromSeverityFunction{}
+}
+
+type PriorityFromSeverityFunction struct{}
+
+func (f *PriorityFromSeverityFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "priority_from_severity"
+}
+
+func (f *PriorityFromSeverityFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Maps a severity name to an alert priority.",
+		MarkdownDescription: fmt.Sprintf("Maps a common severity name, matched case-insensitively, to a `coralogix_alert` priority (one of %q). ", alerttypes.ValidAlertPriorities) +
+			severityPrioritiesDescription + ". A priority is returned as is.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "severity",
+				MarkdownDescription: "Severity name, for example the `severity` label of a Prometheus rule.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *PriorityFromSeverityFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var severity string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &severity))
+	if resp.Error != nil {
+		return
+	}
+
+	priority, ok := priorityFromSeverity(severity)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is not a known severity", severity))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, priority))
+}
+
+func priorityFromSeverity(severity string) (string, bool) {
+	severity = strings.TrimSpace(severity)
+	if _, ok := alerttypes.AlertPrioritySchemaToProtoMap[strings.ToUpper(severity)]; ok {
+		return strings.ToUpper(severity), true
+	}
+	priority, ok := severityPriorities[strings.ToLower(severity)]
+	if _, valid := alerttypes.AlertPrioritySchemaToProtoMap[priority]; !ok || !valid {
+		return "", false
+	}
+	return priority, true
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import "testing"
+
+func TestPriorityFromSeverity(t *testing.T) {
+	cases := []struct {
+		severity string
+		want     string
+		ok       bool
+	}{
+		{"critical", "P1", true},
+		{" Critical ", "P1", true},
+		{"ERROR", "P2", true},
+		{"warn", "P3", true},
+		{"minor", "P4", true},
+		{"info", "P5", true},
+		{"p2", "P2", true},
+		{"P6", "", false},
+		{"", "", false},
+		{"unheard-of", "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.severity, func(t *testing.T) {
+			got, ok := priorityFromSeverity(tc.severity)
+			if got != tc.want || ok != tc.ok {
+				t.Errorf("priorityFromSeverity(%q) = (%q, %v), want (%q, %v)", tc.severity, got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
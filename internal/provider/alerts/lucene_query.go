@@ -0,0 +1,583 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// luceneQuery is a parsed Lucene query that can be evaluated locally against a
+// decoded JSON log. It approximates the Coralogix query engine closely enough to
+// test alert filters: terms and phrases match a run of case-insensitive words of
+// a value, wildcards and regular expressions match a whole value or one of its
+// words, and clauses combine the way the classic Lucene query parser combines
+// them, with OR as the implicit operator.
+type luceneQuery interface {
+	matches(log any) bool
+}
+
+type luceneOccur int
+
+const (
+	luceneShould luceneOccur = iota
+	luceneMust
+	luceneMustNot
+)
+
+type luceneClause struct {
+	occur luceneOccur
+	query luceneQuery
+}
+
+type luceneBoolean []luceneClause
+
+func (b luceneBoolean) matches(log any) bool {
+	hasMust, hasShould, shouldMatched := false, false, false
+	for _, clause := range b {
+		matched := clause.query.matches(log)
+		switch clause.occur {
+		case luceneMust:
+			if !matched {
+				return false
+			}
+			hasMust = true
+		case luceneMustNot:
+			if matched {
+				return false
+			}
+		default:
+			hasShould = true
+			shouldMatched = shouldMatched || matched
+		}
+	}
+	// A query of only prohibited clauses matches everything else, as in the
+	// Coralogix UI.
+	return hasMust || !hasShould || shouldMatched
+}
+
+type luceneMatchAll struct{}
+
+func (luceneMatchAll) matches(any) bool {
+	return true
+}
+
+type luceneExists struct {
+	field string
+}
+
+func (q luceneExists) matches(log any) bool {
+	for _, value := range luceneFieldValues(log, q.field) {
+		if value != nil {
+			return true
+		}
+	}
+	return false
+}
+
+type luceneTerm struct {
+	field string
+	// text is matched as a run of words, or pattern when the term has
+	// wildcards or is a regular expression.
+	text    string
+	pattern *regexp.Regexp
+	// compare is one of >, >=, < or <= for comparison terms like field:>=500.
+	compare string
+}
+
+func (q luceneTerm) matches(log any) bool {
+	for _, value := range luceneFieldValues(log, q.field) {
+		if q.matchesValue(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q luceneTerm) matchesValue(value any) bool {
+	if value == nil {
+		return false
+	}
+	if q.compare != "" {
+		c, ok := luceneCompare(value, q.text)
+		switch q.compare {
+		case ">":
+			return ok && c > 0
+		case ">=":
+			return ok && c >= 0
+		case "<":
+			return ok && c < 0
+		default:
+			return ok && c <= 0
+		}
+	}
+
+	text := luceneValueString(value)
+	if q.pattern != nil {
+		if q.pattern.MatchString(text) {
+			return true
+		}
+		for _, word := range luceneWords(text) {
+			if q.pattern.MatchString(word) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if number, ok := value.(float64); ok {
+		if term, err := strconv.ParseFloat(q.text, 64); err == nil {
+			return number == term
+		}
+	}
+	if strings.EqualFold(text, q.text) {
+		return true
+	}
+	words, termWords := luceneWords(text), luceneWords(q.text)
+	if len(termWords) == 0 {
+		return false
+	}
+	for i := 0; i+len(termWords) <= len(words); i++ {
+		if slicesEqualFold(words[i:i+len(termWords)], termWords) {
+			return true
+		}
+	}
+	return false
+}
+
+type luceneRange struct {
+	field                      string
+	lower, upper               string
+	includeLower, includeUpper bool
+}
+
+func (q luceneRange) matches(log any) bool {
+	for _, value := range luceneFieldValues(log, q.field) {
+		if value == nil {
+			continue
+		}
+		if q.lower != "*" {
+			c, ok := luceneCompare(value, q.lower)
+			if !ok || c < 0 || (c == 0 && !q.includeLower) {
+				continue
+			}
+		}
+		if q.upper != "*" {
+			c, ok := luceneCompare(value, q.upper)
+			if !ok || c > 0 || (c == 0 && !q.includeUpper) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// luceneCompare compares a log value with a query bound, numerically when both
+// are numbers and as strings otherwise, so RFC 3339 timestamps order correctly.
+func luceneCompare(value any, bound string) (int, bool) {
+	if number, ok := value.(float64); ok {
+		b, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return 0, false
+		}
+		switch {
+		case number < b:
+			return -1, true
+		case number > b:
+			return 1, true
+		}
+		return 0, true
+	}
+	if _, ok := value.(string); !ok {
+		return 0, false
+	}
+	return strings.Compare(value.(string), bound), true
+}
+
+// luceneFieldValues returns the leaf values of field in log. Dotted names walk
+// nested objects, keys that contain dots themselves included, arrays match on
+// any element, and an empty field searches every value of the log.
+func luceneFieldValues(log any, field string) []any {
+	if field == "" {
+		return luceneLeafValues(log, nil)
+	}
+	return luceneFieldPath(log, strings.Split(field, "."), nil)
+}
+
+func luceneFieldPath(value any, path []string, values []any) []any {
+	if array, ok := value.([]any); ok {
+		for _, element := range array {
+			values = luceneFieldPath(element, path, values)
+		}
+		return values
+	}
+	if len(path) == 0 {
+		return append(values, value)
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return values
+	}
+	for i := len(path); i > 0; i-- {
+		if child, ok := object[strings.Join(path[:i], ".")]; ok {
+			values = luceneFieldPath(child, path[i:], values)
+		}
+	}
+	return values
+}
+
+func luceneLeafValues(value any, values []any) []any {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, child := range v {
+			values = luceneLeafValues(child, values)
+		}
+	case []any:
+		for _, child := range v {
+			values = luceneLeafValues(child, values)
+		}
+	default:
+		values = append(values, v)
+	}
+	return values
+}
+
+func luceneValueString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// luceneWords splits text into its words, the letters and digits between
+// punctuation and white space.
+func luceneWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func slicesEqualFold(a, b []string) bool {
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+type luceneTokenKind int
+
+const (
+	luceneTokenWord luceneTokenKind = iota
+	luceneTokenPhrase
+	luceneTokenRegex
+	luceneTokenRange
+	luceneTokenColon
+	luceneTokenOpen
+	luceneTokenClose
+	luceneTokenPlus
+	luceneTokenMinus
+	luceneTokenAnd
+	luceneTokenOr
+	luceneTokenNot
+)
+
+type luceneToken struct {
+	kind luceneTokenKind
+	text string
+}
+
+func tokenizeLucene(query string) ([]luceneToken, error) {
+	var tokens []luceneToken
+	runes := []rune(query)
+	// atStart is true where a + or - prefix can start a clause.
+	atStart := true
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			atStart = true
+			continue
+		case r == '(':
+			tokens = append(tokens, luceneToken{kind: luceneTokenOpen})
+			i++
+			atStart = true
+			continue
+		case r == ')':
+			tokens = append(tokens, luceneToken{kind: luceneTokenClose})
+			i++
+		case r == ':':
+			tokens = append(tokens, luceneToken{kind: luceneTokenColon})
+			i++
+			atStart = false
+			continue
+		case atStart && (r == '+' || r == '-' || r == '!') && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			kind := map[rune]luceneTokenKind{'+': luceneTokenPlus, '-': luceneTokenMinus, '!': luceneTokenNot}[r]
+			tokens = append(tokens, luceneToken{kind: kind})
+			i++
+			continue
+		case r == '"' || r == '/':
+			end, text, err := scanLuceneQuoted(runes, i, r)
+			if err != nil {
+				return nil, err
+			}
+			kind := luceneTokenPhrase
+			if r == '/' {
+				kind = luceneTokenRegex
+			}
+			tokens = append(tokens, luceneToken{kind: kind, text: text})
+			i = end
+		case r == '[' || r == '{':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' && runes[end] != '}' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("range starting at offset %d is not closed", i)
+			}
+			tokens = append(tokens, luceneToken{kind: luceneTokenRange, text: string(runes[i : end+1])})
+			i = end + 1
+		default:
+			var word strings.Builder
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`():"`, runes[i]) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				word.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, luceneWordToken(word.String()))
+		}
+		atStart = false
+	}
+	return tokens, nil
+}
+
+func luceneWordToken(word string) luceneToken {
+	switch word {
+	case "AND", "&&":
+		return luceneToken{kind: luceneTokenAnd}
+	case "OR", "||":
+		return luceneToken{kind: luceneTokenOr}
+	case "NOT", "!":
+		return luceneToken{kind: luceneTokenNot}
+	}
+	return luceneToken{kind: luceneTokenWord, text: word}
+}
+
+func scanLuceneQuoted(runes []rune, start int, quote rune) (int, string, error) {
+	var text strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				if quote == '/' && runes[i] != '/' {
+					text.WriteRune('\\')
+				}
+			}
+		case quote:
+			return i + 1, text.String(), nil
+		}
+		text.WriteRune(runes[i])
+	}
+	return 0, "", fmt.Errorf("%c starting at offset %d is not closed", quote, start)
+}
+
+type luceneParser struct {
+	tokens []luceneToken
+	pos    int
+}
+
+// parseLuceneQuery parses a Lucene query. An empty query matches every log.
+func parseLuceneQuery(query string) (luceneQuery, error) {
+	if strings.TrimSpace(query) == "" {
+		return luceneMatchAll{}, nil
+	}
+	tokens, err := tokenizeLucene(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &luceneParser{tokens: tokens}
+	q, err := p.parseBoolean("")
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", ")")
+	}
+	return q, nil
+}
+
+func (p *luceneParser) peek() (luceneToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return luceneToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// parseBoolean parses clauses up to a closing parenthesis, applying AND, OR,
+// NOT, + and - the way the classic Lucene query parser does.
+func (p *luceneParser) parseBoolean(field string) (luceneQuery, error) {
+	var clauses luceneBoolean
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind == luceneTokenClose {
+			break
+		}
+
+		conjunction := token.kind
+		if conjunction == luceneTokenAnd || conjunction == luceneTokenOr {
+			p.pos++
+		}
+		occur := luceneShould
+		if token, ok := p.peek(); ok {
+			switch token.kind {
+			case luceneTokenNot, luceneTokenMinus:
+				occur = luceneMustNot
+				p.pos++
+			case luceneTokenPlus:
+				occur = luceneMust
+				p.pos++
+			}
+		}
+
+		query, err := p.parseClause(field)
+		if err != nil {
+			return nil, err
+		}
+
+		if conjunction == luceneTokenAnd {
+			if len(clauses) > 0 && clauses[len(clauses)-1].occur == luceneShould {
+				clauses[len(clauses)-1].occur = luceneMust
+			}
+			if occur == luceneShould {
+				occur = luceneMust
+			}
+		}
+		clauses = append(clauses, luceneClause{occur: occur, query: query})
+	}
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+	return clauses, nil
+}
+
+func (p *luceneParser) parseClause(field string) (luceneQuery, error) {
+	token, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("query ends with an operator")
+	}
+	p.pos++
+
+	switch token.kind {
+	case luceneTokenOpen:
+		query, err := p.parseBoolean(field)
+		if err != nil {
+			return nil, err
+		}
+		if token, ok := p.peek(); !ok || token.kind != luceneTokenClose {
+			return nil, fmt.Errorf("%q is not closed", "(")
+		}
+		p.pos++
+		return query, nil
+	case luceneTokenWord:
+		if next, ok := p.peek(); ok && next.kind == luceneTokenColon && field == "" {
+			p.pos++
+			if token.text == "_exists_" {
+				name, ok := p.peek()
+				if !ok || name.kind != luceneTokenWord {
+					return nil, fmt.Errorf("_exists_ must be followed by a field name")
+				}
+				p.pos++
+				return luceneExists{field: name.text}, nil
+			}
+			if token.text == "*" {
+				if value, ok := p.peek(); ok && value.kind == luceneTokenWord && value.text == "*" {
+					p.pos++
+					return luceneMatchAll{}, nil
+				}
+			}
+			return p.parseClause(token.text)
+		}
+		return newLuceneWordTerm(field, token.text)
+	case luceneTokenPhrase:
+		return luceneTerm{field: field, text: token.text}, nil
+	case luceneTokenRegex:
+		pattern, err := regexp.Compile("^(?:" + token.text + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression /%s/: %w", token.text, err)
+		}
+		return luceneTerm{field: field, pattern: pattern}, nil
+	case luceneTokenRange:
+		return newLuceneRange(field, token.text)
+	}
+	return nil, fmt.Errorf("unexpected operator in query")
+}
+
+func newLuceneWordTerm(field, word string) (luceneQuery, error) {
+	if word == "*" {
+		if field == "" {
+			return luceneMatchAll{}, nil
+		}
+		return luceneExists{field: field}, nil
+	}
+	for _, compare := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(word, compare) && len(word) > len(compare) {
+			return luceneTerm{field: field, text: word[len(compare):], compare: compare}, nil
+		}
+	}
+	if strings.ContainsAny(word, "*?") {
+		var pattern strings.Builder
+		pattern.WriteString("(?i)^")
+		for _, r := range word {
+			switch r {
+			case '*':
+				pattern.WriteString(".*")
+			case '?':
+				pattern.WriteString(".")
+			default:
+				pattern.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		pattern.WriteString("$")
+		return luceneTerm{field: field, pattern: regexp.MustCompile(pattern.String())}, nil
+	}
+	return luceneTerm{field: field, text: word}, nil
+}
+
+func newLuceneRange(field, text string) (luceneQuery, error) {
+	bounds := strings.Fields(text[1 : len(text)-1])
+	if len(bounds) != 3 || bounds[1] != "TO" {
+		return nil, fmt.Errorf("range %s must look like [lower TO upper]", text)
+	}
+	return luceneRange{
+		field:        field,
+		lower:        strings.Trim(bounds[0], `"`),
+		upper:        strings.Trim(bounds[2], `"`),
+		includeLower: text[0] == '[',
+		includeUpper: text[len(text)-1] == ']',
+	}, nil
+}
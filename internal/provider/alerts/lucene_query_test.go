@@ -0,0 +1,110 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseLuceneQuery(t *testing.T) {
+	const log = `{
+		"message": "Connection timeout after 30s to db-1.internal",
+		"status": 503,
+		"path": "/api/v1/orders",
+		"kubernetes": {"namespace": "checkout", "labels": [{"app": "api"}, {"app": "worker"}]},
+		"http.method": "POST",
+		"time": "2025-06-01T10:00:00Z"
+	}`
+	var document map[string]any
+	if err := json.Unmarshal([]byte(log), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		query string
+		want  bool
+	}{
+		{``, true},
+		{`*`, true},
+		{`*:*`, true},
+		{`timeout`, true},
+		{`TIMEOUT`, true},
+		{`time`, false},
+		{`"connection timeout"`, true},
+		{`"timeout connection"`, false},
+		{`message:timeout`, true},
+		{`message:"db-1.internal"`, true},
+		{`message:time*`, true},
+		{`message:tim?out`, true},
+		{`message:/time.*/`, true},
+		{`message:/time/`, false},
+		{`status:503`, true},
+		{`status:500`, false},
+		{`status:[500 TO 599]`, true},
+		{`status:{500 TO 503}`, false},
+		{`status:[500 TO *]`, true},
+		{`status:>=500`, true},
+		{`status:<500`, false},
+		{`time:[2025-06-01 TO 2025-06-02]`, true},
+		{`kubernetes.namespace:checkout`, true},
+		{`kubernetes.labels.app:worker`, true},
+		{`http.method:post`, true},
+		{`_exists_:kubernetes.namespace`, true},
+		{`_exists_:kubernetes.pod`, false},
+		{`path:*`, true},
+		{`path:"/api/v1/orders"`, true},
+		{`timeout AND status:500`, false},
+		{`timeout && status:503`, true},
+		{`timeout OR status:500`, true},
+		{`refused status:500`, false},
+		{`refused status:503`, true},
+		{`+refused status:503`, false},
+		{`timeout -status:503`, false},
+		{`NOT status:500`, true},
+		{`!status:503`, false},
+		{`timeout AND NOT status:503`, false},
+		{`status:(500 OR 503)`, true},
+		{`(refused OR timeout) AND kubernetes.namespace:checkout`, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			query, err := parseLuceneQuery(tc.query)
+			if err != nil {
+				t.Fatalf("parseLuceneQuery(%q) returned an error: %s", tc.query, err)
+			}
+			if got := query.matches(document); got != tc.want {
+				t.Errorf("parseLuceneQuery(%q).matches() = %v, want %v", tc.query, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseLuceneQueryErrors(t *testing.T) {
+	for _, query := range []string{
+		`(timeout`,
+		`timeout)`,
+		`"timeout`,
+		`status:[500 TO`,
+		`status:[500 599]`,
+		`message:/(/`,
+		`timeout AND`,
+		`_exists_:`,
+	} {
+		if _, err := parseLuceneQuery(query); err == nil {
+			t.Errorf("parseLuceneQuery(%q) returned no error", query)
+		}
+	}
+}
@@ -0,0 +1,576 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	alertschema "github.com/coralogix/terraform-provider-coralogix/internal/provider/alerts/alert_schema"
+	alerttypes "github.com/coralogix/terraform-provider-coralogix/internal/provider/alerts/alert_types"
+
+	cxsdkOpenapi "github.com/coralogix/coralogix-management-sdk/go/openapi/cxsdk"
+	alerts "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/alert_definitions_service"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ resource.ResourceWithConfigure  = &AlertsSetResource{}
+	_ resource.ResourceWithModifyPlan = &AlertsSetResource{}
+)
+
+type AlertsSetResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	YamlContent types.String `tfsdk:"yaml_content"`
+	Alerts      types.Map    `tfsdk:"alerts"` // map[string]types.String
+}
+
+func NewAlertsSetResource() resource.Resource {
+	return &AlertsSetResource{}
+}
+
+type AlertsSetResource struct {
+	client *alerts.AlertDefinitionsServiceAPIService
+}
+
+func (r *AlertsSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alerts_set"
+}
+
+func (r *AlertsSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientSet.Alerts()
+}
+
+func (r *AlertsSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Alerts set ID.",
+			},
+			"yaml_content": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					alertsSetYamlContentValidator{},
+				},
+				MarkdownDescription: "YAML or JSON document mapping a stable key to an alert definition. " +
+					"Each definition uses the attributes of `coralogix_alert` (except `id`). " +
+					"Alerts are created, replaced and deleted by key, so renaming a key replaces that alert. " +
+					"Alerts changed outside of Terraform are compared on the attributes their definition sets, and show as a change of `yaml_content` that replaces them.",
+			},
+			"alerts": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The ID of each managed alert, by its key in `yaml_content`.",
+			},
+		},
+		MarkdownDescription: "Manages a whole set of Coralogix alerts from a single YAML or JSON document. " +
+			"For more info check - https://coralogix.com/docs/getting-started-with-coralogix-alerts/.",
+	}
+}
+
+// ModifyPlan keeps the IDs of alerts whose key is still in the document, so the
+// plan only shows the keys that are added or removed.
+func (r *AlertsSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan AlertsSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.YamlContent.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("alerts"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	definitions, diags := parseAlertsSetDocument(plan.YamlContent.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids := map[string]string{}
+	if !req.State.Raw.IsNull() {
+		var state AlertsSetResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ids, diags = utils.TypeMapToStringMap(ctx, state.Alerts)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	alertIDs, diags := plannedAlertsSetIDs(definitions, ids)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("alerts"), alertIDs)...)
+}
+
+func (r *AlertsSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *AlertsSetResourceModel
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	definitions, diags := parseAlertsSetDocument(plan.YamlContent.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, diags := reconcileAlertsSet(ctx, r.client, "coralogix_alerts_set", nil, definitions, map[string]string{})
+	resp.Diagnostics.Append(diags...)
+
+	plan.ID = types.StringValue(uuid.NewString())
+	alertIDs, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	plan.Alerts = alertIDs
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *AlertsSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *AlertsSetResourceModel
+	if diags := req.State.Get(ctx, &state); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, diags := utils.TypeMapToStringMap(ctx, state.Alerts)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, alertDefs, diags := refreshAlertsSetIDs(ctx, r.client, "coralogix_alerts_set", ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	yamlContent, diags := refreshAlertsSetDocument(ctx, state.YamlContent.ValueString(), alertDefs)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	alertIDs, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	state.YamlContent = types.StringValue(yamlContent)
+	state.Alerts = alertIDs
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *AlertsSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *AlertsSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definitions, diags := parseAlertsSetDocument(plan.YamlContent.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	priorDefinitions, diags := parseAlertsSetDocument(state.YamlContent.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	ids, diags := utils.TypeMapToStringMap(ctx, state.Alerts)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, diags = reconcileAlertsSet(ctx, r.client, "coralogix_alerts_set", priorDefinitions, definitions, ids)
+	resp.Diagnostics.Append(diags...)
+
+	alertIDs, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	plan.Alerts = alertIDs
+	if resp.Diagnostics.HasError() {
+		// Keep the prior document so the next apply compares against what
+		// was actually applied, while still tracking the alerts that exist.
+		plan.YamlContent = state.YamlContent
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *AlertsSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AlertsSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, diags := utils.TypeMapToStringMap(ctx, state.Alerts)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(deleteAlertsSetIDs(ctx, r.client, "coralogix_alerts_set", ids)...)
+}
+
+// refreshAlertsSetIDs drops the IDs of alerts that no longer exist in Coralogix,
+// with a warning, so the next apply recreates them. It also returns the
+// definitions of the alerts that exist, by key.
+func refreshAlertsSetIDs(ctx context.Context, client *alerts.AlertDefinitionsServiceAPIService, resourceType string, ids map[string]string) (map[string]string, map[string]alerts.AlertDef, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	result := make(map[string]string, len(ids))
+	alertDefs := make(map[string]alerts.AlertDef, len(ids))
+	for _, key := range utils.GetKeys(ids) {
+		id := ids[key]
+		getAlertResp, httpResponse, err := client.AlertDefsServiceGetAlertDef(ctx, id).Execute()
+		if err == nil {
+			result[key] = id
+			alertDefs[key] = getAlertResp.GetAlertDef()
+			continue
+		}
+		if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+			diags.AddWarning(
+				fmt.Sprintf("%s alert %q (%s) is in state, but no longer exists in Coralogix backend", resourceType, key, id),
+				fmt.Sprintf("%s will be recreated when you apply", key),
+			)
+			continue
+		}
+		diags.AddError(fmt.Sprintf("Error reading %s", resourceType),
+			utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Read", id),
+		)
+		return nil, nil, diags
+	}
+	return result, alertDefs, diags
+}
+
+// refreshAlertsSetDocument compares each alert with its definition in the
+// document, the same way coralogix_alert compares it with its configuration.
+// The attributes a definition leaves out are not compared. The definitions of
+// alerts changed outside of Terraform are replaced by the remote values, so the
+// next plan shows the change and the next apply replaces those alerts.
+func refreshAlertsSetDocument(ctx context.Context, content string, alertDefs map[string]alerts.AlertDef) (string, diag.Diagnostics) {
+	definitions, diags := parseAlertsSetDocument(content)
+	if diags.HasError() {
+		return content, diags
+	}
+
+	drifted, dg := driftedAlertsSetDefinitions(ctx, definitions, alertDefs)
+	diags.Append(dg...)
+	if diags.HasError() || len(drifted) == 0 {
+		return content, diags
+	}
+
+	document, dg := replaceSetDocumentDefinitions(content, drifted)
+	diags.Append(dg...)
+	return document, diags
+}
+
+// driftedAlertsSetDefinitions returns the definitions of the alerts that
+// differ from their definition on the attributes it sets, updated with the
+// remote values, by key.
+func driftedAlertsSetDefinitions(ctx context.Context, definitions map[string]json.RawMessage, alertDefs map[string]alerts.AlertDef) (map[string]json.RawMessage, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	drifted := map[string]json.RawMessage{}
+	for _, key := range slices.Sorted(maps.Keys(alertDefs)) {
+		definition, ok := definitions[key]
+		if !ok {
+			continue
+		}
+		var model alerttypes.AlertResourceModel
+		if dg := utils.DecodeJSONWithSchema(ctx, alertschema.V3(), definition, &model); dg.HasError() {
+			diags.Append(alertsSetKeyDiagnostics(key, dg)...)
+			continue
+		}
+		remote, dg := flattenAlert(ctx, alertDefs[key], &model.Schedule, &model.NotificationGroup)
+		if dg.HasError() {
+			diags.Append(alertsSetKeyDiagnostics(key, dg)...)
+			continue
+		}
+		remoteDefinition, changed, dg := utils.DriftJSONWithSchema(ctx, alertschema.V3(), definition, remote)
+		if dg.HasError() {
+			diags.Append(alertsSetKeyDiagnostics(key, dg)...)
+			continue
+		}
+		if changed {
+			drifted[key] = remoteDefinition
+		}
+	}
+	return drifted, diags
+}
+
+// replaceSetDocumentDefinitions re-encodes a yaml_content document as YAML,
+// with the given definitions replaced.
+func replaceSetDocumentDefinitions(content string, definitions map[string]json.RawMessage) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var document map[string]any
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		diags.AddError("Error on unmarshal yaml_content", err.Error())
+		return content, diags
+	}
+	for key, definition := range definitions {
+		var decoded any
+		if err := json.Unmarshal(definition, &decoded); err != nil {
+			diags.AddError("Error on unmarshal yaml_content", err.Error())
+			return content, diags
+		}
+		document[key] = decoded
+	}
+	encoded, err := yaml.Marshal(document)
+	if err != nil {
+		diags.AddError("Error on marshal yaml_content", err.Error())
+		return content, diags
+	}
+	return string(encoded), diags
+}
+
+func deleteAlertsSetIDs(ctx context.Context, client *alerts.AlertDefinitionsServiceAPIService, resourceType string, ids map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, key := range utils.GetKeys(ids) {
+		id := ids[key]
+		_, httpResponse, err := client.AlertDefsServiceDeleteAlertDef(ctx, id).Execute()
+		if err != nil && !(httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound) {
+			diags.AddError(fmt.Sprintf("Error deleting alert %q of %s", key, resourceType),
+				utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Delete", id),
+			)
+		}
+	}
+	return diags
+}
+
+// reconcileAlertsSet creates the alerts that have no ID yet, replaces the ones
+// whose definition changed since the prior document and deletes the ones whose
+// key was removed. It returns the IDs of every alert that exists afterwards,
+// including when some of the calls failed.
+func reconcileAlertsSet(ctx context.Context, client *alerts.AlertDefinitionsServiceAPIService, resourceType string, prior, definitions map[string]json.RawMessage, ids map[string]string) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	result := make(map[string]string, len(definitions))
+
+	for _, key := range utils.GetKeys(ids) {
+		if _, ok := definitions[key]; ok {
+			continue
+		}
+		id := ids[key]
+		_, httpResponse, err := client.AlertDefsServiceDeleteAlertDef(ctx, id).Execute()
+		if err != nil && !(httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound) {
+			diags.AddError(fmt.Sprintf("Error deleting alert %q of %s", key, resourceType),
+				utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Delete", id),
+			)
+			result[key] = id
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(definitions)) {
+		id, exists := ids[key]
+		if exists && alertsSetDefinitionsEqual(prior[key], definitions[key]) {
+			result[key] = id
+			continue
+		}
+
+		alertProperties, dg := expandAlertsSetDefinition(ctx, key, definitions[key])
+		if dg.HasError() {
+			diags.Append(dg...)
+			if exists {
+				result[key] = id
+			}
+			continue
+		}
+
+		if exists {
+			rq := alerts.ReplaceAlertDefinitionRequest{
+				Id:                 &id,
+				AlertDefProperties: alertProperties,
+			}
+			_, httpResponse, err := client.AlertDefsServiceReplaceAlertDef(ctx).ReplaceAlertDefinitionRequest(rq).Execute()
+			if err != nil {
+				diags.AddError(fmt.Sprintf("Error replacing alert %q of %s", key, resourceType),
+					utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Replace", rq),
+				)
+			}
+			result[key] = id
+			continue
+		}
+
+		rq := alerts.CreateAlertDefinitionRequest{AlertDefProperties: alertProperties}
+		createResult, httpResponse, err := client.AlertDefsServiceCreateAlertDef(ctx).CreateAlertDefinitionRequest(rq).Execute()
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Error creating alert %q of %s", key, resourceType),
+				utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Create", rq),
+			)
+			continue
+		}
+		alertDef := createResult.GetAlertDef()
+		result[key] = alertDef.GetId()
+	}
+
+	return result, diags
+}
+
+// parseAlertsSetDocument splits a yaml_content document into the JSON encoding
+// of each alert definition, by key. JSON is valid YAML, so both are accepted.
+func parseAlertsSetDocument(content string) (map[string]json.RawMessage, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var document map[string]any
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		diags.AddError("Error on unmarshal yaml_content", err.Error())
+		return nil, diags
+	}
+
+	definitions := make(map[string]json.RawMessage, len(document))
+	for key, definition := range document {
+		if _, ok := definition.(map[string]any); !ok {
+			diags.AddError("Invalid yaml_content", fmt.Sprintf("alert %q must be a mapping of alert attributes", key))
+			continue
+		}
+		if _, ok := definition.(map[string]any)["id"]; ok {
+			diags.AddError("Invalid yaml_content", fmt.Sprintf("alert %q sets \"id\", which is computed by Coralogix", key))
+			continue
+		}
+		encoded, err := json.Marshal(definition)
+		if err != nil {
+			diags.AddError("Invalid yaml_content", fmt.Sprintf("alert %q: %s", key, err))
+			continue
+		}
+		definitions[key] = encoded
+	}
+
+	return definitions, diags
+}
+
+// expandAlertsSetDefinition decodes one definition against the coralogix_alert
+// schema and expands it the same way AlertResource does.
+func expandAlertsSetDefinition(ctx context.Context, key string, definition json.RawMessage) (*alerts.AlertDefProperties, diag.Diagnostics) {
+	var model alerttypes.AlertResourceModel
+	if diags := utils.DecodeJSONWithSchema(ctx, alertschema.V3(), definition, &model); diags.HasError() {
+		return nil, alertsSetKeyDiagnostics(key, diags)
+	}
+
+	alertProperties, diags := extractAlertProperties(ctx, &model)
+	if diags.HasError() {
+		return nil, alertsSetKeyDiagnostics(key, diags)
+	}
+	return alertProperties, nil
+}
+
+func alertsSetKeyDiagnostics(key string, diags diag.Diagnostics) diag.Diagnostics {
+	var keyed diag.Diagnostics
+	for _, d := range diags {
+		summary := fmt.Sprintf("alert %q: %s", key, d.Summary())
+		if d.Severity() == diag.SeverityError {
+			keyed.AddError(summary, d.Detail())
+		} else {
+			keyed.AddWarning(summary, d.Detail())
+		}
+	}
+	return keyed
+}
+
+func alertsSetDefinitionsEqual(prior, current json.RawMessage) bool {
+	if prior == nil {
+		return false
+	}
+	var p, c any
+	if json.Unmarshal(prior, &p) != nil || json.Unmarshal(current, &c) != nil {
+		return false
+	}
+	return reflect.DeepEqual(p, c)
+}
+
+func plannedAlertsSetIDs(definitions map[string]json.RawMessage, ids map[string]string) (types.Map, diag.Diagnostics) {
+	keys := slices.Sorted(maps.Keys(definitions))
+	elements := make(map[string]attr.Value, len(keys))
+	for _, key := range keys {
+		if id, ok := ids[key]; ok {
+			elements[key] = types.StringValue(id)
+		} else {
+			elements[key] = types.StringUnknown()
+		}
+	}
+	return types.MapValue(types.StringType, elements)
+}
+
+type alertsSetYamlContentValidator struct{}
+
+func (v alertsSetYamlContentValidator) Description(_ context.Context) string {
+	return "validate yaml_content"
+}
+
+func (v alertsSetYamlContentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v alertsSetYamlContentValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	definitions, diags := parseAlertsSetDocument(req.ConfigValue.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	for _, key := range slices.Sorted(maps.Keys(definitions)) {
+		var model alerttypes.AlertResourceModel
+		if diags := utils.DecodeJSONWithSchema(ctx, alertschema.V3(), definitions[key], &model); diags.HasError() {
+			resp.Diagnostics.Append(alertsSetKeyDiagnostics(key, diags)...)
+		}
+	}
+}
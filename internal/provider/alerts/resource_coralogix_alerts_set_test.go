@@ -0,0 +1,187 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	alerts "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/alert_definitions_service"
+)
+
+const alertsSetTestDocument = `
+errors:
+  name: Too many errors
+  priority: P2
+  type_definition:
+    logs_immediate:
+      logs_filter:
+        simple_filter:
+          lucene_query: "level:error"
+business-hours:
+  name: Business hours only
+  schedule:
+    active_on:
+      days_of_week: ["Monday", "Tuesday"]
+      start_time: "09:00"
+      end_time: "17:00"
+  type_definition:
+    logs_immediate: {}
+`
+
+func TestParseAlertsSetDocument(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		definitions, diags := parseAlertsSetDocument(alertsSetTestDocument)
+		if diags.HasError() {
+			t.Fatalf("parseAlertsSetDocument returned diagnostics: %v", diags)
+		}
+		if len(definitions) != 2 {
+			t.Fatalf("parseAlertsSetDocument returned %d definitions, want 2", len(definitions))
+		}
+		if _, ok := definitions["business-hours"]; !ok {
+			t.Errorf("definition %q is missing", "business-hours")
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		definitions, diags := parseAlertsSetDocument(`{"a": {"name": "A", "type_definition": {"logs_immediate": {}}}}`)
+		if diags.HasError() {
+			t.Fatalf("parseAlertsSetDocument returned diagnostics: %v", diags)
+		}
+		if len(definitions) != 1 {
+			t.Fatalf("parseAlertsSetDocument returned %d definitions, want 1", len(definitions))
+		}
+	})
+
+	t.Run("id is rejected", func(t *testing.T) {
+		_, diags := parseAlertsSetDocument("a:\n  id: abc\n  name: A\n")
+		if !diags.HasError() {
+			t.Fatal("expected an error for a definition that sets id")
+		}
+	})
+
+	t.Run("definition must be a mapping", func(t *testing.T) {
+		_, diags := parseAlertsSetDocument("a: not-an-alert\n")
+		if !diags.HasError() {
+			t.Fatal("expected an error for a scalar definition")
+		}
+	})
+}
+
+func TestExpandAlertsSetDefinition(t *testing.T) {
+	ctx := context.Background()
+	definitions, diags := parseAlertsSetDocument(alertsSetTestDocument)
+	if diags.HasError() {
+		t.Fatalf("parseAlertsSetDocument returned diagnostics: %v", diags)
+	}
+
+	properties, diags := expandAlertsSetDefinition(ctx, "errors", definitions["errors"])
+	if diags.HasError() {
+		t.Fatalf("expandAlertsSetDefinition returned diagnostics: %v", diags)
+	}
+	if properties.Name == nil || *properties.Name != "Too many errors" {
+		t.Errorf("Name = %v, want %q", properties.Name, "Too many errors")
+	}
+	if properties.Enabled == nil || !*properties.Enabled {
+		t.Errorf("Enabled = %v, want the schema default true", properties.Enabled)
+	}
+	if properties.Priority == nil || *properties.Priority != alerts.ALERTDEFPRIORITY_ALERT_DEF_PRIORITY_P2 {
+		t.Errorf("Priority = %v, want P2", properties.Priority)
+	}
+	if properties.LogsImmediate == nil {
+		t.Fatal("LogsImmediate is nil")
+	}
+
+	// utc_offset is left out and must fall back to the schema default.
+	properties, diags = expandAlertsSetDefinition(ctx, "business-hours", definitions["business-hours"])
+	if diags.HasError() {
+		t.Fatalf("expandAlertsSetDefinition returned diagnostics: %v", diags)
+	}
+	if properties.ActiveOn == nil || properties.ActiveOn.StartTime == nil || *properties.ActiveOn.StartTime.Hours != 9 {
+		t.Errorf("ActiveOn = %v, want a schedule starting at 09:00 UTC", properties.ActiveOn)
+	}
+
+	_, diags = expandAlertsSetDefinition(ctx, "broken", []byte(`{"name": "A", "unknown_attribute": true}`))
+	if !diags.HasError() {
+		t.Fatal("expected an error for an unknown attribute")
+	}
+}
+
+func TestPlannedAlertsSetIDs(t *testing.T) {
+	definitions, diags := parseAlertsSetDocument(alertsSetTestDocument)
+	if diags.HasError() {
+		t.Fatalf("parseAlertsSetDocument returned diagnostics: %v", diags)
+	}
+
+	ids, diags := plannedAlertsSetIDs(definitions, map[string]string{"errors": "id-1", "removed": "id-2"})
+	if diags.HasError() {
+		t.Fatalf("plannedAlertsSetIDs returned diagnostics: %v", diags)
+	}
+	elements := ids.Elements()
+	if len(elements) != 2 {
+		t.Fatalf("plannedAlertsSetIDs returned %d elements, want 2", len(elements))
+	}
+	if elements["errors"].String() != `"id-1"` {
+		t.Errorf("errors = %s, want the existing ID", elements["errors"])
+	}
+	if !elements["business-hours"].IsUnknown() {
+		t.Errorf("business-hours = %s, want unknown", elements["business-hours"])
+	}
+}
+
+func TestAlertsSetDefinitionsEqual(t *testing.T) {
+	cases := []struct {
+		name    string
+		prior   string
+		current string
+		want    bool
+	}{
+		{"key order is ignored", `{"a":1,"b":2}`, `{"b":2,"a":1}`, true},
+		{"changed value", `{"a":1}`, `{"a":2}`, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := alertsSetDefinitionsEqual([]byte(tc.prior), []byte(tc.current)); got != tc.want {
+				t.Errorf("alertsSetDefinitionsEqual() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if alertsSetDefinitionsEqual(nil, []byte(`{}`)) {
+		t.Error("a definition without a prior one must not be equal")
+	}
+}
+
+func TestReplaceSetDocumentDefinitions(t *testing.T) {
+	document, diags := replaceSetDocumentDefinitions(alertsSetTestDocument, map[string]json.RawMessage{
+		"errors": []byte(`{"name":"Too many errors","priority":"P1"}`),
+	})
+	if diags.HasError() {
+		t.Fatalf("replaceSetDocumentDefinitions returned diagnostics: %v", diags)
+	}
+
+	definitions, diags := parseAlertsSetDocument(document)
+	if diags.HasError() {
+		t.Fatalf("parseAlertsSetDocument returned diagnostics: %v", diags)
+	}
+	if !alertsSetDefinitionsEqual(definitions["errors"], []byte(`{"name":"Too many errors","priority":"P1"}`)) {
+		t.Errorf("errors = %s, want the replaced definition", definitions["errors"])
+	}
+	prior, _ := parseAlertsSetDocument(alertsSetTestDocument)
+	if !alertsSetDefinitionsEqual(prior["business-hours"], definitions["business-hours"]) {
+		t.Errorf("business-hours = %s, want %s", definitions["business-hours"], prior["business-hours"])
+	}
+}
@@ -0,0 +1,812 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	cxsdkOpenapi "github.com/coralogix/coralogix-management-sdk/go/openapi/cxsdk"
+	alertscheduler "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/alert_scheduler_rule_service"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.ResourceWithConfigure      = &MaintenanceWindowResource{}
+	_ resource.ResourceWithValidateConfig = &MaintenanceWindowResource{}
+	_ resource.ResourceWithModifyPlan     = &MaintenanceWindowResource{}
+
+	cronDaysOfWeek = map[string]time.Weekday{
+		"SUN": time.Sunday,
+		"MON": time.Monday,
+		"TUE": time.Tuesday,
+		"WED": time.Wednesday,
+		"THU": time.Thursday,
+		"FRI": time.Friday,
+		"SAT": time.Saturday,
+	}
+
+	entityLabelKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+)
+
+const defaultMaintenanceWindowWhatExpression = "source logs | filter true"
+
+type MaintenanceWindowResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Selector       types.Map    `tfsdk:"selector"`        // map[string]types.String
+	EntitySelector types.Map    `tfsdk:"entity_selector"` // map[string]types.String
+	WhatExpression types.String `tfsdk:"what_expression"`
+	StartTime      types.String `tfsdk:"start_time"`
+	EndTime        types.String `tfsdk:"end_time"`
+	Duration       types.String `tfsdk:"duration"`
+	Recurrence     types.Object `tfsdk:"recurrence"` // MaintenanceWindowRecurrenceModel
+}
+
+type MaintenanceWindowRecurrenceModel struct {
+	Cron  types.String `tfsdk:"cron"`
+	Until types.String `tfsdk:"until"`
+}
+
+func maintenanceWindowRecurrenceAttr() map[string]attr.Type {
+	return map[string]attr.Type{
+		"cron":  types.StringType,
+		"until": types.StringType,
+	}
+}
+
+// maintenanceWindowCron is the subset of a cron expression a scheduler rule can
+// repeat on: a fixed time of day, every day or on some days of the week or month.
+type maintenanceWindowCron struct {
+	Minute      int
+	Hour        int
+	DaysOfWeek  []time.Weekday
+	DaysOfMonth []int32
+}
+
+func NewMaintenanceWindowResource() resource.Resource {
+	return &MaintenanceWindowResource{}
+}
+
+type MaintenanceWindowResource struct {
+	client *alertscheduler.AlertSchedulerRuleServiceAPIService
+}
+
+func (r *MaintenanceWindowResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_maintenance_window"
+}
+
+func (r *MaintenanceWindowResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientSet.AlertSchedulers()
+}
+
+func (r *MaintenanceWindowResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of the alerts-scheduler rule that implements the maintenance window.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Maintenance window name.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maintenance window description.",
+			},
+			"selector": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.AtLeastOneOf(path.MatchRoot("entity_selector")),
+				},
+				MarkdownDescription: "Alert `labels` that select the alerts to mute. An empty value matches any value of the label. " +
+					"Alerts created while the window is active are muted as well. Without `selector`, `entity_selector` applies to every alert.",
+			},
+			"entity_selector": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(entityLabelKeyRegex, "must be a label name such as service or kubernetes.namespace")),
+					mapvalidator.ConflictsWith(path.MatchRoot("what_expression")),
+				},
+				MarkdownDescription: "Alert `entity_labels`, the group-by values of the alert events, that select the events to mute, for example `{ service = \"checkout\" }`. " +
+					"An empty value matches any value of the label. The selector is written to `what_expression`.",
+			},
+			"what_expression": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: fmt.Sprintf("DataPrime expression over group-by values to mute, as in `coralogix_alerts_scheduler`. "+
+					"Defaults to the expression of `entity_selector`, or to `%s`, which mutes every value.", defaultMaintenanceWindowWhatExpression),
+			},
+			"start_time": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "RFC 3339 start of the window, for example `2025-07-01T10:00:00Z`. " +
+					"`timestamp()` changes on every plan and must not be used. To start a window when it is applied, " +
+					"use `plantimestamp()` together with `lifecycle { ignore_changes = [start_time] }`. " +
+					"With `recurrence`, only the date is used and the window starts at the time of day of `recurrence.cron`.",
+			},
+			"end_time": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("duration")),
+				},
+				MarkdownDescription: "RFC 3339 end of the window. Cannot be used with `recurrence`.",
+			},
+			"duration": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("end_time")),
+				},
+				MarkdownDescription: "Length of the window as a duration of whole minutes, for example `30m` or `2h`.",
+			},
+			"recurrence": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"cron": schema.StringAttribute{
+						Required: true,
+						MarkdownDescription: "Cron expression (`minute hour day-of-month month day-of-week`, in UTC) that starts the window. " +
+							"Minute and hour must be single values, month must be `*`, and only one of day-of-month and day-of-week can be restricted, " +
+							"with lists, ranges and `SUN`-`SAT` names. For example `0 2 * * SAT` or `30 22 1,15 * *`.",
+					},
+					"until": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "RFC 3339 time after which the window no longer repeats.",
+					},
+				},
+				MarkdownDescription: "Repeats the window. Requires `duration`.",
+			},
+		},
+		MarkdownDescription: "Mutes the alerts selected by their labels for a time range or on a recurring schedule, through an alerts-scheduler rule. " +
+			"For more info please review - https://coralogix.com/docs/user-guides/alerting/alert-suppression-rules/.",
+	}
+}
+
+func (r *MaintenanceWindowResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data MaintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.StartTime.IsUnknown() && !data.StartTime.IsNull() {
+		if _, err := time.Parse(time.RFC3339, data.StartTime.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("start_time"), "Invalid start_time", err.Error())
+		}
+	}
+	if !data.EndTime.IsUnknown() && !data.EndTime.IsNull() {
+		if _, err := time.Parse(time.RFC3339, data.EndTime.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("end_time"), "Invalid end_time", err.Error())
+		}
+	}
+	if !data.Duration.IsUnknown() && !data.Duration.IsNull() {
+		if _, _, err := parseMaintenanceWindowDuration(data.Duration.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("duration"), "Invalid duration", err.Error())
+		}
+	}
+
+	if utils.ObjIsNullOrUnknown(data.Recurrence) {
+		return
+	}
+	if !data.EndTime.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("end_time"), "Invalid maintenance window",
+			"A recurring maintenance window needs duration instead of end_time.")
+	}
+	var recurrence MaintenanceWindowRecurrenceModel
+	if diags := data.Recurrence.As(ctx, &recurrence, basetypes.ObjectAsOptions{}); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	if !recurrence.Cron.IsUnknown() {
+		if _, err := parseMaintenanceWindowCron(recurrence.Cron.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("recurrence").AtName("cron"), "Invalid cron", err.Error())
+		}
+	}
+	if !recurrence.Until.IsUnknown() && !recurrence.Until.IsNull() {
+		if _, err := time.Parse(time.RFC3339, recurrence.Until.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("recurrence").AtName("until"), "Invalid until", err.Error())
+		}
+	}
+}
+
+// ModifyPlan computes an unset what_expression from entity_selector.
+func (r *MaintenanceWindowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan MaintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !config.WhatExpression.IsNull() {
+		return
+	}
+
+	whatExpression := types.StringUnknown()
+	if !plan.EntitySelector.IsUnknown() {
+		entitySelector, diags := utils.TypeMapToStringMap(ctx, plan.EntitySelector)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+		whatExpression = types.StringValue(maintenanceWindowWhatExpression(entitySelector))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("what_expression"), whatExpression)...)
+}
+
+func (r *MaintenanceWindowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *MaintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, diags := expandMaintenanceWindow(ctx, plan, nil)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	createRequest := alertscheduler.CreateAlertSchedulerRuleRequestDataStructure{
+		AlertSchedulerRule: rule,
+	}
+	createResp, httpResp, err := r.client.
+		AlertSchedulerRuleServiceCreateAlertSchedulerRule(ctx).
+		CreateAlertSchedulerRuleRequestDataStructure(createRequest).
+		Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating coralogix_maintenance_window",
+			utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResp, err), "Create", createRequest))
+		return
+	}
+
+	plan.ID = types.StringValue(createResp.AlertSchedulerRule.GetUniqueIdentifier())
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *MaintenanceWindowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *MaintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	getResp, httpResp, err := r.client.
+		AlertSchedulerRuleServiceGetAlertSchedulerRule(ctx, id).
+		Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			resp.Diagnostics.AddWarning(
+				fmt.Sprintf("coralogix_maintenance_window %q is in state, but no longer exists in Coralogix backend", id),
+				fmt.Sprintf("%s will be recreated when you apply", id),
+			)
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError("Error reading coralogix_maintenance_window",
+				utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResp, err), "Read", id),
+			)
+		}
+		return
+	}
+
+	state, diags := flattenMaintenanceWindow(ctx, getResp.GetAlertSchedulerRule(), state)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *MaintenanceWindowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *MaintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	rule, diags := expandMaintenanceWindow(ctx, plan, &id)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	updateRequest := alertscheduler.UpdateAlertSchedulerRuleRequestDataStructure{
+		AlertSchedulerRule: rule,
+	}
+	_, httpResp, err := r.client.
+		AlertSchedulerRuleServiceUpdateAlertSchedulerRule(ctx).
+		UpdateAlertSchedulerRuleRequestDataStructure(updateRequest).
+		Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating coralogix_maintenance_window",
+			utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResp, err), "Update", updateRequest),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *MaintenanceWindowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *MaintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	_, httpResp, err := r.client.
+		AlertSchedulerRuleServiceDeleteAlertSchedulerRule(ctx, id).
+		Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return
+		}
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error deleting coralogix_maintenance_window %s", id),
+			utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResp, err), "Delete", id),
+		)
+	}
+}
+
+// expandMaintenanceWindow builds the mute rule of the window. Times are sent in
+// UTC, the same way coralogix_alerts_scheduler sends a "UTC+0" time frame.
+func expandMaintenanceWindow(ctx context.Context, plan *MaintenanceWindowResourceModel, id *string) (alertscheduler.AlertSchedulerRule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	selector, dg := utils.TypeMapToStringMap(ctx, plan.Selector)
+	if dg.HasError() {
+		return alertscheduler.AlertSchedulerRule{}, dg
+	}
+	if len(selector) == 0 && plan.WhatExpression.ValueString() == defaultMaintenanceWindowWhatExpression {
+		// A filter without labels and expression would mute every alert.
+		diags.AddError("Invalid selector", "selector or entity_selector must contain at least one label")
+		return alertscheduler.AlertSchedulerRule{}, diags
+	}
+	filter := &alertscheduler.AlertSchedulerRuleProtobufV1Filter{
+		WhatExpression: alertscheduler.PtrString(plan.WhatExpression.ValueString()),
+	}
+	if len(selector) > 0 {
+		metaLabels := make([]alertscheduler.MetaLabelsProtobufV1MetaLabel, 0, len(selector))
+		for _, key := range utils.GetKeys(selector) {
+			label := alertscheduler.MetaLabelsProtobufV1MetaLabel{Key: alertscheduler.PtrString(key)}
+			if value := selector[key]; value != "" {
+				label.Value = alertscheduler.PtrString(value)
+			}
+			metaLabels = append(metaLabels, label)
+		}
+		filter.AlertMetaLabels = &alertscheduler.MetaLabels{Value: metaLabels}
+	}
+
+	startTime, err := time.Parse(time.RFC3339, plan.StartTime.ValueString())
+	if err != nil {
+		diags.AddError("Invalid start_time", err.Error())
+		return alertscheduler.AlertSchedulerRule{}, diags
+	}
+
+	timeFrame := &alertscheduler.Timeframe{
+		Timezone: alertscheduler.PtrString("UTC+0"),
+	}
+	if !plan.Duration.IsNull() {
+		forOver, frequency, err := parseMaintenanceWindowDuration(plan.Duration.ValueString())
+		if err != nil {
+			diags.AddError("Invalid duration", err.Error())
+			return alertscheduler.AlertSchedulerRule{}, diags
+		}
+		durationFrequency := schemaToProtoDurationFrequency[frequency]
+		timeFrame.Duration = &alertscheduler.V1Duration{
+			ForOver:   alertscheduler.PtrInt32(forOver),
+			Frequency: &durationFrequency,
+		}
+	} else {
+		endTime, err := time.Parse(time.RFC3339, plan.EndTime.ValueString())
+		if err != nil {
+			diags.AddError("Invalid end_time", err.Error())
+			return alertscheduler.AlertSchedulerRule{}, diags
+		}
+		timeFrame.EndTime = alertscheduler.PtrString(endTime.UTC().Format(startTimeFormatCanonical))
+	}
+
+	operation := alertscheduler.SCHEDULEOPERATION_SCHEDULE_OPERATION_MUTE
+	schedule := &alertscheduler.Schedule{
+		ScheduleOperation: &operation,
+	}
+	if utils.ObjIsNullOrUnknown(plan.Recurrence) {
+		timeFrame.StartTime = alertscheduler.PtrString(startTime.UTC().Format(startTimeFormatCanonical))
+		schedule.OneTime = &alertscheduler.OneTime{Timeframe: timeFrame}
+	} else {
+		dynamic, dg := expandMaintenanceWindowRecurrence(ctx, plan.Recurrence, startTime, timeFrame)
+		if dg.HasError() {
+			return alertscheduler.AlertSchedulerRule{}, dg
+		}
+		schedule.Recurring = &alertscheduler.Recurring{Schedule: dynamic}
+	}
+
+	return alertscheduler.AlertSchedulerRule{
+		UniqueIdentifier: id,
+		Name:             alertscheduler.PtrString(plan.Name.ValueString()),
+		Description:      utils.TypeStringToStringPointer(plan.Description),
+		Filter:           filter,
+		Schedule:         schedule,
+		Enabled:          alertscheduler.PtrBool(true),
+	}, nil
+}
+
+// maintenanceWindowWhatExpression builds the DataPrime expression muting the
+// group-by values matching an entity_selector.
+func maintenanceWindowWhatExpression(entitySelector map[string]string) string {
+	if len(entitySelector) == 0 {
+		return defaultMaintenanceWindowWhatExpression
+	}
+	quote := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	conditions := make([]string, 0, len(entitySelector))
+	for _, key := range slices.Sorted(maps.Keys(entitySelector)) {
+		if value := entitySelector[key]; value != "" {
+			conditions = append(conditions, fmt.Sprintf("$d.%s == '%s'", key, quote.Replace(value)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("$d.%s != null", key))
+		}
+	}
+	return "source logs | filter " + strings.Join(conditions, " && ")
+}
+
+func expandMaintenanceWindowRecurrence(ctx context.Context, recurrence types.Object, startTime time.Time, timeFrame *alertscheduler.Timeframe) (*alertscheduler.RecurringDynamic, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var recurrenceModel MaintenanceWindowRecurrenceModel
+	if dg := recurrence.As(ctx, &recurrenceModel, basetypes.ObjectAsOptions{}); dg.HasError() {
+		return nil, dg
+	}
+
+	cron, err := parseMaintenanceWindowCron(recurrenceModel.Cron.ValueString())
+	if err != nil {
+		diags.AddError("Invalid recurrence.cron", err.Error())
+		return nil, diags
+	}
+	if timeFrame.Duration == nil {
+		diags.AddError("Invalid maintenance window", "A recurring maintenance window needs duration instead of end_time.")
+		return nil, diags
+	}
+
+	startDate := startTime.UTC()
+	firstStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), cron.Hour, cron.Minute, 0, 0, time.UTC)
+	timeFrame.StartTime = alertscheduler.PtrString(firstStart.Format(startTimeFormatCanonical))
+
+	dynamic := &alertscheduler.RecurringDynamic{
+		Timeframe:   timeFrame,
+		RepeatEvery: alertscheduler.PtrInt32(1),
+	}
+	if !(recurrenceModel.Until.IsNull() || recurrenceModel.Until.IsUnknown()) {
+		until, err := time.Parse(time.RFC3339, recurrenceModel.Until.ValueString())
+		if err != nil {
+			diags.AddError("Invalid recurrence.until", err.Error())
+			return nil, diags
+		}
+		dynamic.TerminationDate = alertscheduler.PtrString(until.UTC().Format(startTimeFormatCanonical))
+	}
+
+	switch {
+	case len(cron.DaysOfWeek) > 0:
+		days := make([]int32, 0, len(cron.DaysOfWeek))
+		for _, day := range cron.DaysOfWeek {
+			days = append(days, daysToProtoValue[day.String()])
+		}
+		dynamic.Weekly = &alertscheduler.Weekly{DaysOfWeek: days}
+	case len(cron.DaysOfMonth) > 0:
+		dynamic.Monthly = &alertscheduler.Monthly{DaysOfMonth: cron.DaysOfMonth}
+	default:
+		dynamic.Daily = make(map[string]interface{})
+	}
+	return dynamic, nil
+}
+
+// parseMaintenanceWindowDuration splits a duration into the largest scheduler
+// unit that expresses it exactly.
+func parseMaintenanceWindowDuration(value string) (int32, string, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, "", err
+	}
+	if duration <= 0 || duration%time.Minute != 0 {
+		return 0, "", fmt.Errorf("%q must be a positive number of whole minutes", value)
+	}
+
+	day := 24 * time.Hour
+	switch {
+	case duration%day == 0:
+		return int32(duration / day), "days", nil
+	case duration%time.Hour == 0:
+		return int32(duration / time.Hour), "hours", nil
+	default:
+		return int32(duration / time.Minute), "minutes", nil
+	}
+}
+
+func parseMaintenanceWindowCron(expression string) (maintenanceWindowCron, error) {
+	var cron maintenanceWindowCron
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return cron, fmt.Errorf("%q must have 5 fields: minute hour day-of-month month day-of-week", expression)
+	}
+
+	var err error
+	if cron.Minute, err = strconv.Atoi(fields[0]); err != nil || cron.Minute < 0 || cron.Minute > 59 {
+		return cron, fmt.Errorf("minute %q must be a single value between 0 and 59", fields[0])
+	}
+	if cron.Hour, err = strconv.Atoi(fields[1]); err != nil || cron.Hour < 0 || cron.Hour > 23 {
+		return cron, fmt.Errorf("hour %q must be a single value between 0 and 23", fields[1])
+	}
+	if fields[3] != "*" {
+		return cron, fmt.Errorf("month %q is not supported, it must be *", fields[3])
+	}
+	if fields[2] != "*" && fields[4] != "*" {
+		return cron, fmt.Errorf("only one of day-of-month and day-of-week can be restricted")
+	}
+
+	if fields[2] != "*" {
+		days, err := parseCronList(fields[2], 1, 31, nil)
+		if err != nil {
+			return cron, fmt.Errorf("day-of-month: %w", err)
+		}
+		for _, day := range days {
+			cron.DaysOfMonth = append(cron.DaysOfMonth, int32(day))
+		}
+	}
+	if fields[4] != "*" {
+		days, err := parseCronList(fields[4], 0, 7, cronDaysOfWeek)
+		if err != nil {
+			return cron, fmt.Errorf("day-of-week: %w", err)
+		}
+		for _, day := range days {
+			weekday := time.Weekday(day % 7)
+			if !slices.Contains(cron.DaysOfWeek, weekday) {
+				cron.DaysOfWeek = append(cron.DaysOfWeek, weekday)
+			}
+		}
+	}
+	return cron, nil
+}
+
+// parseCronList parses a comma separated list of values and ranges, without steps.
+func parseCronList(field string, minValue, maxValue int, names map[string]time.Weekday) ([]int, error) {
+	parseValue := func(value string) (int, error) {
+		if day, ok := names[strings.ToUpper(value)]; ok {
+			return int(day), nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < minValue || n > maxValue {
+			return 0, fmt.Errorf("%q must be between %d and %d", value, minValue, maxValue)
+		}
+		return n, nil
+	}
+
+	var values []int
+	for _, part := range strings.Split(field, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, err := parseValue(from)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = parseValue(to); err != nil {
+				return nil, err
+			}
+			if last < first {
+				return nil, fmt.Errorf("range %q is reversed", part)
+			}
+		}
+		for value := first; value <= last; value++ {
+			if !slices.Contains(values, value) {
+				values = append(values, value)
+			}
+		}
+	}
+	return values, nil
+}
+
+// maintenanceWindowTimes is the time range of a scheduler rule, compared
+// between the rule expanded from the state and the rule read from Coralogix.
+type maintenanceWindowTimes struct {
+	Timezone    string
+	Start       int64
+	End         int64
+	ForOver     int32
+	Frequency   string
+	Recurring   bool
+	Until       int64
+	DaysOfWeek  []int32
+	DaysOfMonth []int32
+}
+
+func maintenanceWindowScheduleTimes(schedule *alertscheduler.Schedule) (maintenanceWindowTimes, bool) {
+	var times maintenanceWindowTimes
+	parseUnix := func(value string) int64 {
+		t, ok := parseStartTime(value)
+		if !ok {
+			return 0
+		}
+		return t.Unix()
+	}
+
+	var timeFrame *alertscheduler.Timeframe
+	switch {
+	case schedule == nil:
+		return times, false
+	case schedule.OneTime != nil:
+		timeFrame = schedule.OneTime.Timeframe
+	case schedule.Recurring != nil && schedule.Recurring.Schedule != nil:
+		dynamic := schedule.Recurring.Schedule
+		timeFrame = dynamic.Timeframe
+		times.Recurring = true
+		times.Until = parseUnix(dynamic.GetTerminationDate())
+		if dynamic.Weekly != nil {
+			times.DaysOfWeek = slices.Sorted(slices.Values(dynamic.Weekly.GetDaysOfWeek()))
+		}
+		if dynamic.Monthly != nil {
+			times.DaysOfMonth = slices.Sorted(slices.Values(dynamic.Monthly.GetDaysOfMonth()))
+		}
+	}
+	if timeFrame == nil {
+		return times, false
+	}
+
+	times.Timezone = timeFrame.GetTimezone()
+	if timeZoneEquivalent(times.Timezone, "UTC+0") {
+		times.Timezone = "UTC+0"
+	}
+	times.Start = parseUnix(timeFrame.GetStartTime())
+	if timeFrame.EndTime != nil {
+		times.End = parseUnix(timeFrame.GetEndTime())
+	}
+	if timeFrame.Duration != nil {
+		times.ForOver = timeFrame.Duration.GetForOver()
+		times.Frequency = protoToSchemaDurationFrequency[timeFrame.Duration.GetFrequency()]
+	}
+	return times, true
+}
+
+// flattenMaintenanceWindow refreshes state from the scheduler rule. Values that
+// are equivalent to the state, such as a start_time with another UTC offset,
+// are kept as written in the configuration.
+func flattenMaintenanceWindow(ctx context.Context, rule alertscheduler.AlertSchedulerRule, state *MaintenanceWindowResourceModel) (*MaintenanceWindowResourceModel, diag.Diagnostics) {
+	result := *state
+	result.Name = types.StringValue(rule.GetName())
+	if rule.GetDescription() != "" || !state.Description.IsNull() {
+		result.Description = types.StringValue(rule.GetDescription())
+	}
+
+	filter := rule.GetFilter()
+	result.WhatExpression = types.StringValue(filter.GetWhatExpression())
+	selector := map[string]string{}
+	if filter.AlertMetaLabels != nil {
+		for _, label := range filter.AlertMetaLabels.GetValue() {
+			selector[label.GetKey()] = label.GetValue()
+		}
+	}
+	if len(selector) > 0 || !state.Selector.IsNull() {
+		selectorValue, diags := types.MapValueFrom(ctx, types.StringType, selector)
+		if diags.HasError() {
+			return nil, diags
+		}
+		result.Selector = selectorValue
+	}
+
+	remoteTimes, ok := maintenanceWindowScheduleTimes(rule.Schedule)
+	if !ok {
+		return &result, diag.Diagnostics{diag.NewErrorDiagnostic("Error reading coralogix_maintenance_window", "the alerts-scheduler rule is not a one-time or recurring mute rule")}
+	}
+	if expanded, diags := expandMaintenanceWindow(ctx, state, nil); !diags.HasError() {
+		if stateTimes, ok := maintenanceWindowScheduleTimes(expanded.Schedule); ok && reflect.DeepEqual(stateTimes, remoteTimes) {
+			return &result, nil
+		}
+	}
+
+	result.StartTime = types.StringValue(time.Unix(remoteTimes.Start, 0).UTC().Format(time.RFC3339))
+	result.EndTime, result.Duration = types.StringNull(), types.StringNull()
+	if remoteTimes.ForOver > 0 {
+		result.Duration = types.StringValue(formatMaintenanceWindowDuration(remoteTimes.ForOver, remoteTimes.Frequency))
+	} else {
+		result.EndTime = types.StringValue(time.Unix(remoteTimes.End, 0).UTC().Format(time.RFC3339))
+	}
+
+	result.Recurrence = types.ObjectNull(maintenanceWindowRecurrenceAttr())
+	if remoteTimes.Recurring {
+		start := time.Unix(remoteTimes.Start, 0).UTC()
+		daysOfMonth, daysOfWeek := "*", "*"
+		if len(remoteTimes.DaysOfMonth) > 0 {
+			days := make([]string, 0, len(remoteTimes.DaysOfMonth))
+			for _, day := range remoteTimes.DaysOfMonth {
+				days = append(days, strconv.Itoa(int(day)))
+			}
+			daysOfMonth = strings.Join(days, ",")
+		}
+		if len(remoteTimes.DaysOfWeek) > 0 {
+			days := make([]string, 0, len(remoteTimes.DaysOfWeek))
+			for _, day := range remoteTimes.DaysOfWeek {
+				days = append(days, strings.ToUpper(protoToDaysValue[day][:3]))
+			}
+			daysOfWeek = strings.Join(days, ",")
+		}
+		recurrence := MaintenanceWindowRecurrenceModel{
+			Cron:  types.StringValue(fmt.Sprintf("%d %d %s * %s", start.Minute(), start.Hour(), daysOfMonth, daysOfWeek)),
+			Until: types.StringNull(),
+		}
+		if remoteTimes.Until != 0 {
+			recurrence.Until = types.StringValue(time.Unix(remoteTimes.Until, 0).UTC().Format(time.RFC3339))
+		}
+		var diags diag.Diagnostics
+		result.Recurrence, diags = types.ObjectValueFrom(ctx, maintenanceWindowRecurrenceAttr(), recurrence)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+	return &result, nil
+}
+
+// formatMaintenanceWindowDuration is the inverse of parseMaintenanceWindowDuration.
+func formatMaintenanceWindowDuration(forOver int32, frequency string) string {
+	switch frequency {
+	case "days":
+		return fmt.Sprintf("%dh", forOver*24)
+	case "hours":
+		return fmt.Sprintf("%dh", forOver)
+	default:
+		return fmt.Sprintf("%dm", forOver)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	alertscheduler "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/alert_scheduler_rule_service"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestParseMaintenanceWindowDuration(t *testing.T) {
+	cases := []struct {
+		value     string
+		forOver   int32
+		frequency string
+		wantError bool
+	}{
+		{value: "30m", forOver: 30, frequency: "minutes"},
+		{value: "90m", forOver: 90, frequency: "minutes"},
+		{value: "2h", forOver: 2, frequency: "hours"},
+		{value: "48h", forOver: 2, frequency: "days"},
+		{value: "30s", wantError: true},
+		{value: "0m", wantError: true},
+		{value: "-1h", wantError: true},
+		{value: "soon", wantError: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			forOver, frequency, err := parseMaintenanceWindowDuration(tc.value)
+			if tc.wantError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMaintenanceWindowDuration returned an error: %s", err)
+			}
+			if forOver != tc.forOver || frequency != tc.frequency {
+				t.Errorf("parseMaintenanceWindowDuration() = (%d, %q), want (%d, %q)", forOver, frequency, tc.forOver, tc.frequency)
+			}
+		})
+	}
+}
+
+func TestParseMaintenanceWindowCron(t *testing.T) {
+	cases := []struct {
+		expression string
+		want       maintenanceWindowCron
+		wantError  bool
+	}{
+		{expression: "0 2 * * *", want: maintenanceWindowCron{Minute: 0, Hour: 2}},
+		{expression: "30 22 * * SAT,sun", want: maintenanceWindowCron{Minute: 30, Hour: 22, DaysOfWeek: []time.Weekday{time.Saturday, time.Sunday}}},
+		{expression: "0 9 * * 1-5", want: maintenanceWindowCron{Hour: 9, DaysOfWeek: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}}},
+		{expression: "0 0 * * 0,7", want: maintenanceWindowCron{DaysOfWeek: []time.Weekday{time.Sunday}}},
+		{expression: "15 3 1,15 * *", want: maintenanceWindowCron{Minute: 15, Hour: 3, DaysOfMonth: []int32{1, 15}}},
+		{expression: "*/5 * * * *", wantError: true},
+		{expression: "0 2 * 1 *", wantError: true},
+		{expression: "0 2 1 * MON", wantError: true},
+		{expression: "0 24 * * *", wantError: true},
+		{expression: "0 2 32 * *", wantError: true},
+		{expression: "0 2 * * 5-1", wantError: true},
+		{expression: "0 2 * *", wantError: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.expression, func(t *testing.T) {
+			got, err := parseMaintenanceWindowCron(tc.expression)
+			if tc.wantError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMaintenanceWindowCron returned an error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("parseMaintenanceWindowCron() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpandMaintenanceWindow(t *testing.T) {
+	ctx := context.Background()
+	selector := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"service": types.StringValue("checkout"),
+		"team":    types.StringValue(""),
+	})
+
+	t.Run("one time", func(t *testing.T) {
+		plan := &MaintenanceWindowResourceModel{
+			Name:           types.StringValue("checkout deploy"),
+			Description:    types.StringNull(),
+			Selector:       selector,
+			EntitySelector: types.MapNull(types.StringType),
+			WhatExpression: types.StringValue(defaultMaintenanceWindowWhatExpression),
+			StartTime:      types.StringValue("2025-07-01T12:00:00+02:00"),
+			EndTime:        types.StringNull(),
+			Duration:       types.StringValue("30m"),
+			Recurrence:     types.ObjectNull(map[string]attr.Type{"cron": types.StringType, "until": types.StringType}),
+		}
+		rule, diags := expandMaintenanceWindow(ctx, plan, nil)
+		if diags.HasError() {
+			t.Fatalf("expandMaintenanceWindow returned diagnostics: %v", diags)
+		}
+		if *rule.Schedule.ScheduleOperation != alertscheduler.SCHEDULEOPERATION_SCHEDULE_OPERATION_MUTE {
+			t.Errorf("ScheduleOperation = %v, want mute", *rule.Schedule.ScheduleOperation)
+		}
+		labels := rule.Filter.AlertMetaLabels.Value
+		if len(labels) != 2 || *labels[0].Key != "service" || *labels[0].Value != "checkout" || labels[1].Value != nil {
+			t.Errorf("AlertMetaLabels = %+v, want service=checkout and team without a value", labels)
+		}
+		timeFrame := rule.Schedule.OneTime.Timeframe
+		if *timeFrame.StartTime != "2025-07-01T10:00:00.000" || *timeFrame.Duration.ForOver != 30 {
+			t.Errorf("Timeframe = %+v, want 30 minutes from 10:00 UTC", timeFrame)
+		}
+	})
+
+	t.Run("recurring", func(t *testing.T) {
+		plan := &MaintenanceWindowResourceModel{
+			Name:           types.StringValue("weekly maintenance"),
+			Description:    types.StringNull(),
+			Selector:       selector,
+			EntitySelector: types.MapNull(types.StringType),
+			WhatExpression: types.StringValue(defaultMaintenanceWindowWhatExpression),
+			StartTime:      types.StringValue("2025-07-01T00:00:00Z"),
+			EndTime:        types.StringNull(),
+			Duration:       types.StringValue("2h"),
+			Recurrence: types.ObjectValueMust(map[string]attr.Type{"cron": types.StringType, "until": types.StringType}, map[string]attr.Value{
+				"cron":  types.StringValue("30 22 * * SAT"),
+				"until": types.StringNull(),
+			}),
+		}
+		rule, diags := expandMaintenanceWindow(ctx, plan, nil)
+		if diags.HasError() {
+			t.Fatalf("expandMaintenanceWindow returned diagnostics: %v", diags)
+		}
+		dynamic := rule.Schedule.Recurring.Schedule
+		if *dynamic.Timeframe.StartTime != "2025-07-01T22:30:00.000" {
+			t.Errorf("StartTime = %q, want the cron time of day on the start date", *dynamic.Timeframe.StartTime)
+		}
+		if dynamic.Weekly == nil || !reflect.DeepEqual(dynamic.Weekly.DaysOfWeek, []int32{daysToProtoValue["Saturday"]}) {
+			t.Errorf("Weekly = %+v, want Saturday", dynamic.Weekly)
+		}
+	})
+}
+
+func TestMaintenanceWindowWhatExpression(t *testing.T) {
+	if got := maintenanceWindowWhatExpression(nil); got != defaultMaintenanceWindowWhatExpression {
+		t.Errorf("maintenanceWindowWhatExpression(nil) = %q, want %q", got, defaultMaintenanceWindowWhatExpression)
+	}
+	got := maintenanceWindowWhatExpression(map[string]string{"service": "it's", "kubernetes.namespace": ""})
+	want := `source logs | filter $d.kubernetes.namespace != null && $d.service == 'it\'s'`
+	if got != want {
+		t.Errorf("maintenanceWindowWhatExpression() = %q, want %q", got, want)
+	}
+}
+
+func TestFlattenMaintenanceWindow(t *testing.T) {
+	ctx := context.Background()
+	recurrenceAttr := maintenanceWindowRecurrenceAttr()
+	state := &MaintenanceWindowResourceModel{
+		ID:             types.StringValue("rule-id"),
+		Name:           types.StringValue("weekly maintenance"),
+		Description:    types.StringNull(),
+		Selector:       types.MapValueMust(types.StringType, map[string]attr.Value{"service": types.StringValue("checkout")}),
+		EntitySelector: types.MapNull(types.StringType),
+		WhatExpression: types.StringValue(defaultMaintenanceWindowWhatExpression),
+		StartTime:      types.StringValue("2025-07-01T02:00:00+02:00"),
+		EndTime:        types.StringNull(),
+		Duration:       types.StringValue("120m"),
+		Recurrence: types.ObjectValueMust(recurrenceAttr, map[string]attr.Value{
+			"cron":  types.StringValue("30 22 * * SAT,SUN"),
+			"until": types.StringNull(),
+		}),
+	}
+	id := "rule-id"
+	rule, diags := expandMaintenanceWindow(ctx, state, &id)
+	if diags.HasError() {
+		t.Fatalf("expandMaintenanceWindow returned diagnostics: %v", diags)
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		got, diags := flattenMaintenanceWindow(ctx, rule, state)
+		if diags.HasError() {
+			t.Fatalf("flattenMaintenanceWindow returned diagnostics: %v", diags)
+		}
+		if !reflect.DeepEqual(got, state) {
+			t.Errorf("flattenMaintenanceWindow() = %+v, want the state unchanged", got)
+		}
+	})
+
+	t.Run("changed outside of terraform", func(t *testing.T) {
+		changed := rule
+		changed.Filter = &alertscheduler.AlertSchedulerRuleProtobufV1Filter{
+			WhatExpression: alertscheduler.PtrString(defaultMaintenanceWindowWhatExpression),
+			AlertMetaLabels: &alertscheduler.MetaLabels{Value: []alertscheduler.MetaLabelsProtobufV1MetaLabel{
+				{Key: alertscheduler.PtrString("service"), Value: alertscheduler.PtrString("payments")},
+			}},
+		}
+		dynamic := *rule.Schedule.Recurring.Schedule
+		timeFrame := *dynamic.Timeframe
+		timeFrame.StartTime = alertscheduler.PtrString("2025-07-01T23:00:00.000")
+		dynamic.Timeframe = &timeFrame
+		changed.Schedule = &alertscheduler.Schedule{
+			ScheduleOperation: rule.Schedule.ScheduleOperation,
+			Recurring:         &alertscheduler.Recurring{Schedule: &dynamic},
+		}
+
+		got, diags := flattenMaintenanceWindow(ctx, changed, state)
+		if diags.HasError() {
+			t.Fatalf("flattenMaintenanceWindow returned diagnostics: %v", diags)
+		}
+		if got.Selector.Elements()["service"].(types.String).ValueString() != "payments" {
+			t.Errorf("Selector = %s, want service=payments", got.Selector)
+		}
+		if got.StartTime.ValueString() != "2025-07-01T23:00:00Z" || got.Duration.ValueString() != "2h" {
+			t.Errorf("StartTime, Duration = %s, %s, want 2025-07-01T23:00:00Z, 2h", got.StartTime, got.Duration)
+		}
+		var recurrence MaintenanceWindowRecurrenceModel
+		got.Recurrence.As(ctx, &recurrence, basetypes.ObjectAsOptions{})
+		if recurrence.Cron.ValueString() != "0 23 * * SUN,SAT" {
+			t.Errorf("recurrence.cron = %s, want 0 23 * * SUN,SAT", recurrence.Cron)
+		}
+	})
+}
@@ -0,0 +1,607 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	alerttypes "github.com/coralogix/terraform-provider-coralogix/internal/provider/alerts/alert_types"
+
+	alerts "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/alert_definitions_service"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ resource.ResourceWithConfigure  = &PrometheusAlertRulesResource{}
+	_ resource.ResourceWithModifyPlan = &PrometheusAlertRulesResource{}
+)
+
+// prometheusRuleFile accepts both a Prometheus rule file and a PrometheusRule
+// custom resource, which keeps the same groups under spec.
+type prometheusRuleFile struct {
+	Groups []prometheusRuleGroup `yaml:"groups"`
+	Spec   struct {
+		Groups []prometheusRuleGroup `yaml:"groups"`
+	} `yaml:"spec"`
+}
+
+type prometheusRuleGroup struct {
+	Name  string           `yaml:"name"`
+	Rules []prometheusRule `yaml:"rules"`
+}
+
+type prometheusRule struct {
+	Alert       string            `yaml:"alert"`
+	Record      string            `yaml:"record"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for"`
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+type PrometheusAlertRulesResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	YamlContent        types.String `tfsdk:"yaml_content"`
+	SeverityLabel      types.String `tfsdk:"severity_label"`
+	SeverityPriorities types.Map    `tfsdk:"severity_priorities"` // map[string]types.String
+	Alerts             types.Map    `tfsdk:"alerts"`              // map[string]types.String
+	DriftedAlerts      types.Set    `tfsdk:"drifted_alerts"`      // set[types.String]
+}
+
+func NewPrometheusAlertRulesResource() resource.Resource {
+	return &PrometheusAlertRulesResource{}
+}
+
+type PrometheusAlertRulesResource struct {
+	client *alerts.AlertDefinitionsServiceAPIService
+}
+
+func (r *PrometheusAlertRulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prometheus_alert_rules"
+}
+
+func (r *PrometheusAlertRulesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientSet.Alerts()
+}
+
+func (r *PrometheusAlertRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	defaultPriorities := make(map[string]attr.Value, len(severityPriorities))
+	for severity, priority := range severityPriorities {
+		defaultPriorities[severity] = types.StringValue(priority)
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Prometheus alert rules ID.",
+			},
+			"yaml_content": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					prometheusAlertRulesYamlContentValidator{},
+				},
+				MarkdownDescription: "Prometheus rule file, or one or more `PrometheusRule` custom resources. " +
+					"Every alerting rule becomes a `metric_threshold` alert. Recording rules are ignored, use `coralogix_recording_rules_groups_set` for them.",
+			},
+			"severity_label": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("severity"),
+				MarkdownDescription: "The rule label that sets the alert priority. Defaults to `severity`.",
+			},
+			"severity_priorities": schema.MapAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, defaultPriorities)),
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf(alerttypes.ValidAlertPriorities...)),
+				},
+				MarkdownDescription: fmt.Sprintf("Alert priority by value of the severity label, matched case-insensitively. "+
+					"Rules with another severity get the default alert priority. Valid values: %q. Defaults to the mapping of the `priority_from_severity` function: %s.",
+					alerttypes.ValidAlertPriorities, severityPrioritiesDescription),
+			},
+			"alerts": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The ID of each managed alert, by `<group name>/<alert name>`. A repeated alert name in the same group gets a `/2`, `/3`... suffix.",
+			},
+			"drifted_alerts": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Keys of `alerts` whose alert was changed outside of Terraform, compared on the attributes generated from its rule. " +
+					"They show as a change of this attribute, and the next apply replaces those alerts with the ones generated from `yaml_content`.",
+			},
+		},
+		MarkdownDescription: "Manages Coralogix metric alerts from Prometheus alerting rules, so rules written for Alertmanager can be migrated as they are. " +
+			"For more info check - https://coralogix.com/docs/getting-started-with-coralogix-alerts/.",
+	}
+}
+
+func (r *PrometheusAlertRulesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan PrometheusAlertRulesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Every apply replaces the drifted alerts, so none are left after it.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("drifted_alerts"), types.SetValueMust(types.StringType, nil))...)
+	if plan.YamlContent.IsUnknown() || plan.SeverityLabel.IsUnknown() || plan.SeverityPriorities.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("alerts"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	definitions, diags := prometheusAlertRulesDefinitions(ctx, &plan)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids := map[string]string{}
+	if !req.State.Raw.IsNull() {
+		var state PrometheusAlertRulesResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ids, diags = utils.TypeMapToStringMap(ctx, state.Alerts)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	alertIDs, diags := plannedAlertsSetIDs(definitions, ids)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("alerts"), alertIDs)...)
+}
+
+func (r *PrometheusAlertRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *PrometheusAlertRulesResourceModel
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	definitions, diags := prometheusAlertRulesDefinitions(ctx, plan)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, diags := reconcileAlertsSet(ctx, r.client, "coralogix_prometheus_alert_rules", nil, definitions, map[string]string{})
+	resp.Diagnostics.Append(diags...)
+
+	plan.ID = types.StringValue(uuid.NewString())
+	alertIDs, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	plan.Alerts = alertIDs
+	plan.DriftedAlerts = types.SetValueMust(types.StringType, nil)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *PrometheusAlertRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *PrometheusAlertRulesResourceModel
+	if diags := req.State.Get(ctx, &state); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, diags := utils.TypeMapToStringMap(ctx, state.Alerts)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, alertDefs, diags := refreshAlertsSetIDs(ctx, r.client, "coralogix_prometheus_alert_rules", ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definitions, diags := prometheusAlertRulesDefinitions(ctx, state)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	drifted, diags := driftedAlertsSetDefinitions(ctx, definitions, alertDefs)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	driftedAlerts, diags := types.SetValueFrom(ctx, types.StringType, slices.Sorted(maps.Keys(drifted)))
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	alertIDs, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	state.Alerts = alertIDs
+	state.DriftedAlerts = driftedAlerts
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *PrometheusAlertRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *PrometheusAlertRulesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definitions, diags := prometheusAlertRulesDefinitions(ctx, plan)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	priorDefinitions, diags := prometheusAlertRulesDefinitions(ctx, state)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	ids, diags := utils.TypeMapToStringMap(ctx, state.Alerts)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	var driftedAlerts []string
+	resp.Diagnostics.Append(state.DriftedAlerts.ElementsAs(ctx, &driftedAlerts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Without a prior definition, the drifted alerts are replaced even when
+	// their rule didn't change.
+	for _, key := range driftedAlerts {
+		delete(priorDefinitions, key)
+	}
+
+	ids, diags = reconcileAlertsSet(ctx, r.client, "coralogix_prometheus_alert_rules", priorDefinitions, definitions, ids)
+	resp.Diagnostics.Append(diags...)
+
+	alertIDs, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	plan.Alerts = alertIDs
+	plan.DriftedAlerts = types.SetValueMust(types.StringType, nil)
+	if resp.Diagnostics.HasError() {
+		// Keep the prior rules so the next apply compares against what was
+		// actually applied, while still tracking the alerts that exist.
+		plan.YamlContent = state.YamlContent
+		plan.SeverityLabel = state.SeverityLabel
+		plan.SeverityPriorities = state.SeverityPriorities
+		plan.DriftedAlerts = state.DriftedAlerts
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *PrometheusAlertRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PrometheusAlertRulesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, diags := utils.TypeMapToStringMap(ctx, state.Alerts)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(deleteAlertsSetIDs(ctx, r.client, "coralogix_prometheus_alert_rules", ids)...)
+}
+
+// prometheusAlertRulesDefinitions translates the alerting rules of the model into
+// coralogix_alert definitions, keyed the same way as the alerts attribute.
+func prometheusAlertRulesDefinitions(ctx context.Context, model *PrometheusAlertRulesResourceModel) (map[string]json.RawMessage, diag.Diagnostics) {
+	groups, err := parsePrometheusRuleGroups(model.YamlContent.ValueString())
+	if err != nil {
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Error on unmarshal yaml_content", err.Error())}
+	}
+
+	priorities, diags := utils.TypeMapToStringMap(ctx, model.SeverityPriorities)
+	if diags.HasError() {
+		return nil, diags
+	}
+	priorityBySeverity := make(map[string]string, len(priorities))
+	for severity, priority := range priorities {
+		priorityBySeverity[strings.ToLower(severity)] = priority
+	}
+
+	definitions := map[string]json.RawMessage{}
+	for _, group := range groups {
+		seen := map[string]int{}
+		for _, rule := range group.Rules {
+			if rule.Alert == "" {
+				continue
+			}
+			seen[rule.Alert]++
+			key := group.Name + "/" + rule.Alert
+			if n := seen[rule.Alert]; n > 1 {
+				key = fmt.Sprintf("%s/%d", key, n)
+			}
+
+			priority := priorityBySeverity[strings.ToLower(rule.Labels[model.SeverityLabel.ValueString()])]
+			definition, err := json.Marshal(prometheusRuleToAlertDefinition(rule, priority))
+			if err != nil {
+				diags.AddError("Invalid yaml_content", fmt.Sprintf("alert %q: %s", key, err))
+				continue
+			}
+			definitions[key] = definition
+		}
+	}
+	return definitions, diags
+}
+
+func parsePrometheusRuleGroups(content string) ([]prometheusRuleGroup, error) {
+	var groups []prometheusRuleGroup
+	decoder := yaml.NewDecoder(bytes.NewBufferString(content))
+	for {
+		var file prometheusRuleFile
+		err := decoder.Decode(&file)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, file.Groups...)
+		groups = append(groups, file.Spec.Groups...)
+	}
+
+	for _, group := range groups {
+		if group.Name == "" {
+			return nil, fmt.Errorf("every rule group must have a name")
+		}
+		for _, rule := range group.Rules {
+			if rule.Alert == "" && rule.Record == "" {
+				return nil, fmt.Errorf("group %q has a rule without \"alert\" or \"record\"", group.Name)
+			}
+			if rule.Alert != "" && strings.TrimSpace(rule.Expr) == "" {
+				return nil, fmt.Errorf("alert %q in group %q has no \"expr\"", rule.Alert, group.Name)
+			}
+		}
+	}
+	return groups, nil
+}
+
+// prometheusRuleToAlertDefinition builds a coralogix_alert definition for one
+// alerting rule. Prometheus fires when the expression returns a series for the
+// whole "for" duration, which is a threshold that holds for 100% of the window.
+func prometheusRuleToAlertDefinition(rule prometheusRule, priority string) map[string]any {
+	promql, conditionType, threshold, ok := splitPromQLThreshold(rule.Expr)
+	if !ok {
+		// Any returned series means the rule fires, whatever its value.
+		promql, conditionType, threshold = fmt.Sprintf("(%s) * 0 + 1", strings.TrimSpace(rule.Expr)), "MORE_THAN", 0
+	}
+
+	ofTheLast := strings.TrimSpace(rule.For)
+	if ofTheLast == "" || strings.Trim(ofTheLast, "0smhdwy") == "" {
+		ofTheLast = alerttypes.MetricFilterOperationTypeProtoToSchemaMap[alerts.METRICTIMEWINDOWVALUE_METRIC_TIME_WINDOW_VALUE_MINUTES_1_OR_UNSPECIFIED]
+	}
+
+	override := map[string]any{}
+	definition := map[string]any{
+		"name": rule.Alert,
+		"type_definition": map[string]any{
+			"metric_threshold": map[string]any{
+				"metric_filter": map[string]any{
+					"promql": promql,
+				},
+				"missing_values": map[string]any{
+					"replace_with_zero": false,
+				},
+				"rules": []any{
+					map[string]any{
+						"condition": map[string]any{
+							"threshold":      threshold,
+							"for_over_pct":   100,
+							"of_the_last":    ofTheLast,
+							"condition_type": conditionType,
+						},
+						"override": override,
+					},
+				},
+			},
+		},
+	}
+	if priority != "" {
+		definition["priority"] = priority
+		override["priority"] = priority
+	}
+	if len(rule.Labels) > 0 {
+		definition["labels"] = rule.Labels
+	}
+	if description := rule.Annotations["description"]; description != "" {
+		definition["description"] = description
+	} else if summary := rule.Annotations["summary"]; summary != "" {
+		definition["description"] = summary
+	}
+	return definition
+}
+
+var promQLComparisonConditions = map[string]string{
+	">":  "MORE_THAN",
+	">=": "MORE_THAN_OR_EQUALS",
+	"<":  "LESS_THAN",
+	"<=": "LESS_THAN_OR_EQUALS",
+}
+
+var promQLMirroredComparisons = map[string]string{
+	">":  "<",
+	">=": "<=",
+	"<":  ">",
+	"<=": ">=",
+}
+
+// splitPromQLThreshold splits an expression of the form `<query> <op> <number>`
+// (or `<number> <op> <query>`) into the query, the matching condition type and
+// the threshold. It reports false for anything else, such as `==`, `bool`
+// comparisons, set operators or more than one comparison at the top level.
+func splitPromQLThreshold(expr string) (string, string, float64, bool) {
+	var (
+		depth      int
+		quote      rune
+		opStart    = -1
+		opEnd      int
+		op         string
+		runes      = []rune(expr)
+		topLevelID strings.Builder
+	)
+
+	flushIdentifier := func() bool {
+		word := strings.ToLower(topLevelID.String())
+		topLevelID.Reset()
+		return word == "and" || word == "or" || word == "unless"
+	}
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		if quote != 0 {
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '#':
+			// Drop the comment so it is not part of the split query.
+			end := i
+			for end < len(runes) && runes[end] != '\n' {
+				end++
+			}
+			runes = append(runes[:i], runes[end:]...)
+			i--
+			continue
+		case c == '(' || c == '{' || c == '[':
+			depth++
+		case c == ')' || c == '}' || c == ']':
+			depth--
+		case depth > 0:
+		case unicode.IsLetter(c) || c == '_' || c == ':' || (topLevelID.Len() > 0 && unicode.IsDigit(c)):
+			topLevelID.WriteRune(c)
+			continue
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			candidate := string(c)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				candidate += "="
+			}
+			if opStart >= 0 {
+				return "", "", 0, false
+			}
+			if _, ok := promQLComparisonConditions[candidate]; !ok {
+				return "", "", 0, false
+			}
+			opStart, opEnd, op = i, i+len(candidate), candidate
+			i = opEnd - 1
+		}
+		if flushIdentifier() {
+			return "", "", 0, false
+		}
+	}
+	if flushIdentifier() || opStart < 0 {
+		return "", "", 0, false
+	}
+
+	left := strings.TrimSpace(string(runes[:opStart]))
+	right := strings.TrimSpace(string(runes[opEnd:]))
+	if strings.HasPrefix(strings.ToLower(right), "bool") {
+		return "", "", 0, false
+	}
+	if threshold, err := strconv.ParseFloat(right, 64); err == nil && left != "" {
+		return left, promQLComparisonConditions[op], threshold, true
+	}
+	if threshold, err := strconv.ParseFloat(left, 64); err == nil && right != "" {
+		return right, promQLComparisonConditions[promQLMirroredComparisons[op]], threshold, true
+	}
+	return "", "", 0, false
+}
+
+type prometheusAlertRulesYamlContentValidator struct{}
+
+func (v prometheusAlertRulesYamlContentValidator) Description(_ context.Context) string {
+	return "validate yaml_content"
+}
+
+func (v prometheusAlertRulesYamlContentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v prometheusAlertRulesYamlContentValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parsePrometheusRuleGroups(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid yaml_content", err.Error())
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"testing"
+
+	alerts "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/alert_definitions_service"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const prometheusRuleTestDocument = `
+apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: checkout
+spec:
+  groups:
+    - name: checkout
+      rules:
+        - record: job:http_requests:rate5m
+          expr: sum by (job) (rate(http_requests_total[5m]))
+        - alert: HighErrorRate
+          expr: sum(rate(http_requests_total{code=~"5.."}[5m])) / sum(rate(http_requests_total[5m])) > 0.05
+          for: 10m
+          labels:
+            severity: Critical
+            team: checkout
+          annotations:
+            summary: High error rate
+        - alert: HighErrorRate
+          expr: sum(rate(http_requests_total{code=~"5.."}[5m])) / sum(rate(http_requests_total[5m])) > 0.01
+          labels:
+            severity: warning
+---
+groups:
+  - name: nodes
+    rules:
+      - alert: NodeDown
+        expr: up{job="node"} == 0
+`
+
+func TestSplitPromQLThreshold(t *testing.T) {
+	cases := []struct {
+		expr          string
+		promql        string
+		conditionType string
+		threshold     float64
+		ok            bool
+	}{
+		{`rate(errors_total[5m]) > 0.5`, `rate(errors_total[5m])`, "MORE_THAN", 0.5, true},
+		{`avg(latency{path=">="}) <= 200`, `avg(latency{path=">="})`, "LESS_THAN_OR_EQUALS", 200, true},
+		{`10 < free_bytes`, `free_bytes`, "MORE_THAN", 10, true},
+		{`job:disk_free:ratio >= -1e3`, `job:disk_free:ratio`, "MORE_THAN_OR_EQUALS", -1000, true},
+		{`up == 0`, "", "", 0, false},
+		{`errors > bool 1`, "", "", 0, false},
+		{`a > 1 and b > 2`, "", "", 0, false},
+		{`a > 1 < 2`, "", "", 0, false},
+		{`a > b`, "", "", 0, false},
+		{`absent(up{job="api"})`, "", "", 0, false},
+		{`sum(rate(x[5m])) by (job) > 3 # comment > 4`, `sum(rate(x[5m])) by (job)`, "MORE_THAN", 3, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			promql, conditionType, threshold, ok := splitPromQLThreshold(tc.expr)
+			if ok != tc.ok || promql != tc.promql || conditionType != tc.conditionType || threshold != tc.threshold {
+				t.Errorf("splitPromQLThreshold() = (%q, %q, %v, %v), want (%q, %q, %v, %v)",
+					promql, conditionType, threshold, ok, tc.promql, tc.conditionType, tc.threshold, tc.ok)
+			}
+		})
+	}
+}
+
+func TestParsePrometheusRuleGroups(t *testing.T) {
+	groups, err := parsePrometheusRuleGroups(prometheusRuleTestDocument)
+	if err != nil {
+		t.Fatalf("parsePrometheusRuleGroups returned an error: %s", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("parsePrometheusRuleGroups returned %d groups, want 2", len(groups))
+	}
+
+	if _, err := parsePrometheusRuleGroups("groups:\n  - name: a\n    rules:\n      - alert: NoExpr\n"); err == nil {
+		t.Error("expected an error for an alert without expr")
+	}
+	if _, err := parsePrometheusRuleGroups("groups:\n  - rules: []\n"); err == nil {
+		t.Error("expected an error for a group without a name")
+	}
+}
+
+func TestPrometheusAlertRulesDefinitions(t *testing.T) {
+	ctx := context.Background()
+	priorities, diags := types.MapValueFrom(ctx, types.StringType, severityPriorities)
+	if diags.HasError() {
+		t.Fatalf("MapValueFrom returned diagnostics: %v", diags)
+	}
+	model := &PrometheusAlertRulesResourceModel{
+		YamlContent:        types.StringValue(prometheusRuleTestDocument),
+		SeverityLabel:      types.StringValue("severity"),
+		SeverityPriorities: priorities,
+	}
+
+	definitions, diags := prometheusAlertRulesDefinitions(ctx, model)
+	if diags.HasError() {
+		t.Fatalf("prometheusAlertRulesDefinitions returned diagnostics: %v", diags)
+	}
+	for _, key := range []string{"checkout/HighErrorRate", "checkout/HighErrorRate/2", "nodes/NodeDown"} {
+		if _, ok := definitions[key]; !ok {
+			t.Errorf("definition %q is missing", key)
+		}
+	}
+	if len(definitions) != 3 {
+		t.Fatalf("prometheusAlertRulesDefinitions returned %d definitions, want 3", len(definitions))
+	}
+
+	properties, diags := expandAlertsSetDefinition(ctx, "checkout/HighErrorRate", definitions["checkout/HighErrorRate"])
+	if diags.HasError() {
+		t.Fatalf("expandAlertsSetDefinition returned diagnostics: %v", diags)
+	}
+	if properties.Priority == nil || *properties.Priority != alerts.ALERTDEFPRIORITY_ALERT_DEF_PRIORITY_P1 {
+		t.Errorf("Priority = %v, want P1", properties.Priority)
+	}
+	if properties.Description == nil || *properties.Description != "High error rate" {
+		t.Errorf("Description = %v, want the summary annotation", properties.Description)
+	}
+	if properties.EntityLabels == nil || (*properties.EntityLabels)["team"] != "checkout" {
+		t.Errorf("EntityLabels = %v, want the rule labels", properties.EntityLabels)
+	}
+	if properties.MetricThreshold == nil || len(properties.MetricThreshold.Rules) != 1 {
+		t.Fatalf("MetricThreshold = %v, want a single rule", properties.MetricThreshold)
+	}
+	condition := properties.MetricThreshold.Rules[0].Condition
+	if *condition.Threshold != 0.05 || *condition.ForOverPct != 100 {
+		t.Errorf("Condition = %+v, want threshold 0.05 for 100%% of the window", condition)
+	}
+
+	properties, diags = expandAlertsSetDefinition(ctx, "nodes/NodeDown", definitions["nodes/NodeDown"])
+	if diags.HasError() {
+		t.Fatalf("expandAlertsSetDefinition returned diagnostics: %v", diags)
+	}
+	if promql := properties.MetricThreshold.MetricFilter.Promql; promql == nil || *promql != `(up{job="node"} == 0) * 0 + 1` {
+		t.Errorf("Promql = %v, want the wrapped expression", promql)
+	}
+}
+
+func TestPrometheusAlertRulesDrift(t *testing.T) {
+	ctx := context.Background()
+	priorities, diags := types.MapValueFrom(ctx, types.StringType, severityPriorities)
+	if diags.HasError() {
+		t.Fatalf("MapValueFrom returned diagnostics: %v", diags)
+	}
+	definitions, diags := prometheusAlertRulesDefinitions(ctx, &PrometheusAlertRulesResourceModel{
+		YamlContent:        types.StringValue(prometheusRuleTestDocument),
+		SeverityLabel:      types.StringValue("severity"),
+		SeverityPriorities: priorities,
+	})
+	if diags.HasError() {
+		t.Fatalf("prometheusAlertRulesDefinitions returned diagnostics: %v", diags)
+	}
+
+	alertDefs := map[string]alerts.AlertDef{}
+	for _, key := range []string{"checkout/HighErrorRate", "nodes/NodeDown"} {
+		properties, diags := expandAlertsSetDefinition(ctx, key, definitions[key])
+		if diags.HasError() {
+			t.Fatalf("expandAlertsSetDefinition returned diagnostics: %v", diags)
+		}
+		alertDefs[key] = alerts.AlertDef{Id: &key, AlertDefProperties: properties}
+	}
+	changed := "Edited in the UI"
+	alertDefs["nodes/NodeDown"].AlertDefProperties.Description = &changed
+
+	drifted, diags := driftedAlertsSetDefinitions(ctx, definitions, alertDefs)
+	if diags.HasError() {
+		t.Fatalf("driftedAlertsSetDefinitions returned diagnostics: %v", diags)
+	}
+	if len(drifted) != 1 {
+		t.Fatalf("driftedAlertsSetDefinitions returned %d definitions, want only nodes/NodeDown", len(drifted))
+	}
+	if _, ok := drifted["nodes/NodeDown"]; !ok {
+		t.Errorf("nodes/NodeDown is not drifted")
+	}
+}
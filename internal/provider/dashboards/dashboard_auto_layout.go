@@ -0,0 +1,272 @@
+// Copyright 2026 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	dashboardwidgets "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_widgets"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	dashboardservice "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/dashboard_service"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+type DashboardAutoLayoutModel struct {
+	Widgets types.List `tfsdk:"widgets"` //AutoLayoutWidgetModel
+}
+
+type AutoLayoutWidgetModel struct {
+	ID          types.String                            `tfsdk:"id"`
+	Title       types.String                            `tfsdk:"title"`
+	Description types.String                            `tfsdk:"description"`
+	Definition  *dashboardwidgets.WidgetDefinitionModel `tfsdk:"definition"`
+	Reference   *WidgetReferenceModel                   `tfsdk:"reference"`
+	Section     types.String                            `tfsdk:"section"`
+	Size        types.String                            `tfsdk:"size"`
+	Height      types.Int64                             `tfsdk:"height"`
+}
+
+// autoLayoutSection holds the indexes of the auto_layout widgets in each row
+// of a section.
+type autoLayoutSection struct {
+	name types.String
+	rows [][]int
+}
+
+func autoLayoutModelAttr() map[string]attr.Type {
+	return map[string]attr.Type{
+		"widgets": types.ListType{
+			ElemType: types.ObjectType{AttrTypes: autoLayoutWidgetModelAttr()},
+		},
+	}
+}
+
+func autoLayoutWidgetModelAttr() map[string]attr.Type {
+	attributes := widgetModelAttr()
+	delete(attributes, "width")
+	attributes["section"] = types.StringType
+	attributes["size"] = types.StringType
+	attributes["height"] = types.Int64Type
+	return attributes
+}
+
+func autoLayoutWidgetSpan(size types.String) int64 {
+	if span, ok := dashboardwidgets.AutoLayoutWidgetSpans[size.ValueString()]; ok {
+		return span
+	}
+	return dashboardwidgets.AutoLayoutWidgetSpans[dashboardwidgets.AutoLayoutDefaultWidgetSize]
+}
+
+// autoLayoutRowWidgetSize is the largest size that fits count widgets in a
+// row, or the smallest one when none does.
+func autoLayoutRowWidgetSize(count int) types.String {
+	spans := dashboardwidgets.AutoLayoutWidgetSpans
+	sizes := slices.SortedFunc(maps.Keys(spans), func(a, b string) int {
+		return cmp.Compare(spans[a], spans[b])
+	})
+	size := sizes[0]
+	for _, candidate := range sizes[1:] {
+		if spans[candidate]*int64(count) <= dashboardwidgets.AutoLayoutRowColumns {
+			size = candidate
+		}
+	}
+	return types.StringValue(size)
+}
+
+// arrangeAutoLayout places the widgets in order, starting a new section when
+// the section name changes and a new row when a widget does not fit in the
+// current one. It only depends on the widget sizes and sections, so the same
+// configuration always produces the same layout. The API does not keep
+// widget widths, so sizes only decide which widgets share a row.
+func arrangeAutoLayout(widgets []AutoLayoutWidgetModel) []autoLayoutSection {
+	var sections []autoLayoutSection
+	var used int64
+	for i, widget := range widgets {
+		if len(sections) == 0 || !sections[len(sections)-1].name.Equal(widget.Section) {
+			sections = append(sections, autoLayoutSection{name: widget.Section})
+			used = dashboardwidgets.AutoLayoutRowColumns
+		}
+		section := &sections[len(sections)-1]
+		span := autoLayoutWidgetSpan(widget.Size)
+		if used+span > dashboardwidgets.AutoLayoutRowColumns {
+			section.rows = append(section.rows, nil)
+			used = 0
+		}
+		section.rows[len(section.rows)-1] = append(section.rows[len(section.rows)-1], i)
+		used += span
+	}
+	return sections
+}
+
+func autoLayoutRowHeight(widgets []AutoLayoutWidgetModel, row []int) int64 {
+	var height int64
+	for _, i := range row {
+		height = max(height, widgets[i].Height.ValueInt64())
+	}
+	return max(height, 1)
+}
+
+func autoLayoutWidgets(ctx context.Context, autoLayout types.Object) ([]AutoLayoutWidgetModel, diag.Diagnostics) {
+	var autoLayoutObject DashboardAutoLayoutModel
+	if diags := autoLayout.As(ctx, &autoLayoutObject, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return nil, diags
+	}
+	var widgets []AutoLayoutWidgetModel
+	if diags := autoLayoutObject.Widgets.ElementsAs(ctx, &widgets, false); diags.HasError() {
+		return nil, diags
+	}
+	return widgets, nil
+}
+
+func expandDashboardAutoLayout(ctx context.Context, name types.String, autoLayout types.Object) (*dashboardservice.Layout, diag.Diagnostics) {
+	widgets, diags := autoLayoutWidgets(ctx, autoLayout)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// Section and row IDs are not kept in the state, derive them from the
+	// dashboard name and their position so that they do not change on every
+	// update.
+	namespace := uuid.NewSHA1(uuid.NameSpaceURL, []byte("auto_layout:"+name.ValueString()))
+	id := func(format string, args ...any) *dashboardservice.UUID {
+		value := uuid.NewSHA1(namespace, []byte(fmt.Sprintf(format, args...))).String()
+		return &dashboardservice.UUID{Value: &value}
+	}
+
+	layout := &dashboardservice.Layout{Sections: []dashboardservice.Section{}}
+	for i, section := range arrangeAutoLayout(widgets) {
+		expandedSection := dashboardservice.Section{
+			Id: id("section/%d", i),
+		}
+		if !section.name.IsNull() {
+			expandedSection.Options = &dashboardservice.SectionOptions{
+				Custom: &dashboardservice.CustomSectionOptions{
+					Name: section.name.ValueStringPointer(),
+				},
+			}
+		}
+		for j, row := range section.rows {
+			expandedRow := dashboardservice.Row{
+				Id: id("section/%d/row/%d", i, j),
+				Appearance: &dashboardservice.RowAppearance{
+					Height: typeInt64ToInt32Pointer(types.Int64Value(autoLayoutRowHeight(widgets, row))),
+				},
+			}
+			for _, k := range row {
+				widget := widgets[k]
+				expandedWidget, diags := expandWidget(ctx, WidgetModel{
+					ID:          widget.ID,
+					Title:       widget.Title,
+					Description: widget.Description,
+					Definition:  widget.Definition,
+					Reference:   widget.Reference,
+					Width:       types.Int64Null(),
+				})
+				if diags.HasError() {
+					return nil, diags
+				}
+				expandedRow.Widgets = append(expandedRow.Widgets, *expandedWidget)
+			}
+			expandedSection.Rows = append(expandedSection.Rows, expandedRow)
+		}
+		layout.Sections = append(layout.Sections, expandedSection)
+	}
+
+	return layout, nil
+}
+
+// flattenDashboardAutoLayout lists the widgets of the layout in order. The API
+// does not keep widget widths, so sizes are kept as planned while the sections
+// and rows are still the ones arrangeAutoLayout computes for the planned
+// widgets, and heights while their row also has the planned height. Otherwise
+// the widgets take the sections and row heights of the layout, and the size
+// that fits the number of widgets in their row, so that the plan shows the
+// difference.
+func flattenDashboardAutoLayout(ctx context.Context, planned types.Object, layout *dashboardservice.Layout) (types.Object, diag.Diagnostics) {
+	plannedWidgets, diags := autoLayoutWidgets(ctx, planned)
+	if diags.HasError() {
+		return types.ObjectNull(autoLayoutModelAttr()), diags
+	}
+	expected := arrangeAutoLayout(plannedWidgets)
+	sections := layout.GetSections()
+	arranged := autoLayoutArranged(expected, sections)
+
+	widgets := []AutoLayoutWidgetModel{}
+	for i, section := range sections {
+		sectionName := types.StringNull()
+		if section.Options != nil && section.Options.Custom != nil {
+			sectionName = utils.StringPointerToTypeString(section.Options.Custom.Name)
+		}
+		for j, row := range section.Rows {
+			height := int32PointerToTypeInt64(row.GetAppearance().Height)
+			for k := range row.Widgets {
+				flattenedWidget, diags := flattenDashboardWidget(ctx, &row.Widgets[k])
+				if diags.HasError() {
+					return types.ObjectNull(autoLayoutModelAttr()), diags
+				}
+				widget := AutoLayoutWidgetModel{
+					ID:          flattenedWidget.ID,
+					Title:       flattenedWidget.Title,
+					Description: flattenedWidget.Description,
+					Definition:  flattenedWidget.Definition,
+					Reference:   flattenedWidget.Reference,
+					Section:     sectionName,
+					Size:        autoLayoutRowWidgetSize(len(row.Widgets)),
+					Height:      height,
+				}
+				if arranged {
+					plannedRow := expected[i].rows[j]
+					widget.Size = plannedWidgets[plannedRow[k]].Size
+					if height.ValueInt64() == autoLayoutRowHeight(plannedWidgets, plannedRow) {
+						widget.Height = plannedWidgets[plannedRow[k]].Height
+					}
+				}
+				widgets = append(widgets, widget)
+			}
+		}
+	}
+
+	widgetsList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: autoLayoutWidgetModelAttr()}, widgets)
+	if diags.HasError() {
+		return types.ObjectNull(autoLayoutModelAttr()), diags
+	}
+	return types.ObjectValueFrom(ctx, autoLayoutModelAttr(), DashboardAutoLayoutModel{Widgets: widgetsList})
+}
+
+func autoLayoutArranged(expected []autoLayoutSection, sections []dashboardservice.Section) bool {
+	if len(expected) != len(sections) {
+		return false
+	}
+	for i, section := range sections {
+		if len(expected[i].rows) != len(section.Rows) {
+			return false
+		}
+		for j, row := range section.Rows {
+			if len(expected[i].rows[j]) != len(row.Widgets) {
+				return false
+			}
+		}
+	}
+	return true
+}
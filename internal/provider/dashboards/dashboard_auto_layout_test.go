@@ -0,0 +1,197 @@
+// Copyright 2026 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	dashboardwidgets "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_widgets"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testAutoLayoutWidget(section, size string, height int64) AutoLayoutWidgetModel {
+	widget := AutoLayoutWidgetModel{
+		ID:          types.StringUnknown(),
+		Title:       types.StringNull(),
+		Description: types.StringNull(),
+		Definition: &dashboardwidgets.WidgetDefinitionModel{
+			Markdown: &dashboardwidgets.MarkdownModel{
+				MarkdownText: types.StringValue("text"),
+				TooltipText:  types.StringNull(),
+			},
+		},
+		Section: types.StringNull(),
+		Size:    types.StringValue(size),
+		Height:  types.Int64Value(height),
+	}
+	if section != "" {
+		widget.Section = types.StringValue(section)
+	}
+	return widget
+}
+
+func testAutoLayoutObject(t *testing.T, widgets []AutoLayoutWidgetModel) types.Object {
+	t.Helper()
+	ctx := context.Background()
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: autoLayoutWidgetModelAttr()}, widgets)
+	if diags.HasError() {
+		t.Fatalf("build auto_layout widgets: %v", diags)
+	}
+	autoLayout, diags := types.ObjectValueFrom(ctx, autoLayoutModelAttr(), DashboardAutoLayoutModel{Widgets: list})
+	if diags.HasError() {
+		t.Fatalf("build auto_layout: %v", diags)
+	}
+	return autoLayout
+}
+
+func TestArrangeAutoLayout(t *testing.T) {
+	widgets := []AutoLayoutWidgetModel{
+		testAutoLayoutWidget("", "large", 19),
+		testAutoLayoutWidget("", "medium", 19),
+		testAutoLayoutWidget("", "small", 19),
+		testAutoLayoutWidget("", "medium", 19),
+		testAutoLayoutWidget("Details", "full", 19),
+		testAutoLayoutWidget("Details", "small", 19),
+		testAutoLayoutWidget("", "small", 19),
+	}
+
+	sections := arrangeAutoLayout(widgets)
+	var got [][][]int
+	var names []types.String
+	for _, section := range sections {
+		got = append(got, section.rows)
+		names = append(names, section.name)
+	}
+	want := [][][]int{
+		{{0, 1}, {2, 3}},
+		{{4}, {5}},
+		{{6}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+	if !names[0].IsNull() || names[1].ValueString() != "Details" || !names[2].IsNull() {
+		t.Errorf("section names = %v, want null, Details and null", names)
+	}
+}
+
+func TestExpandFlattenDashboardAutoLayout(t *testing.T) {
+	ctx := context.Background()
+	planned := []AutoLayoutWidgetModel{
+		testAutoLayoutWidget("", "large", 19),
+		testAutoLayoutWidget("", "large", 30),
+		testAutoLayoutWidget("Details", "full", 19),
+	}
+
+	layout, diags := expandDashboardAutoLayout(ctx, types.StringValue("auto"), testAutoLayoutObject(t, planned))
+	if diags.HasError() {
+		t.Fatalf("expandDashboardAutoLayout: %v", diags)
+	}
+	if len(layout.Sections) != 2 || len(layout.Sections[0].Rows) != 1 || len(layout.Sections[0].Rows[0].Widgets) != 2 {
+		t.Fatalf("unexpected layout %+v", layout)
+	}
+	if height := *layout.Sections[0].Rows[0].Appearance.Height; height != 30 {
+		t.Errorf("row height = %d, want the highest widget", height)
+	}
+	if layout.Sections[0].Options != nil || *layout.Sections[1].Options.Custom.Name != "Details" {
+		t.Error("expected an unnamed section followed by the Details section")
+	}
+	again, _ := expandDashboardAutoLayout(ctx, types.StringValue("auto"), testAutoLayoutObject(t, planned))
+	if *again.Sections[1].Rows[0].Id.Value != *layout.Sections[1].Rows[0].Id.Value {
+		t.Error("section and row IDs should not change between expansions")
+	}
+
+	flattened, diags := flattenDashboardAutoLayout(ctx, testAutoLayoutObject(t, planned), layout)
+	if diags.HasError() {
+		t.Fatalf("flattenDashboardAutoLayout: %v", diags)
+	}
+	widgets, diags := autoLayoutWidgets(ctx, flattened)
+	if diags.HasError() {
+		t.Fatalf("read flattened widgets: %v", diags)
+	}
+	for i, widget := range widgets {
+		if widget.ID.IsUnknown() || !widget.Size.Equal(planned[i].Size) || !widget.Height.Equal(planned[i].Height) || !widget.Section.Equal(planned[i].Section) {
+			t.Errorf("widget %d = %+v, want the planned size, height and section", i, widget)
+		}
+	}
+
+	// A row made higher outside of Terraform shows up as a change of height.
+	*layout.Sections[0].Rows[0].Appearance.Height = 40
+	flattened, _ = flattenDashboardAutoLayout(ctx, testAutoLayoutObject(t, planned), layout)
+	widgets, _ = autoLayoutWidgets(ctx, flattened)
+	if widgets[0].Height.ValueInt64() != 40 || widgets[1].Height.ValueInt64() != 40 || widgets[2].Height.ValueInt64() != 19 {
+		t.Errorf("heights = %v, %v and %v, want 40, 40 and 19", widgets[0].Height, widgets[1].Height, widgets[2].Height)
+	}
+	// A widget moved to a row of its own outside of Terraform shows up as a
+	// change of size, taking the size that fits the widgets of its row.
+	rows := layout.Sections[0].Rows
+	moved := rows[0]
+	moved.Widgets = rows[0].Widgets[1:]
+	rows[0].Widgets = rows[0].Widgets[:1]
+	layout.Sections[0].Rows = append(rows, moved)
+	flattened, _ = flattenDashboardAutoLayout(ctx, testAutoLayoutObject(t, planned), layout)
+	widgets, _ = autoLayoutWidgets(ctx, flattened)
+	if widgets[0].Size.ValueString() != "full" || widgets[1].Size.ValueString() != "full" || widgets[2].Size.ValueString() != "full" {
+		t.Errorf("sizes = %v, %v and %v, want full, full and full", widgets[0].Size, widgets[1].Size, widgets[2].Size)
+	}
+}
+
+func TestFlattenDashboardAutoLayoutWithoutWidths(t *testing.T) {
+	ctx := context.Background()
+	planned := []AutoLayoutWidgetModel{
+		testAutoLayoutWidget("", "large", 19),
+		testAutoLayoutWidget("", "small", 19),
+		testAutoLayoutWidget("", "small", 19),
+		testAutoLayoutWidget("", "full", 19),
+		testAutoLayoutWidget("", "medium", 19),
+	}
+	layout, diags := expandDashboardAutoLayout(ctx, types.StringValue("auto"), testAutoLayoutObject(t, planned))
+	if diags.HasError() {
+		t.Fatalf("expandDashboardAutoLayout: %v", diags)
+	}
+	// The API does not return the widths of the widgets.
+	for i := range layout.Sections[0].Rows {
+		for j := range layout.Sections[0].Rows[i].Widgets {
+			layout.Sections[0].Rows[i].Widgets[j].Appearance = nil
+		}
+	}
+
+	flattened, diags := flattenDashboardAutoLayout(ctx, testAutoLayoutObject(t, planned), layout)
+	if diags.HasError() {
+		t.Fatalf("flattenDashboardAutoLayout: %v", diags)
+	}
+	widgets, diags := autoLayoutWidgets(ctx, flattened)
+	if diags.HasError() {
+		t.Fatalf("read flattened widgets: %v", diags)
+	}
+	if len(widgets) != len(planned) {
+		t.Fatalf("widgets = %+v, want %d widgets", widgets, len(planned))
+	}
+	for i, widget := range widgets {
+		if !widget.Size.Equal(planned[i].Size) {
+			t.Errorf("widget %d size = %v, want %v", i, widget.Size, planned[i].Size)
+		}
+	}
+}
+
+func TestAutoLayoutRowWidgetSize(t *testing.T) {
+	for count, want := range map[int]string{1: "full", 2: "large", 3: "medium", 4: "small", 5: "small"} {
+		if got := autoLayoutRowWidgetSize(count).ValueString(); got != want {
+			t.Errorf("autoLayoutRowWidgetSize(%d) = %q, want %q", count, got, want)
+		}
+	}
+}
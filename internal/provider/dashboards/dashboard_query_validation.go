@@ -0,0 +1,245 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	"github.com/coralogix/coralogix-management-sdk/go/openapi/dashboardjson"
+	dashboardservice "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/dashboard_service"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ resource.ResourceWithModifyPlan = &DashboardResource{}
+
+// dashboardMetricReference is a metric, and the labels of it, that a query or
+// variable of the dashboard reads.
+type dashboardMetricReference struct {
+	path   path.Path
+	metric string
+	labels []string
+}
+
+func (r *DashboardResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !r.queryValidation.Enabled || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateDashboardQueries(ctx, req.Plan.Raw, r.queryValidation.Metadata)...)
+}
+
+// validateDashboardQueries checks the syntax of every promql_query of the
+// planned dashboard, including the PromQL queries of the widgets in
+// content_json, and, when metadata is set, that the metrics and labels read by
+// the queries and variables exist.
+func validateDashboardQueries(ctx context.Context, plan tftypes.Value, metadata clientset.MetricsMetadataSource) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var references []dashboardMetricReference
+	_ = tftypes.Walk(plan, func(attributePath *tftypes.AttributePath, value tftypes.Value) (bool, error) {
+		if !value.IsKnown() || value.IsNull() {
+			return false, nil
+		}
+		steps := attributePath.Steps()
+		if len(steps) == 0 {
+			return true, nil
+		}
+
+		if name, ok := steps[len(steps)-1].(tftypes.AttributeName); ok && name == "content_json" && len(steps) == 1 {
+			content, ok := dashboardQueryString(value)
+			if !ok {
+				return false, nil
+			}
+			contentDiags, contentReferences := validateContentJSONQueries(content)
+			diags.Append(contentDiags...)
+			references = append(references, contentReferences...)
+			return false, nil
+		}
+
+		if name, ok := steps[len(steps)-1].(tftypes.AttributeName); ok && name == "promql_query" {
+			query, ok := dashboardQueryString(value)
+			if !ok {
+				return false, nil
+			}
+			selectors, unknownFunctions, err := utils.ParsePromQL(query)
+			if err != nil {
+				diags.AddAttributeError(dashboardQueryPath(attributePath), "Invalid PromQL query", fmt.Sprintf("%s\n\n%s", err, query))
+				return false, nil
+			}
+			if len(unknownFunctions) > 0 {
+				diags.AddAttributeWarning(dashboardQueryPath(attributePath), "Unknown PromQL function",
+					fmt.Sprintf("The query calls %q, which are not known PromQL functions.\n\n%s", unknownFunctions, query))
+			}
+			for _, selector := range selectors {
+				if selector.Metric != "" {
+					references = append(references, dashboardMetricReference{dashboardQueryPath(attributePath), selector.Metric, selector.Labels})
+				}
+			}
+			return false, nil
+		}
+
+		if _, ok := value.Type().(tftypes.Object); !ok {
+			return true, nil
+		}
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return true, nil
+		}
+		// Metric label variables and metric filters name the metric and label
+		// directly, label value queries wrap them in a string_value.
+		metric, metricOK := dashboardQueryString(attributes["metric_name"])
+		label, labelOK := dashboardQueryString(attributes["label"])
+		if !labelOK {
+			label, labelOK = dashboardQueryString(attributes["label_name"])
+		}
+		if metricOK && labelOK {
+			references = append(references, dashboardMetricReference{dashboardQueryPath(attributePath), metric, []string{label}})
+		}
+		return true, nil
+	})
+
+	if metadata == nil || len(references) == 0 || diags.HasError() {
+		return diags
+	}
+	metrics, err := metadata.MetricNames(ctx)
+	if err != nil {
+		diags.AddWarning("Unable to verify dashboard metrics", err.Error())
+		return diags
+	}
+	known := make(map[string]bool, len(metrics))
+	for _, metric := range metrics {
+		known[metric] = true
+	}
+	for _, reference := range references {
+		if dashboardQueryHasVariable(reference.metric) {
+			continue
+		}
+		if !known[reference.metric] {
+			diags.AddAttributeError(reference.path, "Unknown metric", fmt.Sprintf("metric %q does not exist", reference.metric))
+			continue
+		}
+		if len(reference.labels) == 0 {
+			continue
+		}
+		labels, err := metadata.LabelNames(ctx, reference.metric)
+		if err != nil {
+			diags.AddWarning("Unable to verify dashboard metric labels", err.Error())
+			return diags
+		}
+		for _, label := range reference.labels {
+			if !dashboardQueryHasVariable(label) && !slices.Contains(labels, label) {
+				diags.AddAttributeError(reference.path, "Unknown metric label", fmt.Sprintf("metric %q has no label %q", reference.metric, label))
+			}
+		}
+	}
+	return diags
+}
+
+// validateContentJSONQueries checks the syntax of the PromQL queries of the
+// widgets in content_json and returns the metrics they read. Variables of
+// content_json are not checked.
+func validateContentJSONQueries(content string) (diag.Diagnostics, []dashboardMetricReference) {
+	var diags diag.Diagnostics
+	dashboard := new(dashboardservice.Dashboard)
+	if err := dashboardjson.Unmarshal([]byte(content), dashboard); err != nil {
+		// ContentJsonValidator reports an invalid content_json.
+		return diags, nil
+	}
+	canonical, err := json.Marshal(dashboard)
+	if err != nil {
+		return diags, nil
+	}
+	queries, err := dashboardWidgetQueries(canonical)
+	if err != nil {
+		return diags, nil
+	}
+
+	var references []dashboardMetricReference
+	contentPath := path.Root("content_json")
+	for _, query := range queries {
+		if query.language != "promql" || query.query == "" {
+			continue
+		}
+		selectors, unknownFunctions, err := utils.ParsePromQL(query.query)
+		if err != nil {
+			diags.AddAttributeError(contentPath, "Invalid PromQL query", fmt.Sprintf("widget %q: %s\n\n%s", query.title, err, query.query))
+			continue
+		}
+		if len(unknownFunctions) > 0 {
+			diags.AddAttributeWarning(contentPath, "Unknown PromQL function",
+				fmt.Sprintf("widget %q calls %q, which are not known PromQL functions.\n\n%s", query.title, unknownFunctions, query.query))
+		}
+		for _, selector := range selectors {
+			if selector.Metric != "" {
+				references = append(references, dashboardMetricReference{contentPath, selector.Metric, selector.Labels})
+			}
+		}
+	}
+	return diags, references
+}
+
+// dashboardQueryString returns the known string of value, or of its
+// string_value attribute.
+func dashboardQueryString(value tftypes.Value) (string, bool) {
+	if value.Type() == nil || !value.IsKnown() || value.IsNull() {
+		return "", false
+	}
+	if _, ok := value.Type().(tftypes.Object); ok {
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return "", false
+		}
+		return dashboardQueryString(attributes["string_value"])
+	}
+	if !value.Type().Is(tftypes.String) {
+		return "", false
+	}
+	var s string
+	if err := value.As(&s); err != nil || s == "" {
+		return "", false
+	}
+	return s, true
+}
+
+func dashboardQueryHasVariable(s string) bool {
+	return strings.Contains(s, "{{") || strings.Contains(s, "$")
+}
+
+func dashboardQueryPath(attributePath *tftypes.AttributePath) path.Path {
+	p := path.Empty()
+	for _, step := range attributePath.Steps() {
+		switch step := step.(type) {
+		case tftypes.AttributeName:
+			p = p.AtName(string(step))
+		case tftypes.ElementKeyInt:
+			p = p.AtListIndex(int(step))
+		case tftypes.ElementKeyString:
+			p = p.AtMapKey(string(step))
+		default:
+			// Set elements are addressed by value, report the set itself.
+			return p
+		}
+	}
+	return p
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testDashboardQueriesPlan(queries []string, metric, label string) tftypes.Value {
+	queryType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"promql_query": tftypes.String}}
+	metricLabelType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"metric_name": tftypes.String, "label": tftypes.String}}
+	planType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"queries":      tftypes.List{ElementType: queryType},
+		"metric_label": metricLabelType,
+	}}
+
+	var elements []tftypes.Value
+	for _, query := range queries {
+		elements = append(elements, tftypes.NewValue(queryType, map[string]tftypes.Value{"promql_query": tftypes.NewValue(tftypes.String, query)}))
+	}
+	return tftypes.NewValue(planType, map[string]tftypes.Value{
+		"queries": tftypes.NewValue(tftypes.List{ElementType: queryType}, elements),
+		"metric_label": tftypes.NewValue(metricLabelType, map[string]tftypes.Value{
+			"metric_name": tftypes.NewValue(tftypes.String, metric),
+			"label":       tftypes.NewValue(tftypes.String, label),
+		}),
+	})
+}
+
+func dashboardQueryDiagnostics(t *testing.T, plan tftypes.Value, metadata clientset.MetricsMetadataSource) []string {
+	t.Helper()
+	var got []string
+	for _, d := range validateDashboardQueries(context.Background(), plan, metadata) {
+		got = append(got, d.Summary()+": "+d.Detail())
+	}
+	return got
+}
+
+func TestValidateDashboardQueriesSyntax(t *testing.T) {
+	plan := testDashboardQueriesPlan([]string{
+		`sum by (service) (rate(http_requests_total{namespace=~"{{ namespace }}"}[$__rate_interval]))`,
+		`sum(rate(http_requests_total[5m])`,
+	}, "kube_pod_info", "namespace")
+
+	got := dashboardQueryDiagnostics(t, plan, nil)
+	want := []string{"Invalid PromQL query: parse error at char 34: unexpected end of input\n\nsum(rate(http_requests_total[5m])"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diagnostics = %q, want %q", got, want)
+	}
+}
+
+func TestValidateDashboardQueriesMetadata(t *testing.T) {
+	metadata, err := clientset.NewFileMetricsMetadataSource(filepath.Join("..", "testdata", "dashboards", "metrics_metadata.json"))
+	if err != nil {
+		t.Fatalf("read metrics metadata fixture: %s", err)
+	}
+
+	plan := testDashboardQueriesPlan([]string{
+		`sum by (service) (rate(http_requests_total{namespace=~"{{ namespace }}", code="500"}[5m]))`,
+		`up{job="api"} and on (instance) node_boot_time_seconds`,
+		`rate(http_requests_total{servce="checkout"}[5m])`,
+	}, "kube_pod_info", "pod")
+	got := dashboardQueryDiagnostics(t, plan, metadata)
+	want := []string{
+		`Unknown metric: metric "node_boot_time_seconds" does not exist`,
+		`Unknown metric label: metric "http_requests_total" has no label "servce"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diagnostics = %q, want %q", got, want)
+	}
+
+	got = dashboardQueryDiagnostics(t, testDashboardQueriesPlan(nil, "kube_pod_info", "node"), metadata)
+	if want := []string{`Unknown metric label: metric "kube_pod_info" has no label "node"`}; !reflect.DeepEqual(got, want) {
+		t.Errorf("diagnostics = %q, want %q", got, want)
+	}
+}
+
+func testDashboardContentJSONPlan(widgets string) tftypes.Value {
+	planType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"content_json": tftypes.String}}
+	return tftypes.NewValue(planType, map[string]tftypes.Value{
+		"content_json": tftypes.NewValue(tftypes.String, `{"name":"queries","layout":{"sections":[{"rows":[{"widgets":[`+widgets+`]}]}]}}`),
+	})
+}
+
+func TestValidateDashboardQueriesContentJSON(t *testing.T) {
+	metadata, err := clientset.NewFileMetricsMetadataSource(filepath.Join("..", "testdata", "dashboards", "metrics_metadata.json"))
+	if err != nil {
+		t.Fatalf("read metrics metadata fixture: %s", err)
+	}
+
+	plan := testDashboardContentJSONPlan(`{"title":"Broken","definition":{"dataTable":{"query":{"metrics":{"promqlQuery":{"value":"sum(rate(http_requests_total[5m])"}}}}}}`)
+	got := dashboardQueryDiagnostics(t, plan, metadata)
+	want := []string{"Invalid PromQL query: widget \"Broken\": parse error at char 34: unexpected end of input\n\nsum(rate(http_requests_total[5m])"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diagnostics = %q, want %q", got, want)
+	}
+
+	plan = testDashboardContentJSONPlan(`{"title":"Requests","definition":{"dataTable":{"query":{"metrics":{"promqlQuery":{"value":"sum(rate(http_requests_total{servce=\"checkout\"}[5m]))"}}}}}}`)
+	got = dashboardQueryDiagnostics(t, plan, metadata)
+	want = []string{`Unknown metric label: metric "http_requests_total" has no label "servce"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diagnostics = %q, want %q", got, want)
+	}
+}
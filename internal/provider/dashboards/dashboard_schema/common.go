@@ -16,7 +16,9 @@ package dashboard_schema
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	dashboardwidgets "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_widgets"
@@ -34,6 +36,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsf/jsondiff"
 )
 
 type NormalizeEmptyListToNull struct{}
@@ -74,6 +77,191 @@ func (m PreserveStateForEquivalentJSON) PlanModifyString(_ context.Context, req
 	}
 }
 
+// ContentJSONSemanticDiff summarizes a change of content_json in a warning,
+// ignoring IDs, the default values the API fills in, key order and the casing
+// of the keys, since the plan itself only shows two long strings. A
+// configuration equivalent to the state plans the state value, so that such
+// edits do not update the dashboard, and a content_json left out of the
+// configuration plans null, as content_json is only Computed for the former.
+type ContentJSONSemanticDiff struct{}
+
+func (m ContentJSONSemanticDiff) Description(_ context.Context) string {
+	return "Shows the changes of the dashboard, ignoring IDs and default values, as a structural diff."
+}
+
+func (m ContentJSONSemanticDiff) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m ContentJSONSemanticDiff) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.ConfigValue.IsNull() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() || req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	difference, explanation, err := CompareContentJSON(req.StateValue.ValueString(), req.PlanValue.ValueString())
+	if err != nil {
+		// ContentJsonValidator reports an invalid configuration.
+		return
+	}
+	if difference == jsondiff.FullMatch {
+		resp.PlanValue = req.StateValue
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(req.Path, "Dashboard content_json changes",
+		"Changes to the dashboard, ignoring IDs and default values:\n\n"+explanation)
+}
+
+var contentJSONDiffOptions = jsondiff.Options{
+	Added:                 jsondiff.Tag{Begin: "+ "},
+	Removed:               jsondiff.Tag{Begin: "- "},
+	Changed:               jsondiff.Tag{Begin: "~ "},
+	ChangedSeparator:      " => ",
+	SkippedArrayElement:   jsondiff.SkippedArrayElement,
+	SkippedObjectProperty: jsondiff.SkippedObjectProperty,
+	Indent:                "  ",
+	SkipMatches:           true,
+}
+
+// CompareContentJSON compares the dashboard in current, as returned by the
+// API or kept in state, with the dashboard in configured. Both are compared
+// with the keys the API uses and without IDs or null and empty properties. A
+// default value is only ignored where the other dashboard leaves the property
+// out, as the API fills those in or leaves them out by itself; a value both
+// dashboards set is always compared.
+func CompareContentJSON(current, configured string) (jsondiff.Difference, string, error) {
+	currentValue, err := canonicalContentJSON(current)
+	if err != nil {
+		return jsondiff.NoMatch, "", err
+	}
+	configuredValue, err := canonicalContentJSON(configured)
+	if err != nil {
+		return jsondiff.NoMatch, "", err
+	}
+	// Only defaults missing from the other dashboard are dropped, so the
+	// second call sees the same properties as the first.
+	currentValue = withoutContentJSONDefaults(currentValue, configuredValue)
+	configuredValue = withoutContentJSONDefaults(configuredValue, currentValue)
+
+	currentJSON, err := json.Marshal(currentValue)
+	if err != nil {
+		return jsondiff.NoMatch, "", err
+	}
+	configuredJSON, err := json.Marshal(configuredValue)
+	if err != nil {
+		return jsondiff.NoMatch, "", err
+	}
+	difference, explanation := jsondiff.Compare(currentJSON, configuredJSON, &contentJSONDiffOptions)
+	return difference, explanation, nil
+}
+
+// canonicalContentJSON decodes the dashboard in content with the keys the API
+// uses, without IDs and without null or empty properties.
+func canonicalContentJSON(content string) (any, error) {
+	dashboard := new(dashboardservice.Dashboard)
+	if err := dashboardjson.Unmarshal([]byte(content), dashboard); err != nil {
+		return nil, err
+	}
+	canonical, err := json.Marshal(dashboard)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	decoder := json.NewDecoder(strings.NewReader(string(canonical)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return withoutContentJSONIDs(value), nil
+}
+
+func withoutContentJSONIDs(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, property := range v {
+			property = withoutContentJSONIDs(property)
+			if key == "id" || isContentJSONEmpty(property) {
+				delete(v, key)
+				continue
+			}
+			v[key] = property
+		}
+		return v
+	case []any:
+		for i, element := range v {
+			v[i] = withoutContentJSONIDs(element)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// withoutContentJSONDefaults drops the object properties of value that hold a
+// default value and are missing from other. Array elements are kept, their
+// position is part of the layout.
+func withoutContentJSONDefaults(value, other any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		otherObject, _ := other.(map[string]any)
+		for key, property := range v {
+			otherProperty, inOther := otherObject[key]
+			property = withoutContentJSONDefaults(property, otherProperty)
+			if !inOther && isContentJSONDefault(property) {
+				delete(v, key)
+				continue
+			}
+			v[key] = property
+		}
+		return v
+	case []any:
+		otherArray, _ := other.([]any)
+		for i, element := range v {
+			var otherElement any
+			if i < len(otherArray) {
+				otherElement = otherArray[i]
+			}
+			v[i] = withoutContentJSONDefaults(element, otherElement)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isContentJSONEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// isContentJSONDefault reports the zero values the API returns for properties
+// it was not given, including enums left unspecified.
+func isContentJSONDefault(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return !v
+	case string:
+		return v == "" || strings.HasSuffix(v, "_UNSPECIFIED")
+	case json.Number:
+		number, err := v.Float64()
+		return err == nil && number == 0
+	default:
+		return isContentJSONEmpty(v)
+	}
+}
+
 // NullWhenContentJSONManaged plans a null value for an attribute that
 // content_json always leaves null in state.
 //
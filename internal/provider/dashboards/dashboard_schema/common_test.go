@@ -16,17 +16,128 @@ package dashboard_schema
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/nsf/jsondiff"
 )
 
+func TestContentJSONSemanticDiff(t *testing.T) {
+	t.Parallel()
+
+	state := types.StringValue(`{"name":"checkout","layout":{"sections":[{"id":{"value":"2f0d1e36-1111-4b44-9a53-96c6d3d5b7c1"},"rows":[{"appearance":{"height":19},"widgets":[{"title":"Requests","definition":{"markdown":{"markdownText":"runbook"}}}]}]}]}}`)
+
+	tests := []struct {
+		name        string
+		config      types.String
+		wantState   bool
+		wantWarning string
+	}{
+		{
+			name:      "generated ids and default values are ignored and keep the state",
+			config:    types.StringValue(`{"layout":{"sections":[{"options":null,"rows":[{"id":{"value":"8b0c0bd6-2222-4a57-8f3c-1f6ac1d02f9e"},"appearance":{"height":19},"widgets":[{"id":{"value":"0b5c1a9e-3333-4a44-bb1e-7a3f3cbf6a41"},"title":"Requests","description":"","definition":{"markdown":{"markdownText":"runbook","tooltipText":""}}}]}]}]},"name":"checkout","variables":[],"annotations":[]}`),
+			wantState: true,
+		},
+		{
+			name:        "other changes are shown in a warning",
+			config:      types.StringValue(`{"name":"checkout","layout":{"sections":[{"rows":[{"appearance":{"height":30},"widgets":[{"title":"Requests","definition":{"markdown":{"markdownText":"runbook"}}}]}]}]}}`),
+			wantWarning: `"height": ~ 19 => 30`,
+		},
+		{
+			name:   "content_json left out of the configuration is planned null",
+			config: types.StringNull(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := planmodifier.StringRequest{
+				Path:        path.Root("content_json"),
+				ConfigValue: tt.config,
+				PlanValue:   tt.config,
+				StateValue:  state,
+			}
+			if tt.config.IsNull() {
+				req.PlanValue = types.StringUnknown()
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			ContentJSONSemanticDiff{}.PlanModifyString(context.Background(), req, resp)
+
+			wantPlan := tt.config
+			if tt.wantState {
+				wantPlan = state
+			}
+			if !resp.PlanValue.Equal(wantPlan) {
+				t.Fatalf("expected PlanValue %v, got %v", wantPlan, resp.PlanValue)
+			}
+			warnings := resp.Diagnostics.Warnings()
+			if tt.wantWarning == "" {
+				if len(warnings) != 0 {
+					t.Fatalf("expected no warnings, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), tt.wantWarning) {
+				t.Fatalf("expected a warning containing %q, got %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestCompareContentJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		current    string
+		configured string
+		wantMatch  bool
+	}{
+		{
+			name:       "defaults filled in by the api are ignored",
+			current:    `{"name":"checkout","layout":{"sections":[{"options":{"custom":{"name":"Main","collapsed":false}},"rows":[{"appearance":{"height":19},"widgets":[{"title":"Requests","description":"","definition":{"markdown":{"markdownText":"runbook","tooltipText":""}}}]}]}]}}`,
+			configured: `{"name":"checkout","layout":{"sections":[{"options":{"custom":{"name":"Main"}},"rows":[{"appearance":{"height":19},"widgets":[{"title":"Requests","definition":{"markdown":{"markdownText":"runbook"}}}]}]}]}}`,
+			wantMatch:  true,
+		},
+		{
+			name:       "configured defaults left out by the api are ignored",
+			current:    `{"name":"checkout","layout":{"sections":[{"options":{"custom":{"name":"Main"}}}]}}`,
+			configured: `{"name":"checkout","layout":{"sections":[{"options":{"custom":{"name":"Main","collapsed":false}}}]}}`,
+			wantMatch:  true,
+		},
+		{
+			name:       "a default set on both sides is compared",
+			current:    `{"name":"checkout","layout":{"sections":[{"options":{"custom":{"name":"Main","collapsed":true}}}]}}`,
+			configured: `{"name":"checkout","layout":{"sections":[{"options":{"custom":{"name":"Main","collapsed":false}}}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			difference, explanation, err := CompareContentJSON(tt.current, tt.configured)
+			if err != nil {
+				t.Fatalf("CompareContentJSON returned an error: %s", err)
+			}
+			if got := difference == jsondiff.FullMatch; got != tt.wantMatch {
+				t.Fatalf("CompareContentJSON() match = %v, want %v:\n%s", got, tt.wantMatch, explanation)
+			}
+		})
+	}
+}
+
 func TestPreserveStateForEquivalentJSON(t *testing.T) {
 	t.Parallel()
 
@@ -16,6 +16,7 @@ package dashboard_schema
 
 import (
 	"fmt"
+	"maps"
 	"strings"
 
 	dashboardwidgets "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_widgets"
@@ -30,6 +31,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -50,7 +52,7 @@ func V4() schema.Schema {
 }
 
 func dashboardSchemaAttributesV4() map[string]schema.Attribute {
-	return map[string]schema.Attribute{
+	attributes := map[string]schema.Attribute{
 		"id": schema.StringAttribute{
 			Computed: true,
 			PlanModifiers: []planmodifier.String{
@@ -912,6 +914,7 @@ func dashboardSchemaAttributesV4() map[string]schema.Attribute {
 			Validators: []validator.Object{
 				dashboardwidgets.ExactlyOneOfObject(
 					path.MatchRelative().AtParent().AtName("content_json"),
+					path.MatchRelative().AtParent().AtName("auto_layout"),
 				),
 			},
 		},
@@ -1181,14 +1184,17 @@ func dashboardSchemaAttributesV4() map[string]schema.Attribute {
 				},
 				"path": schema.StringAttribute{
 					Optional: true,
-					MarkdownDescription: "Slash-separated folder path (e.g. `Team/Subteam`). When set " +
-						"on a `coralogix_dashboard` resource and the path does not already exist, the " +
+					MarkdownDescription: "Slash-separated folder path (e.g. `Team/Subteam`). The path is " +
+						"resolved against the existing dashboards folders, so it can address a folder " +
+						"declared by a `coralogix_dashboards_folder_tree` resource. When set on a " +
+						"`coralogix_dashboard` resource and the path does not already exist, the " +
 						"Coralogix dashboards service implicitly creates the missing folder hierarchy " +
 						"as a server-side side-effect of placing the dashboard. **That auto-created " +
 						"folder is not tracked in Terraform state and is not removed when the " +
 						"dashboard is destroyed — it will be left behind as an orphan in the Coralogix " +
-						"UI.** Use `folder.id` (referencing a `coralogix_dashboards_folder` resource) " +
-						"for symmetric apply/destroy semantics.",
+						"UI.** Declare the path in a `coralogix_dashboards_folder_tree` resource the " +
+						"dashboard depends on, or use `folder.id` (referencing a `coralogix_dashboards_folder` " +
+						"resource), for symmetric apply/destroy semantics.",
 				},
 			},
 			Optional: true,
@@ -1197,9 +1203,10 @@ func dashboardSchemaAttributesV4() map[string]schema.Attribute {
 			},
 			MarkdownDescription: "The dashboards folder this dashboard belongs to. Exactly one of " +
 				"`id` or `path` is set. When authoring a `coralogix_dashboard` resource, `id` (pointing " +
-				"at a `coralogix_dashboards_folder` resource) is the recommended form; `path` is " +
-				"accepted but can trigger implicit server-side folder creation that Terraform will " +
-				"not clean up on destroy — see the `path` attribute description for details.",
+				"at a `coralogix_dashboards_folder` resource) or `path` (of a `coralogix_dashboards_folder_tree` " +
+				"resource) are the recommended forms; a `path` outside of Terraform can trigger implicit " +
+				"server-side folder creation that Terraform will not clean up on destroy — see the " +
+				"`path` attribute description for details.",
 		},
 		"annotations": schema.ListNestedAttribute{
 			Optional: true,
@@ -1312,12 +1319,14 @@ func dashboardSchemaAttributesV4() map[string]schema.Attribute {
 		},
 		"content_json": schema.StringAttribute{
 			Optional: true,
+			Computed: true,
 			Validators: []validator.String{
 				stringvalidator.ConflictsWith(
 					path.MatchRelative().AtParent().AtName("id"),
 					path.MatchRelative().AtParent().AtName("name"),
 					path.MatchRelative().AtParent().AtName("description"),
 					path.MatchRelative().AtParent().AtName("layout"),
+					path.MatchRelative().AtParent().AtName("auto_layout"),
 					path.MatchRelative().AtParent().AtName("variables"),
 					path.MatchRelative().AtParent().AtName("variables_v2"),
 					path.MatchRelative().AtParent().AtName("filters"),
@@ -1327,9 +1336,9 @@ func dashboardSchemaAttributesV4() map[string]schema.Attribute {
 				ContentJsonValidator{},
 			},
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplaceIf(utils.JSONStringsEqualPlanModifier, "", ""),
+				ContentJSONSemanticDiff{},
 			},
-			Description: "an option to set the dashboard content from a json file.",
+			Description: "an option to set the dashboard content from a json file. IDs and default values the API adds are not reported as changes made outside of Terraform, and changes of the json are summarized in a plan warning, ignoring IDs and default values. Changes of the json, and changes made outside of Terraform, update the dashboard in place.",
 		},
 		"access_policy": schema.StringAttribute{
 			Optional:            true,
@@ -1338,4 +1347,63 @@ func dashboardSchemaAttributesV4() map[string]schema.Attribute {
 			MarkdownDescription: "JSON-encoded access policy for this dashboard.",
 		},
 	}
+	attributes["auto_layout"] = autoLayoutSchemaV4(attributes["layout"].(schema.SingleNestedAttribute))
+
+	return attributes
+}
+
+// autoLayoutSchemaV4 takes its widgets from the widgets of layout, so that
+// both attributes accept the same widget definitions.
+func autoLayoutSchemaV4(layout schema.SingleNestedAttribute) schema.SingleNestedAttribute {
+	sections := layout.Attributes["sections"].(schema.ListNestedAttribute)
+	rows := sections.NestedObject.Attributes["rows"].(schema.ListNestedAttribute)
+	widgets := rows.NestedObject.Attributes["widgets"].(schema.ListNestedAttribute)
+
+	widgetAttributes := maps.Clone(widgets.NestedObject.Attributes)
+	delete(widgetAttributes, "width")
+	widgetAttributes["section"] = schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Name of the section of the widget. Consecutive widgets with the same section share it, widgets without a section are placed in sections without a name.",
+	}
+	widgetAttributes["size"] = schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(dashboardwidgets.AutoLayoutDefaultWidgetSize),
+		Validators: []validator.String{
+			stringvalidator.OneOf(dashboardwidgets.AutoLayoutValidWidgetSizes...),
+		},
+		MarkdownDescription: fmt.Sprintf("Width of the widget, valid values: %v. A row is %d columns wide and takes widgets until the next one does not fit; small widgets take %d columns, medium %d, large %d and full the whole row. "+
+			"The API does not keep widget widths, so the size only decides which widgets share a row.",
+			dashboardwidgets.AutoLayoutValidWidgetSizes, dashboardwidgets.AutoLayoutRowColumns,
+			dashboardwidgets.AutoLayoutWidgetSpans["small"], dashboardwidgets.AutoLayoutWidgetSpans["medium"], dashboardwidgets.AutoLayoutWidgetSpans["large"]),
+	}
+	widgetAttributes["height"] = schema.Int64Attribute{
+		Optional: true,
+		Computed: true,
+		Default:  int64default.StaticInt64(dashboardwidgets.AutoLayoutDefaultRowHeight),
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+		MarkdownDescription: "Minimum height of the row of the widget. A row is as high as its highest widget.",
+	}
+
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"widgets": schema.ListNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: widgetAttributes,
+					Validators: widgets.NestedObject.Validators,
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				MarkdownDescription: "The widgets of the dashboard, in order.",
+			},
+		},
+		MarkdownDescription: "Layout computed from a flat list of widgets, as an alternative to `layout`. " +
+			"Widgets are placed in order, starting a new section whenever `section` changes and a new row whenever the next widget does not fit. " +
+			"Only the widgets are kept in the Terraform state, so the computed sections and rows do not show up in plans.",
+	}
 }
@@ -114,8 +114,9 @@ func sourceV2Schema() schema.SingleNestedAttribute {
 			"static":  staticSourceV2Schema(),
 			"textbox": textboxSourceV2Schema(),
 			"query":   querySourceV2Schema(),
+			"csv":     csvSourceV2Schema(),
 		},
-		Validators: []validator.Object{dashboardwidgets.ExactlyOneOfChildren("static", "query", "textbox")},
+		Validators: []validator.Object{dashboardwidgets.ExactlyOneOfChildren("static", "query", "textbox", "csv")},
 	}
 }
 
@@ -153,6 +154,35 @@ func staticSourceV2Schema() schema.SingleNestedAttribute {
 	}
 }
 
+func csvSourceV2Schema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"content": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "CSV content with a header row, usually `coralogix_data_enrichments.custom.custom_enrichment_data.contents` or `coralogix_data_set.file_content`, " +
+					"so the variable options follow the enrichment table.",
+			},
+			"value_column": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Header of the column holding the option values. Rows with an empty value are skipped and repeated values are listed once.",
+			},
+			"label_column": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Header of the column holding the option labels. When omitted, labels are the values.",
+			},
+			"default_values": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Values selected by default. Each must be a value of `value_column`.",
+			},
+			"values_order_direction": enumAttribute(dashboardwidgets.DashboardValidOrderDirectionsV2, "none"),
+			"all_option":             allOptionV2Schema(),
+		},
+		MarkdownDescription: "Options read from a CSV, such as a custom enrichment file. The provider sends them to Coralogix as a `static` source.",
+	}
+}
+
 type displayFullRowTextboxValidator struct{}
 
 func (displayFullRowTextboxValidator) Description(context.Context) string {
@@ -330,6 +330,22 @@ var (
 		"markdown",
 		"dynamic",
 	}
+
+	// AutoLayoutWidgetSpans maps the sizes of auto_layout widgets to the
+	// columns they take in a row of AutoLayoutRowColumns.
+	AutoLayoutWidgetSpans = map[string]int64{
+		"small":  3,
+		"medium": 4,
+		"large":  6,
+		"full":   12,
+	}
+	AutoLayoutValidWidgetSizes = utils.GetKeys(AutoLayoutWidgetSpans)
+)
+
+const (
+	AutoLayoutRowColumns        = 12
+	AutoLayoutDefaultWidgetSize = "medium"
+	AutoLayoutDefaultRowHeight  = 19
 )
 
 // OptionalEnumPointer converts a Terraform string to an OpenAPI enum pointer.
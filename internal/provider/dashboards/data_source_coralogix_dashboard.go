@@ -20,10 +20,13 @@ import (
 	"log"
 
 	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	dashboardwidgets "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_widgets"
 	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ datasource.DataSourceWithConfigure = &DashboardDataSource{}
@@ -36,6 +39,24 @@ type DashboardDataSource struct {
 	client *dashboardOpenAPIClient
 }
 
+// DashboardDataSourceModel is DashboardResourceModel without auto_layout,
+// which only describes how a resource arranges the widgets it is given.
+type DashboardDataSourceModel struct {
+	ID           types.String                     `tfsdk:"id"`
+	Name         types.String                     `tfsdk:"name"`
+	Description  types.String                     `tfsdk:"description"`
+	Layout       types.Object                     `tfsdk:"layout"`    //DashboardLayoutModel
+	Variables    types.List                       `tfsdk:"variables"` //DashboardVariableModel
+	VariablesV2  types.List                       `tfsdk:"variables_v2"`
+	Filters      types.List                       `tfsdk:"filters"` //DashboardFilterModel
+	TimeFrame    *dashboardwidgets.TimeFrameModel `tfsdk:"time_frame"`
+	Folder       types.Object                     `tfsdk:"folder"`       //DashboardFolderModel
+	Annotations  types.List                       `tfsdk:"annotations"`  //DashboardAnnotationModel
+	AutoRefresh  types.Object                     `tfsdk:"auto_refresh"` //DashboardAutoRefreshModel
+	ContentJson  types.String                     `tfsdk:"content_json"`
+	AccessPolicy types.String                     `tfsdk:"access_policy"`
+}
+
 func (d *DashboardDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_dashboard"
 }
@@ -63,17 +84,18 @@ func (d *DashboardDataSource) Schema(ctx context.Context, _ datasource.SchemaReq
 	r.Schema(ctx, resource.SchemaRequest{}, &resourceResp)
 
 	resp.Schema = utils.FrameworkDatasourceSchemaFromFrameworkResourceSchema(resourceResp.Schema)
+	delete(resp.Schema.Attributes, "auto_layout")
 }
 
 func (d *DashboardDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data DashboardResourceModel
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	var idValue types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &idValue)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	//Get refreshed Dashboard value from Coralogix
-	id := data.ID.ValueString()
+	id := idValue.ValueString()
 	log.Printf("[INFO] Reading Dashboard: %s", id)
 	getDashboardResp, err := d.client.Get(ctx, id)
 	if err != nil {
@@ -92,7 +114,21 @@ func (d *DashboardDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	data = *dashboard
+	data := DashboardDataSourceModel{
+		ID:           dashboard.ID,
+		Name:         dashboard.Name,
+		Description:  dashboard.Description,
+		Layout:       dashboard.Layout,
+		Variables:    dashboard.Variables,
+		VariablesV2:  dashboard.VariablesV2,
+		Filters:      dashboard.Filters,
+		TimeFrame:    dashboard.TimeFrame,
+		Folder:       dashboard.Folder,
+		Annotations:  dashboard.Annotations,
+		AutoRefresh:  dashboard.AutoRefresh,
+		ContentJson:  dashboard.ContentJson,
+		AccessPolicy: dashboard.AccessPolicy,
+	}
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,300 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+
+	"github.com/coralogix/coralogix-management-sdk/go/openapi/dashboardjson"
+	dashboardservice "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/dashboard_service"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &DashboardQueriesDataSource{}
+
+// dashboardQueryLanguages maps the keys of the query objects of a dashboard
+// JSON to the query_language they are reported with, and the key of the
+// query text inside them.
+var dashboardQueryLanguages = map[string]struct{ language, text string }{
+	"promqlQuery":    {"promql", "value"},
+	"luceneQuery":    {"lucene", "value"},
+	"dataprimeQuery": {"dataprime", "text"},
+}
+
+func NewDashboardQueriesDataSource() datasource.DataSource {
+	return &DashboardQueriesDataSource{}
+}
+
+type DashboardQueriesDataSource struct {
+	client *dashboardOpenAPIClient
+}
+
+type DashboardQueriesDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ContentJson types.String `tfsdk:"content_json"`
+	Queries     types.List   `tfsdk:"queries"` // []DashboardQueryModel
+}
+
+type DashboardQueryModel struct {
+	WidgetID      types.String `tfsdk:"widget_id"`
+	Title         types.String `tfsdk:"title"`
+	Type          types.String `tfsdk:"type"`
+	QueryLanguage types.String `tfsdk:"query_language"`
+	Query         types.String `tfsdk:"query"`
+}
+
+func dashboardQueryModelAttr() map[string]attr.Type {
+	return map[string]attr.Type{
+		"widget_id":      types.StringType,
+		"title":          types.StringType,
+		"type":           types.StringType,
+		"query_language": types.StringType,
+		"query":          types.StringType,
+	}
+}
+
+func (d *DashboardQueriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_queries"
+}
+
+func (d *DashboardQueriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = newDashboardOpenAPIClient(clientSet.Dashboards())
+}
+
+func (d *DashboardQueriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the queries of every widget of a dashboard, whether the dashboard is managed with `layout`, `auto_layout` or `content_json`. " +
+			"Widgets without a query, like markdown widgets, are left out.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the dashboard to read from Coralogix.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("content_json")),
+				},
+			},
+			"content_json": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Dashboard JSON to read the queries from instead, in the format of `coralogix_dashboard.content_json`. Coralogix is not called.",
+			},
+			"queries": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Queries in the order of the widgets in the layout, and of the query definitions of a widget.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"widget_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the widget.",
+						},
+						"title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Title of the widget.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the widget, named like the `definition` attribute of `coralogix_dashboard`, e.g. `line_chart` or `data_table`.",
+						},
+						"query_language": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "One of `promql`, `lucene` and `dataprime`.",
+						},
+						"query": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Text of the query. Empty for a Lucene query matching everything.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DashboardQueriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DashboardQueriesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dashboard := new(dashboardservice.Dashboard)
+	if !data.ContentJson.IsNull() {
+		if err := dashboardjson.Unmarshal([]byte(data.ContentJson.ValueString()), dashboard); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("content_json"), "Error unmarshalling dashboard content json", err.Error())
+			return
+		}
+	} else {
+		id := data.ID.ValueString()
+		log.Printf("[INFO] Reading Dashboard: %s", id)
+		getDashboardResp, err := d.client.Get(ctx, id)
+		if err != nil {
+			log.Printf("[ERROR] Received error: %s", err.Error())
+			resp.Diagnostics.AddError("Error reading Dashboard", err.Error())
+			return
+		}
+		dashboard = getDashboardResp.Dashboard
+	}
+
+	content, err := json.Marshal(dashboard)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading dashboard queries", err.Error())
+		return
+	}
+	queries, err := dashboardWidgetQueries(content)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading dashboard queries", err.Error())
+		return
+	}
+
+	elements := make([]DashboardQueryModel, 0, len(queries))
+	for _, query := range queries {
+		elements = append(elements, DashboardQueryModel{
+			WidgetID:      types.StringValue(query.widgetID),
+			Title:         types.StringValue(query.title),
+			Type:          types.StringValue(query.widgetType),
+			QueryLanguage: types.StringValue(query.language),
+			Query:         types.StringValue(query.query),
+		})
+	}
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: dashboardQueryModelAttr()}, elements)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Queries = list
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+type dashboardWidgetQuery struct {
+	widgetID, title, widgetType, language, query string
+}
+
+type dashboardQueriesJSON struct {
+	Layout struct {
+		Sections []struct {
+			Rows []struct {
+				Widgets []struct {
+					ID struct {
+						Value string `json:"value"`
+					} `json:"id"`
+					Title      string                     `json:"title"`
+					Definition map[string]json.RawMessage `json:"definition"`
+				} `json:"widgets"`
+			} `json:"rows"`
+		} `json:"sections"`
+	} `json:"layout"`
+}
+
+// dashboardWidgetQueries extracts the queries of the widgets of a dashboard
+// JSON. Queries are found wherever they are nested in the widget definition,
+// so every widget type and query data source is covered.
+func dashboardWidgetQueries(content []byte) ([]dashboardWidgetQuery, error) {
+	var dashboard dashboardQueriesJSON
+	if err := json.Unmarshal(content, &dashboard); err != nil {
+		return nil, err
+	}
+
+	var queries []dashboardWidgetQuery
+	for _, section := range dashboard.Layout.Sections {
+		for _, row := range section.Rows {
+			for _, widget := range row.Widgets {
+				for widgetType, definition := range widget.Definition {
+					var value any
+					if err := json.Unmarshal(definition, &value); err != nil {
+						return nil, err
+					}
+					collectDashboardQueries(value, func(language, query string) {
+						queries = append(queries, dashboardWidgetQuery{
+							widgetID:   widget.ID.Value,
+							title:      widget.Title,
+							widgetType: dashboardQueriesSnakeCase(widgetType),
+							language:   language,
+							query:      query,
+						})
+					})
+				}
+			}
+		}
+	}
+	return queries, nil
+}
+
+func collectDashboardQueries(value any, add func(language, query string)) {
+	switch value := value.(type) {
+	case []any:
+		for _, element := range value {
+			collectDashboardQueries(element, add)
+		}
+	case map[string]any:
+		// Keys are sorted so the queries of a widget keep the same order
+		// between reads.
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if language, ok := dashboardQueryLanguages[key]; ok {
+				if query, ok := value[key].(map[string]any); ok {
+					text, _ := query[language.text].(string)
+					add(language.language, text)
+					continue
+				}
+			}
+			collectDashboardQueries(value[key], add)
+		}
+	}
+}
+
+func dashboardQueriesSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDashboardWidgetQueriesContentJSON(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "testdata", "dashboards", "content_json_dynamic_queries_table.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := dashboardWidgetQueries(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []dashboardWidgetQuery{
+		{"30000000-0000-4000-8000-000000000001", "Dynamic logs table", "dynamic", "lucene", ""},
+		{"30000000-0000-4000-8000-000000000002", "Dynamic metrics table", "dynamic", "promql", "up"},
+		{"30000000-0000-4000-8000-000000000003", "Dynamic spans table", "dynamic", "lucene", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestDashboardWidgetQueriesNestedDefinitions(t *testing.T) {
+	content := []byte(`{
+		"name": "queries",
+		"layout": {"sections": [{"rows": [
+			{"widgets": [
+				{"id": {"value": "line"}, "title": "Requests", "definition": {"lineChart": {"queryDefinitions": [
+					{"id": "a", "query": {"metrics": {"promqlQuery": {"value": "sum(rate(http_requests_total[5m]))"}}}},
+					{"id": "b", "query": {"dataprime": {"dataprimeQuery": {"text": "source logs | count"}, "filters": []}}}
+				]}}},
+				{"id": {"value": "notes"}, "title": "Notes", "definition": {"markdown": {"markdownText": "# notes"}}}
+			]},
+			{"widgets": [
+				{"id": {"value": "bars"}, "title": "Errors", "definition": {"horizontalBarChart": {"query": {"logs": {"luceneQuery": {"value": "level:error"}}}}}}
+			]}
+		]}]}
+	}`)
+
+	got, err := dashboardWidgetQueries(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []dashboardWidgetQuery{
+		{"line", "Requests", "line_chart", "promql", "sum(rate(http_requests_total[5m]))"},
+		{"line", "Requests", "line_chart", "dataprime", "source logs | count"},
+		{"bars", "Errors", "horizontal_bar_chart", "lucene", "level:error"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,799 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	dashboardservice "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/dashboard_service"
+	dashboardwidgets "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_widgets"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = &GrafanaToDashboardJSONFunction{}
+
+	// Grafana units mapped to the unit names of the dashboard schema. Both the
+	// common and the gauge unit maps share these names.
+	grafanaUnits = map[string]string{
+		"percent":     "percent100",
+		"percentunit": "percent01",
+		"bytes":       "bytes_iec",
+		"decbytes":    "bytes",
+		"kbytes":      "kibytes",
+		"deckbytes":   "kbytes",
+		"mbytes":      "mibytes",
+		"decmbytes":   "mbytes",
+		"gbytes":      "gibytes",
+		"decgbytes":   "gbytes",
+		"s":           "seconds",
+		"ms":          "milliseconds",
+		"µs":          "microseconds",
+		"ns":          "nanoseconds",
+		"currencyUSD": "usd",
+		"currencyEUR": "euro",
+	}
+
+	grafanaTimeUnits = map[string]int64{
+		"s": 1,
+		"m": 60,
+		"h": 60 * 60,
+		"d": 24 * 60 * 60,
+		"w": 7 * 24 * 60 * 60,
+		"M": 30 * 24 * 60 * 60,
+		"y": 365 * 24 * 60 * 60,
+	}
+
+	grafanaRelativeTime      = regexp.MustCompile(`^now-(\d+)([smhdwMy])$`)
+	grafanaLabelValues       = regexp.MustCompile(`^\s*label_values\(\s*([^,]+?)\s*,\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\)\s*$`)
+	grafanaVariableReference = regexp.MustCompile(`\$\{(\w+)(?::[^}]*)?\}|\[\[(\w+)(?::[^\]]*)?\]\]|\$(\w+)`)
+	grafanaLegendLabel       = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+	grafanaByClause          = regexp.MustCompile(`\bby\s*\(([^)]*)\)`)
+)
+
+// Default Grafana panels are 8 grid units high, default dashboard rows 19.
+const (
+	grafanaPanelHeight      = 8
+	dashboardRowHeight      = 19
+	grafanaAllValue         = "$__all"
+	grafanaPrometheusSource = "prometheus"
+)
+
+func NewGrafanaToDashboardJSONFunction() function.Function {
+	return &GrafanaToDashboardJSONFunction{}
+}
+
+type GrafanaToDashboardJSONFunction struct{}
+
+type GrafanaToDashboardJSONResultModel struct {
+	ContentJson types.String `tfsdk:"content_json"`
+	Warnings    types.List   `tfsdk:"warnings"` //types.String
+}
+
+func grafanaToDashboardJSONResultAttr() map[string]attr.Type {
+	return map[string]attr.Type{
+		"content_json": types.StringType,
+		"warnings":     types.ListType{ElemType: types.StringType},
+	}
+}
+
+func (f *GrafanaToDashboardJSONFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "grafana_to_dashboard_json"
+}
+
+func (f *GrafanaToDashboardJSONFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts a Grafana dashboard to coralogix_dashboard content_json.",
+		MarkdownDescription: "Converts the JSON model of a Grafana dashboard to JSON accepted by `coralogix_dashboard.content_json`. " +
+			"`timeseries` and `graph` panels become `line_chart` widgets, `stat` and `gauge` panels `gauge` widgets, `piechart` panels `pie_chart` widgets, " +
+			"`barchart` panels `bar_chart` widgets, `table` panels `data_table` widgets and `text` panels `markdown` widgets. " +
+			"Grafana rows become sections and panels on the same grid line share a row. Only PromQL targets are converted. " +
+			"`query` variables using `label_values(metric, label)`, `custom` and `constant` variables become `multi_select` variables, and references to them are rewritten to `{{ name }}`. " +
+			"Anything that cannot be converted is left out and described in `warnings`; functions cannot emit warnings, so check it with a `check` block or an output.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "Grafana dashboard JSON model, as exported from the Grafana UI or returned by the `/api/dashboards/uid/:uid` API.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: grafanaToDashboardJSONResultAttr(),
+		},
+	}
+}
+
+func (f *GrafanaToDashboardJSONFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &content))
+	if resp.Error != nil {
+		return
+	}
+
+	dashboard, warnings, err := grafanaToDashboard([]byte(content))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	contentJson, err := json.Marshal(dashboard)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Error marshalling dashboard content json: %s", err))
+		return
+	}
+
+	warningsValue, diags := types.ListValueFrom(ctx, types.StringType, warnings)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	value, diags := types.ObjectValueFrom(ctx, grafanaToDashboardJSONResultAttr(), GrafanaToDashboardJSONResultModel{
+		ContentJson: types.StringValue(string(contentJson)),
+		Warnings:    warningsValue,
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, value))
+}
+
+type grafanaDashboard struct {
+	UID         string `json:"uid"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Time        *struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	} `json:"time"`
+	Templating struct {
+		List []grafanaVariable `json:"list"`
+	} `json:"templating"`
+	Panels []grafanaPanel    `json:"panels"`
+	Rows   []json.RawMessage `json:"rows"`
+}
+
+type grafanaPanel struct {
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	GridPos     struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+		H int `json:"h"`
+	} `json:"gridPos"`
+	Collapsed   bool            `json:"collapsed"`
+	Panels      []grafanaPanel  `json:"panels"`
+	Repeat      string          `json:"repeat"`
+	Datasource  json.RawMessage `json:"datasource"`
+	Targets     []grafanaTarget `json:"targets"`
+	FieldConfig struct {
+		Defaults struct {
+			Unit string   `json:"unit"`
+			Min  *float64 `json:"min"`
+			Max  *float64 `json:"max"`
+		} `json:"defaults"`
+	} `json:"fieldConfig"`
+	// Options differ per panel type and are only decoded for text panels.
+	Options json.RawMessage `json:"options"`
+	// Content and Mode hold the text of text panels before Grafana 7.
+	Content string `json:"content"`
+	Mode    string `json:"mode"`
+}
+
+type grafanaTarget struct {
+	RefID        string          `json:"refId"`
+	Expr         string          `json:"expr"`
+	LegendFormat string          `json:"legendFormat"`
+	Instant      bool            `json:"instant"`
+	Range        bool            `json:"range"`
+	Hide         bool            `json:"hide"`
+	Datasource   json.RawMessage `json:"datasource"`
+}
+
+type grafanaVariable struct {
+	Type       string          `json:"type"`
+	Name       string          `json:"name"`
+	Label      string          `json:"label"`
+	Query      json.RawMessage `json:"query"`
+	Definition string          `json:"definition"`
+	Multi      bool            `json:"multi"`
+	Current    struct {
+		Value json.RawMessage `json:"value"`
+	} `json:"current"`
+}
+
+type grafanaConverter struct {
+	namespace uuid.UUID
+	variables map[string]bool
+	warnings  []string
+	warned    map[string]bool
+}
+
+// grafanaToDashboard converts a Grafana dashboard JSON model, optionally
+// wrapped in the {"dashboard": ...} envelope of the Grafana API, and returns
+// warnings for everything that was left out.
+func grafanaToDashboard(content []byte) (*dashboardservice.Dashboard, []string, error) {
+	var envelope struct {
+		Dashboard *grafanaDashboard `json:"dashboard"`
+	}
+	if err := json.Unmarshal(content, &envelope); err != nil {
+		return nil, nil, fmt.Errorf("invalid Grafana dashboard JSON: %s", err)
+	}
+	grafana := envelope.Dashboard
+	if grafana == nil {
+		grafana = new(grafanaDashboard)
+		if err := json.Unmarshal(content, grafana); err != nil {
+			return nil, nil, fmt.Errorf("invalid Grafana dashboard JSON: %s", err)
+		}
+	}
+	if strings.TrimSpace(grafana.Title) == "" {
+		return nil, nil, fmt.Errorf("the Grafana dashboard has no title")
+	}
+
+	seed := grafana.UID
+	if seed == "" {
+		seed = grafana.Title
+	}
+	c := &grafanaConverter{
+		// IDs derive from the dashboard and the panel positions so that the
+		// result does not change between plan and apply.
+		namespace: uuid.NewSHA1(uuid.NameSpaceURL, []byte("grafana:"+seed)),
+		variables: map[string]bool{},
+		warned:    map[string]bool{},
+	}
+
+	dashboard := &dashboardservice.Dashboard{
+		Name:              grafana.Title,
+		RelativeTimeFrame: c.convertTimeFrame(grafana),
+		Variables:         c.convertVariables(grafana.Templating.List),
+	}
+	if grafana.Description != "" {
+		dashboard.Description = pointerTo(grafana.Description)
+	}
+	if len(grafana.Rows) > 0 {
+		c.warn("the dashboard uses the rows layout of Grafana 4, save it in a newer Grafana version to convert its panels")
+	}
+	dashboard.Layout = c.convertLayout(grafana.Panels)
+
+	return dashboard, c.warnings, nil
+}
+
+func (c *grafanaConverter) warn(format string, args ...any) {
+	warning := fmt.Sprintf(format, args...)
+	if c.warned[warning] {
+		return
+	}
+	c.warned[warning] = true
+	c.warnings = append(c.warnings, warning)
+}
+
+func (c *grafanaConverter) id(path string) string {
+	return uuid.NewSHA1(c.namespace, []byte(path)).String()
+}
+
+func (c *grafanaConverter) uuid(path string) *dashboardservice.UUID {
+	return &dashboardservice.UUID{Value: pointerTo(c.id(path))}
+}
+
+func (c *grafanaConverter) convertTimeFrame(grafana *grafanaDashboard) *string {
+	if grafana.Time == nil {
+		return nil
+	}
+	match := grafanaRelativeTime.FindStringSubmatch(grafana.Time.From)
+	if match == nil || grafana.Time.To != "now" {
+		c.warn("time range %s to %s is not relative to now, the default time frame is used", grafana.Time.From, grafana.Time.To)
+		return nil
+	}
+	amount, _ := strconv.ParseInt(match[1], 10, 64)
+	return pointerTo(fmt.Sprintf("%ds", amount*grafanaTimeUnits[match[2]]))
+}
+
+func (c *grafanaConverter) convertVariables(grafanaVariables []grafanaVariable) []dashboardservice.Variable {
+	var variables []dashboardservice.Variable
+	for _, variable := range grafanaVariables {
+		source := c.convertVariableSource(variable)
+		if source == nil {
+			continue
+		}
+		c.variables[variable.Name] = true
+
+		displayName := variable.Label
+		if displayName == "" {
+			displayName = variable.Name
+		}
+		multiSelect := &dashboardservice.MultiSelect{
+			Source:    source,
+			Selection: grafanaVariableSelection(variable),
+		}
+		multiSelect.SelectionOptions = utils.NewLike(multiSelect.SelectionOptions)
+		if variable.Multi {
+			multiSelect.SelectionOptions.SelectionType = dashboardservice.SELECTIONTYPE_SELECTION_TYPE_MULTI.Ptr()
+		} else {
+			multiSelect.SelectionOptions.SelectionType = dashboardservice.SELECTIONTYPE_SELECTION_TYPE_SINGLE.Ptr()
+		}
+		variables = append(variables, dashboardservice.Variable{
+			Name:        pointerTo(variable.Name),
+			DisplayName: pointerTo(displayName),
+			Definition:  &dashboardservice.VariableDefinition{MultiSelect: multiSelect},
+		})
+	}
+	return variables
+}
+
+func (c *grafanaConverter) convertVariableSource(variable grafanaVariable) *dashboardservice.MultiSelectSource {
+	query := grafanaVariableQuery(variable)
+	switch variable.Type {
+	case "query":
+		match := grafanaLabelValues.FindStringSubmatch(query)
+		if match == nil {
+			c.warn("variable %q was left out, only label_values(metric, label) queries are supported", variable.Name)
+			return nil
+		}
+		metric := match[1]
+		if selector := strings.Index(metric, "{"); selector >= 0 {
+			c.warn("variable %q uses the label matchers of %s, only the metric name is kept", variable.Name, metric)
+			metric = strings.TrimSpace(metric[:selector])
+		}
+		return &dashboardservice.MultiSelectSource{
+			MetricLabel: &dashboardservice.MetricLabelSource{
+				MetricName: pointerTo(metric),
+				Label:      pointerTo(match[2]),
+			},
+		}
+	case "custom":
+		var values []string
+		for _, value := range strings.Split(query, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		return &dashboardservice.MultiSelectSource{ConstantList: &dashboardservice.ConstantListSource{Values: values}}
+	case "constant":
+		return &dashboardservice.MultiSelectSource{ConstantList: &dashboardservice.ConstantListSource{Values: []string{query}}}
+	default:
+		c.warn("variable %q was left out, %s variables are not supported", variable.Name, variable.Type)
+		return nil
+	}
+}
+
+// grafanaVariableQuery returns the query of a variable, which newer Grafana
+// versions store as an object.
+func grafanaVariableQuery(variable grafanaVariable) string {
+	var query string
+	if err := json.Unmarshal(variable.Query, &query); err == nil {
+		return query
+	}
+	var queryObject struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(variable.Query, &queryObject); err == nil && queryObject.Query != "" {
+		return queryObject.Query
+	}
+	return variable.Definition
+}
+
+func grafanaVariableSelection(variable grafanaVariable) *dashboardservice.MultiSelectSelection {
+	var values []string
+	if err := json.Unmarshal(variable.Current.Value, &values); err != nil {
+		var value string
+		if err := json.Unmarshal(variable.Current.Value, &value); err == nil && value != "" {
+			values = []string{value}
+		}
+	}
+	if len(values) == 0 || (len(values) == 1 && values[0] == grafanaAllValue) {
+		return &dashboardservice.MultiSelectSelection{All: map[string]interface{}{}}
+	}
+	return &dashboardservice.MultiSelectSelection{
+		List: &dashboardservice.MultiSelectSelectionListSelection{Values: values},
+	}
+}
+
+type grafanaSection struct {
+	title     string
+	collapsed bool
+	panels    []grafanaPanel
+}
+
+func (c *grafanaConverter) convertLayout(panels []grafanaPanel) dashboardservice.Layout {
+	sections := []*grafanaSection{{}}
+	for _, panel := range sortGrafanaPanels(panels) {
+		if panel.Type == "row" {
+			// Collapsed rows nest their panels, expanded rows are followed by them.
+			sections = append(sections, &grafanaSection{title: panel.Title, collapsed: panel.Collapsed, panels: panel.Panels})
+			continue
+		}
+		last := sections[len(sections)-1]
+		last.panels = append(last.panels, panel)
+	}
+	if len(sections) > 1 && len(sections[0].panels) == 0 {
+		sections = sections[1:]
+	}
+
+	layout := dashboardservice.Layout{Sections: []dashboardservice.Section{}}
+	for i, section := range sections {
+		path := fmt.Sprintf("section/%d", i)
+		converted := dashboardservice.Section{
+			Id:   c.uuid(path),
+			Rows: c.convertRows(path, section.panels),
+		}
+		if section.title != "" {
+			converted.Options = &dashboardservice.SectionOptions{
+				Custom: &dashboardservice.CustomSectionOptions{
+					Name:      pointerTo(section.title),
+					Collapsed: pointerTo(section.collapsed),
+				},
+			}
+		}
+		layout.Sections = append(layout.Sections, converted)
+	}
+	return layout
+}
+
+func sortGrafanaPanels(panels []grafanaPanel) []grafanaPanel {
+	sorted := append([]grafanaPanel(nil), panels...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].GridPos.Y != sorted[j].GridPos.Y {
+			return sorted[i].GridPos.Y < sorted[j].GridPos.Y
+		}
+		return sorted[i].GridPos.X < sorted[j].GridPos.X
+	})
+	return sorted
+}
+
+// convertRows puts panels that start on the same grid line into one row.
+func (c *grafanaConverter) convertRows(sectionPath string, panels []grafanaPanel) []dashboardservice.Row {
+	rows := []dashboardservice.Row{}
+	sorted := sortGrafanaPanels(panels)
+	for start := 0; start < len(sorted); {
+		end := start
+		height := 0
+		for end < len(sorted) && sorted[end].GridPos.Y == sorted[start].GridPos.Y {
+			height = max(height, sorted[end].GridPos.H)
+			end++
+		}
+
+		path := fmt.Sprintf("%s/row/%d", sectionPath, len(rows))
+		widgets := []dashboardservice.Widget{}
+		for i, panel := range sorted[start:end] {
+			if widget := c.convertPanel(fmt.Sprintf("%s/widget/%d", path, i), panel); widget != nil {
+				widgets = append(widgets, *widget)
+			}
+		}
+		start = end
+		if len(widgets) == 0 {
+			continue
+		}
+
+		rowHeight := int32(math.Round(float64(height) * dashboardRowHeight / grafanaPanelHeight))
+		rows = append(rows, dashboardservice.Row{
+			Id:         c.uuid(path),
+			Appearance: &dashboardservice.RowAppearance{Height: pointerTo(max(rowHeight, 1))},
+			Widgets:    widgets,
+		})
+	}
+	return rows
+}
+
+func (c *grafanaConverter) convertPanel(path string, panel grafanaPanel) *dashboardservice.Widget {
+	if panel.Repeat != "" {
+		c.warn("panel %q repeats for variable %q, it is converted once", panel.Title, panel.Repeat)
+	}
+
+	var definition *dashboardservice.WidgetDefinition
+	switch panel.Type {
+	case "timeseries", "graph":
+		definition = c.convertLineChart(path, panel)
+	case "stat", "gauge":
+		definition = c.convertGauge(panel)
+	case "piechart":
+		definition = c.convertPieChart(panel)
+	case "barchart":
+		definition = c.convertBarChart(panel)
+	case "table":
+		definition = c.convertDataTable(panel)
+	case "text":
+		definition = c.convertMarkdown(panel)
+	default:
+		c.warn("panel %q was left out, %s panels are not supported", panel.Title, panel.Type)
+	}
+	if definition == nil {
+		return nil
+	}
+
+	widget := &dashboardservice.Widget{
+		Id:         c.uuid(path),
+		Title:      pointerTo(panel.Title),
+		Appearance: &dashboardservice.WidgetAppearance{Width: pointerTo(int32(0))},
+		Definition: definition,
+	}
+	if panel.Description != "" {
+		widget.Description = pointerTo(panel.Description)
+	}
+	return widget
+}
+
+func (c *grafanaConverter) convertLineChart(path string, panel grafanaPanel) *dashboardservice.WidgetDefinition {
+	targets := c.promqlTargets(panel)
+	if len(targets) == 0 {
+		return nil
+	}
+	unit := c.convertUnit(panel)
+
+	queryDefinitions := make([]dashboardservice.LineChartQueryDefinition, 0, len(targets))
+	for _, target := range targets {
+		queryDefinition := dashboardservice.LineChartQueryDefinition{
+			Id: c.id(path + "/query/" + target.RefID),
+			Query: dashboardservice.LineChartQuery{
+				Metrics: &dashboardservice.LineChartMetricsQuery{PromqlQuery: c.convertPromql(panel, target)},
+			},
+			Unit:      dashboardwidgets.OptionalEnumPointer(unit, dashboardwidgets.DashboardSchemaToProtoUnit),
+			ScaleType: dashboardwidgets.OptionalEnumPointer(types.StringValue("linear"), dashboardwidgets.DashboardSchemaToProtoScaleType),
+			Name:      pointerTo(target.RefID),
+			IsVisible: pointerTo(true),
+		}
+		if target.LegendFormat != "" && target.LegendFormat != "__auto" {
+			queryDefinition.SeriesNameTemplate = pointerTo(target.LegendFormat)
+		}
+		queryDefinitions = append(queryDefinitions, queryDefinition)
+	}
+
+	return &dashboardservice.WidgetDefinition{
+		LineChart: &dashboardservice.LineChart{
+			Legend: &dashboardservice.Legend{
+				IsVisible:    pointerTo(true),
+				GroupByQuery: pointerTo(true),
+				Placement:    dashboardwidgets.OptionalEnumPointer(types.StringValue("auto"), dashboardwidgets.DashboardLegendPlacementSchemaToProto),
+			},
+			Tooltip: &dashboardservice.Tooltip{
+				ShowLabels: pointerTo(false),
+				Type:       dashboardwidgets.OptionalEnumPointer(types.StringValue("all"), dashboardwidgets.DashboardSchemaToProtoTooltipType),
+			},
+			QueryDefinitions: queryDefinitions,
+		},
+	}
+}
+
+func (c *grafanaConverter) convertGauge(panel grafanaPanel) *dashboardservice.WidgetDefinition {
+	target := c.firstPromqlTarget(panel)
+	if target == nil {
+		return nil
+	}
+
+	minValue, maxValue := 0.0, 100.0
+	if defaults := panel.FieldConfig.Defaults; defaults.Min != nil {
+		minValue = *defaults.Min
+	}
+	if defaults := panel.FieldConfig.Defaults; defaults.Max != nil {
+		maxValue = *defaults.Max
+	}
+
+	return &dashboardservice.WidgetDefinition{
+		Gauge: &dashboardservice.WidgetsGauge{
+			Query: &dashboardservice.GaugeQuery{
+				Metrics: &dashboardservice.GaugeMetricsQuery{
+					PromqlQuery: c.convertPromql(panel, *target),
+					Aggregation: dashboardwidgets.OptionalEnumPointer(types.StringValue("last"), dashboardwidgets.DashboardSchemaToProtoGaugeAggregation),
+				},
+			},
+			Min:          pointerTo(minValue),
+			Max:          pointerTo(maxValue),
+			ShowInnerArc: pointerTo(false),
+			ShowOuterArc: pointerTo(true),
+			Unit:         dashboardwidgets.OptionalEnumPointer(c.convertUnit(panel), dashboardwidgets.DashboardSchemaToProtoGaugeUnit),
+		},
+	}
+}
+
+func (c *grafanaConverter) convertPieChart(panel grafanaPanel) *dashboardservice.WidgetDefinition {
+	target := c.firstPromqlTarget(panel)
+	if target == nil {
+		return nil
+	}
+
+	return &dashboardservice.WidgetDefinition{
+		PieChart: &dashboardservice.WidgetsPieChart{
+			Query: &dashboardservice.PieChartQuery{
+				Metrics: &dashboardservice.PieChartMetricsQuery{
+					PromqlQuery: c.convertPromql(panel, *target),
+					GroupNames:  c.groupNames(panel, *target),
+				},
+			},
+			ShowLegend: pointerTo(true),
+			Unit:       dashboardwidgets.OptionalEnumPointer(c.convertUnit(panel), dashboardwidgets.DashboardSchemaToProtoUnit),
+		},
+	}
+}
+
+func (c *grafanaConverter) convertBarChart(panel grafanaPanel) *dashboardservice.WidgetDefinition {
+	target := c.firstPromqlTarget(panel)
+	if target == nil {
+		return nil
+	}
+
+	return &dashboardservice.WidgetDefinition{
+		BarChart: &dashboardservice.BarChart{
+			Query: &dashboardservice.BarChartQuery{
+				Metrics: &dashboardservice.BarChartMetricsQuery{
+					PromqlQuery: c.convertPromql(panel, *target),
+					GroupNames:  c.groupNames(panel, *target),
+				},
+			},
+			ScaleType: dashboardwidgets.OptionalEnumPointer(types.StringValue("linear"), dashboardwidgets.DashboardSchemaToProtoScaleType),
+			Unit:      dashboardwidgets.OptionalEnumPointer(c.convertUnit(panel), dashboardwidgets.DashboardSchemaToProtoUnit),
+		},
+	}
+}
+
+func (c *grafanaConverter) convertDataTable(panel grafanaPanel) *dashboardservice.WidgetDefinition {
+	target := c.firstPromqlTarget(panel)
+	if target == nil {
+		return nil
+	}
+
+	queryType := "instant"
+	if target.Range && !target.Instant {
+		queryType = "range"
+	}
+	return &dashboardservice.WidgetDefinition{
+		DataTable: &dashboardservice.DataTable{
+			Query: &dashboardservice.DataTableQuery{
+				Metrics: &dashboardservice.DataTableMetricsQuery{
+					PromqlQuery:     c.convertPromql(panel, *target),
+					PromqlQueryType: dashboardwidgets.OptionalEnumPointer(types.StringValue(queryType), dashboardwidgets.DashboardSchemaToProtoPromQLQueryType),
+				},
+			},
+			ResultsPerPage: pointerTo(int32(10)),
+			RowStyle:       dashboardwidgets.OptionalEnumPointer(types.StringValue("one_line"), dashboardwidgets.DashboardRowStyleSchemaToProto),
+		},
+	}
+}
+
+func (c *grafanaConverter) convertMarkdown(panel grafanaPanel) *dashboardservice.WidgetDefinition {
+	var options struct {
+		Mode    string `json:"mode"`
+		Content string `json:"content"`
+	}
+	if len(panel.Options) > 0 {
+		_ = json.Unmarshal(panel.Options, &options)
+	}
+	content, mode := options.Content, options.Mode
+	if content == "" {
+		content, mode = panel.Content, panel.Mode
+	}
+	if mode == "html" {
+		c.warn("text panel %q is written in HTML, it is kept as markdown", panel.Title)
+	}
+
+	return &dashboardservice.WidgetDefinition{
+		Markdown: &dashboardservice.Markdown{
+			MarkdownText: pointerTo(content),
+		},
+	}
+}
+
+// promqlTargets returns the visible targets of a panel that query Prometheus.
+func (c *grafanaConverter) promqlTargets(panel grafanaPanel) []grafanaTarget {
+	var targets []grafanaTarget
+	for _, target := range panel.Targets {
+		if target.Hide {
+			continue
+		}
+		source := grafanaDatasourceType(target.Datasource)
+		if source == "" {
+			source = grafanaDatasourceType(panel.Datasource)
+		}
+		switch {
+		case source != "" && source != grafanaPrometheusSource:
+			c.warn("target %s of panel %q was left out, %s targets are not supported", target.RefID, panel.Title, source)
+		case strings.TrimSpace(target.Expr) == "":
+			c.warn("target %s of panel %q was left out, it has no PromQL expression", target.RefID, panel.Title)
+		default:
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) == 0 {
+		c.warn("panel %q was left out, it has no PromQL targets", panel.Title)
+	}
+	return targets
+}
+
+func (c *grafanaConverter) firstPromqlTarget(panel grafanaPanel) *grafanaTarget {
+	targets := c.promqlTargets(panel)
+	if len(targets) == 0 {
+		return nil
+	}
+	if len(targets) > 1 {
+		c.warn("%s panel %q has %d targets, only target %s is converted", panel.Type, panel.Title, len(targets), targets[0].RefID)
+	}
+	return &targets[0]
+}
+
+// grafanaDatasourceType returns the type of a data source reference, which is
+// empty for the data source names used before Grafana 8.
+func grafanaDatasourceType(datasource json.RawMessage) string {
+	var reference struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(datasource, &reference); err != nil {
+		return ""
+	}
+	return reference.Type
+}
+
+// convertPromql rewrites references to converted variables, in any of the
+// $name, ${name}, ${name:format} and [[name]] forms, to {{ name }}.
+func (c *grafanaConverter) convertPromql(panel grafanaPanel, target grafanaTarget) *dashboardservice.PromQlQuery {
+	query := grafanaVariableReference.ReplaceAllStringFunc(target.Expr, func(reference string) string {
+		match := grafanaVariableReference.FindStringSubmatch(reference)
+		name := match[1] + match[2] + match[3]
+		if c.variables[name] {
+			return "{{ " + name + " }}"
+		}
+		c.warn("panel %q references %s, which is not a dashboard variable and is kept as is", panel.Title, reference)
+		return reference
+	})
+	return &dashboardservice.PromQlQuery{Value: pointerTo(query)}
+}
+
+// groupNames returns the labels of the legend format, or those of the by
+// clause when the legend format has none.
+func (c *grafanaConverter) groupNames(panel grafanaPanel, target grafanaTarget) []string {
+	var names []string
+	for _, match := range grafanaLegendLabel.FindAllStringSubmatch(target.LegendFormat, -1) {
+		names = append(names, match[1])
+	}
+	if len(names) == 0 {
+		if match := grafanaByClause.FindStringSubmatch(target.Expr); match != nil {
+			for _, label := range strings.Split(match[1], ",") {
+				if label = strings.TrimSpace(label); label != "" {
+					names = append(names, label)
+				}
+			}
+		}
+	}
+	if len(names) == 0 {
+		c.warn("%s panel %q has no legend labels or by clause to group by", panel.Type, panel.Title)
+	}
+	return names
+}
+
+func (c *grafanaConverter) convertUnit(panel grafanaPanel) types.String {
+	unit := panel.FieldConfig.Defaults.Unit
+	switch unit {
+	case "", "none", "short":
+		return types.StringNull()
+	}
+	converted, ok := grafanaUnits[unit]
+	if !ok {
+		c.warn("unit %q of panel %q is not supported and was left out", unit, panel.Title)
+		return types.StringNull()
+	}
+	return types.StringValue(converted)
+}
+
+func pointerTo[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	dashboardservice "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/dashboard_service"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func convertGrafanaFixture(t *testing.T) (*dashboardservice.Dashboard, []string) {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("..", "testdata", "dashboards", "grafana_dashboard.json"))
+	if err != nil {
+		t.Fatalf("read Grafana dashboard fixture: %s", err)
+	}
+	dashboard, warnings, err := grafanaToDashboard(content)
+	if err != nil {
+		t.Fatalf("grafanaToDashboard returned an error: %s", err)
+	}
+	return dashboard, warnings
+}
+
+func TestGrafanaToDashboardLayout(t *testing.T) {
+	dashboard, _ := convertGrafanaFixture(t)
+
+	if dashboard.Name != "Checkout overview" || dashboard.RelativeTimeFrame == nil || *dashboard.RelativeTimeFrame != "21600s" {
+		t.Errorf("name = %q, relative time frame = %v, want Checkout overview and 21600s", dashboard.Name, dashboard.RelativeTimeFrame)
+	}
+
+	sections := dashboard.Layout.Sections
+	if len(sections) != 3 {
+		t.Fatalf("got %d sections, want 3", len(sections))
+	}
+	if sections[0].Options != nil {
+		t.Error("panels above the first row should be in a section without options")
+	}
+	for i, want := range []struct {
+		name      string
+		collapsed bool
+	}{{"Breakdown", false}, {"Details", true}} {
+		custom := sections[i+1].Options.Custom
+		if *custom.Name != want.name || *custom.Collapsed != want.collapsed {
+			t.Errorf("section %d = %q collapsed %v, want %q collapsed %v", i+1, *custom.Name, *custom.Collapsed, want.name, want.collapsed)
+		}
+	}
+
+	for i, want := range []struct {
+		height  int32
+		widgets []string
+	}{
+		{19, []string{"Requests", "Error rate"}},
+		{24, []string{"Requests by code", "Requests by pod"}},
+		{19, []string{"Pods", "Runbook"}},
+	} {
+		rows := sections[i].Rows
+		if len(rows) != 1 {
+			t.Fatalf("section %d has %d rows, want 1", i, len(rows))
+		}
+		if *rows[0].Appearance.Height != want.height {
+			t.Errorf("section %d row height = %d, want %d", i, *rows[0].Appearance.Height, want.height)
+		}
+		var titles []string
+		for _, widget := range rows[0].Widgets {
+			titles = append(titles, *widget.Title)
+		}
+		if !reflect.DeepEqual(titles, want.widgets) {
+			t.Errorf("section %d widgets = %q, want %q", i, titles, want.widgets)
+		}
+	}
+}
+
+func TestGrafanaToDashboardWidgets(t *testing.T) {
+	dashboard, _ := convertGrafanaFixture(t)
+	sections := dashboard.Layout.Sections
+
+	lineChart := sections[0].Rows[0].Widgets[0].Definition.LineChart
+	if len(lineChart.QueryDefinitions) != 2 {
+		t.Fatalf("line chart has %d queries, want 2", len(lineChart.QueryDefinitions))
+	}
+	requests, latency := lineChart.QueryDefinitions[0], lineChart.QueryDefinitions[1]
+	if want := `sum by (service) (rate(http_requests_total{namespace=~"{{ namespace }}"}[$__rate_interval]))`; *requests.Query.Metrics.PromqlQuery.Value != want {
+		t.Errorf("query A = %s, want %s", *requests.Query.Metrics.PromqlQuery.Value, want)
+	}
+	if want := `histogram_quantile({{ quantile }}, sum by (le) (rate(http_request_duration_seconds_bucket{namespace=~"{{ namespace }}"}[5m])))`; *latency.Query.Metrics.PromqlQuery.Value != want {
+		t.Errorf("query B = %s, want %s", *latency.Query.Metrics.PromqlQuery.Value, want)
+	}
+	if *requests.SeriesNameTemplate != "{{service}}" || latency.SeriesNameTemplate != nil || *requests.Name != "A" {
+		t.Errorf("unexpected series name templates %v and %v or name %v", requests.SeriesNameTemplate, latency.SeriesNameTemplate, requests.Name)
+	}
+
+	gauge := sections[0].Rows[0].Widgets[1].Definition.Gauge
+	if *gauge.Min != 0 || *gauge.Max != 1 || *gauge.Unit != dashboardservice.GAUGEUNIT_UNIT_PERCENT_ZERO_ONE {
+		t.Errorf("gauge min %v, max %v, unit %v, want 0, 1 and percent01", *gauge.Min, *gauge.Max, *gauge.Unit)
+	}
+
+	pieChart := sections[1].Rows[0].Widgets[0].Definition.PieChart
+	if !reflect.DeepEqual(pieChart.Query.Metrics.GroupNames, []string{"code"}) {
+		t.Errorf("pie chart group names = %q, want the by clause labels", pieChart.Query.Metrics.GroupNames)
+	}
+	barChart := sections[1].Rows[0].Widgets[1].Definition.BarChart
+	if !reflect.DeepEqual(barChart.Query.Metrics.GroupNames, []string{"pod"}) {
+		t.Errorf("bar chart group names = %q, want the legend labels", barChart.Query.Metrics.GroupNames)
+	}
+
+	dataTable := sections[2].Rows[0].Widgets[0].Definition.DataTable
+	if *dataTable.Query.Metrics.PromqlQueryType != dashboardservice.PROMQLQUERYTYPE_PROM_QL_QUERY_TYPE_INSTANT {
+		t.Errorf("data table query type = %v, want instant", *dataTable.Query.Metrics.PromqlQueryType)
+	}
+	markdown := sections[2].Rows[0].Widgets[1].Definition.Markdown
+	if *markdown.MarkdownText != "See the [runbook](https://example.com/runbook)." {
+		t.Errorf("markdown text = %q", *markdown.MarkdownText)
+	}
+}
+
+func TestGrafanaToDashboardVariables(t *testing.T) {
+	dashboard, _ := convertGrafanaFixture(t)
+
+	if len(dashboard.Variables) != 2 {
+		t.Fatalf("got %d variables, want 2", len(dashboard.Variables))
+	}
+	namespace := dashboard.Variables[0].Definition.MultiSelect
+	if *dashboard.Variables[0].DisplayName != "Namespace" || *namespace.Source.MetricLabel.MetricName != "kube_pod_info" || *namespace.Source.MetricLabel.Label != "namespace" {
+		t.Errorf("unexpected namespace variable %+v", dashboard.Variables[0])
+	}
+	if namespace.Selection.All == nil || *namespace.SelectionOptions.SelectionType != dashboardservice.SELECTIONTYPE_SELECTION_TYPE_MULTI {
+		t.Error("namespace variable should select all values of a multi selection")
+	}
+
+	quantile := dashboard.Variables[1].Definition.MultiSelect
+	if !reflect.DeepEqual(quantile.Source.ConstantList.Values, []string{"0.5", "0.9", "0.99"}) {
+		t.Errorf("quantile values = %q", quantile.Source.ConstantList.Values)
+	}
+	if !reflect.DeepEqual(quantile.Selection.List.Values, []string{"0.9"}) || *quantile.SelectionOptions.SelectionType != dashboardservice.SELECTIONTYPE_SELECTION_TYPE_SINGLE {
+		t.Error("quantile variable should be a single selection of 0.9")
+	}
+}
+
+func TestGrafanaToDashboardWarnings(t *testing.T) {
+	_, warnings := convertGrafanaFixture(t)
+
+	want := []string{
+		`variable "resolution" was left out, interval variables are not supported`,
+		`unit "reqps" of panel "Requests" is not supported and was left out`,
+		`panel "Requests" references $__rate_interval, which is not a dashboard variable and is kept as is`,
+		`panel "Latency heatmap" was left out, heatmap panels are not supported`,
+		`target B of panel "Pods" was left out, loki targets are not supported`,
+	}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("warnings = %q, want %q", warnings, want)
+	}
+}
+
+func TestGrafanaToDashboardIsAcceptedAsContentJSON(t *testing.T) {
+	first, _ := convertGrafanaFixture(t)
+	second, _ := convertGrafanaFixture(t)
+	content, err := json.Marshal(first)
+	if err != nil {
+		t.Fatalf("marshal converted dashboard: %s", err)
+	}
+	if again, _ := json.Marshal(second); string(again) != string(content) {
+		t.Error("converting the same Grafana dashboard twice should produce the same content_json")
+	}
+
+	dashboard, diags := extractDashboard(context.Background(), DashboardResourceModel{
+		ContentJson: types.StringValue(string(content)),
+		Folder:      types.ObjectNull(dashboardFolderModelAttr()),
+	})
+	if diags.HasError() {
+		t.Fatalf("extract content_json dashboard: %v", diags)
+	}
+	if len(dashboard.Layout.Sections) != 3 || dashboard.Layout.Sections[0].Rows[0].Widgets[0].Definition.LineChart == nil {
+		t.Error("expected the converted layout to survive a content_json round trip")
+	}
+}
+
+func TestGrafanaToDashboardErrors(t *testing.T) {
+	for _, content := range []string{`not json`, `{"panels": []}`, `{"dashboard": {"title": ""}}`} {
+		if _, _, err := grafanaToDashboard([]byte(content)); err == nil {
+			t.Errorf("expected an error for %s", content)
+		}
+	}
+
+	dashboard, warnings, err := grafanaToDashboard([]byte(`{"dashboard": {"title": "Empty", "time": {"from": "2025-01-01T00:00:00Z", "to": "now"}}, "meta": {}}`))
+	if err != nil {
+		t.Fatalf("grafanaToDashboard returned an error for an API envelope: %s", err)
+	}
+	if dashboard.Name != "Empty" || dashboard.RelativeTimeFrame != nil || len(dashboard.Layout.Sections) != 1 || len(warnings) != 1 {
+		t.Errorf("unexpected conversion of an empty dashboard: %+v, warnings %q", dashboard, warnings)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &RenderDashboardWidgetFunction{}
+
+func NewRenderDashboardWidgetFunction() function.Function {
+	return &RenderDashboardWidgetFunction{}
+}
+
+type RenderDashboardWidgetFunction struct{}
+
+func (f *RenderDashboardWidgetFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "render_dashboard_widget"
+}
+
+func (f *RenderDashboardWidgetFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Renders a coralogix_dashboard_widget_template into a dashboard widget.",
+		MarkdownDescription: "Renders a `coralogix_dashboard_widget_template` into an object with the `title`, `description` and `definition` of a `coralogix_dashboard` widget, " +
+			"replacing every `{{ .name }}` placeholder with the value of the parameter. " +
+			"Pass the whole resource so that dashboards are updated whenever the template changes.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "template",
+				MarkdownDescription: "A `coralogix_dashboard_widget_template` resource.",
+			},
+			function.MapParameter{
+				Name:                "parameters",
+				ElementType:         types.StringType,
+				AllowNullValue:      true,
+				MarkdownDescription: "Parameter values keyed by name. Parameters with a default can be left out.",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *RenderDashboardWidgetFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		template   types.Dynamic
+		parameters types.Map
+	)
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &template, &parameters))
+	if resp.Error != nil {
+		return
+	}
+
+	arguments := map[string]types.String{}
+	if diags := parameters.ElementsAs(ctx, &arguments, true); diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	for _, argument := range arguments {
+		if argument.IsUnknown() {
+			resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, types.DynamicUnknown()))
+			return
+		}
+	}
+
+	widget, funcErr := renderDashboardWidget(ctx, template, arguments)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, widget))
+}
+
+func renderDashboardWidget(ctx context.Context, template types.Dynamic, arguments map[string]types.String) (types.Dynamic, *function.FuncError) {
+	object, ok := template.UnderlyingValue().(basetypes.ObjectValue)
+	if !ok || object.IsNull() {
+		return types.DynamicNull(), function.NewArgumentFuncError(0, "template must be a coralogix_dashboard_widget_template resource")
+	}
+	attributes := object.Attributes()
+	for _, name := range []string{"name", "title", "description", "definition", "parameters"} {
+		if _, ok := attributes[name]; !ok {
+			return types.DynamicNull(), function.NewArgumentFuncError(0, fmt.Sprintf("template must be a coralogix_dashboard_widget_template resource, it has no %s attribute", name))
+		}
+	}
+	templateName := "template"
+	if name, ok := attributes["name"].(basetypes.StringValue); ok {
+		templateName = name.ValueString()
+	}
+
+	values := map[string]string{}
+	var declared, required []string
+	if declaredParameters, ok := attributes["parameters"].(basetypes.MapValue); ok {
+		for name, parameter := range declaredParameters.Elements() {
+			declared = append(declared, name)
+			var model DashboardWidgetTemplateParameterModel
+			if object, ok := parameter.(basetypes.ObjectValue); ok {
+				if diags := object.As(ctx, &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+					return types.DynamicNull(), function.FuncErrorFromDiags(ctx, diags)
+				}
+			}
+			if model.Default.IsNull() || model.Default.IsUnknown() {
+				required = append(required, name)
+				continue
+			}
+			values[name] = model.Default.ValueString()
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(arguments)) {
+		if !slices.Contains(declared, name) {
+			return types.DynamicNull(), function.NewArgumentFuncError(1, fmt.Sprintf("%q does not declare the parameter %q", templateName, name))
+		}
+		values[name] = arguments[name].ValueString()
+	}
+	slices.Sort(required)
+	for _, name := range required {
+		if _, ok := values[name]; !ok {
+			return types.DynamicNull(), function.NewArgumentFuncError(1, fmt.Sprintf("parameter %q of %q has no default and must be passed", name, templateName))
+		}
+	}
+
+	render := func(s string) (string, error) {
+		var err error
+		rendered := widgetTemplateParameterFormat.ReplaceAllStringFunc(s, func(placeholder string) string {
+			name := widgetTemplateParameterFormat.FindStringSubmatch(placeholder)[1]
+			value, ok := values[name]
+			if !ok {
+				err = fmt.Errorf("%q references the undeclared parameter %q", templateName, name)
+				return placeholder
+			}
+			return value
+		})
+		return rendered, err
+	}
+
+	widgetAttributes := map[string]attr.Value{}
+	widgetTypes := map[string]attr.Type{}
+	for _, name := range []string{"title", "description", "definition"} {
+		value := attributes[name]
+		if dynamic, ok := value.(basetypes.DynamicValue); ok {
+			if dynamic.IsUnknown() || dynamic.IsUnderlyingValueUnknown() {
+				return types.DynamicUnknown(), nil
+			}
+			value = dynamic.UnderlyingValue()
+		}
+		if value == nil {
+			return types.DynamicNull(), function.NewArgumentFuncError(0, fmt.Sprintf("template has no %s", name))
+		}
+		rendered, err := renderWidgetTemplateValue(ctx, value, render)
+		if err != nil {
+			return types.DynamicNull(), function.NewArgumentFuncError(0, err.Error())
+		}
+		widgetAttributes[name] = rendered
+		widgetTypes[name] = rendered.Type(ctx)
+	}
+	widget, diags := types.ObjectValue(widgetTypes, widgetAttributes)
+	if diags.HasError() {
+		return types.DynamicNull(), function.FuncErrorFromDiags(ctx, diags)
+	}
+	return types.DynamicValue(widget), nil
+}
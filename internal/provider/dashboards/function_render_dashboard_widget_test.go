@@ -0,0 +1,134 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func testWidgetTemplate(t *testing.T) types.Dynamic {
+	t.Helper()
+	str := types.StringValue
+
+	queryDefinition := types.ObjectValueMust(
+		map[string]attr.Type{"query": types.ObjectType{AttrTypes: map[string]attr.Type{"metrics": types.ObjectType{AttrTypes: map[string]attr.Type{"promql_query": types.StringType}}}}, "series_name_template": types.StringType},
+		map[string]attr.Value{
+			"query": types.ObjectValueMust(
+				map[string]attr.Type{"metrics": types.ObjectType{AttrTypes: map[string]attr.Type{"promql_query": types.StringType}}},
+				map[string]attr.Value{"metrics": types.ObjectValueMust(
+					map[string]attr.Type{"promql_query": types.StringType},
+					map[string]attr.Value{"promql_query": str(`histogram_quantile({{ .quantile }}, sum by (le) (rate(http_request_duration_seconds_bucket{service="{{ .service }}", namespace=~"{{ namespace }}"}[5m])))`)},
+				)},
+			),
+			"series_name_template": str("{{ pod }}"),
+		},
+	)
+	definition := types.ObjectValueMust(
+		map[string]attr.Type{"line_chart": types.ObjectType{AttrTypes: map[string]attr.Type{"query_definitions": types.TupleType{ElemTypes: []attr.Type{queryDefinition.Type(context.Background())}}}}},
+		map[string]attr.Value{"line_chart": types.ObjectValueMust(
+			map[string]attr.Type{"query_definitions": types.TupleType{ElemTypes: []attr.Type{queryDefinition.Type(context.Background())}}},
+			map[string]attr.Value{"query_definitions": types.TupleValueMust([]attr.Type{queryDefinition.Type(context.Background())}, []attr.Value{queryDefinition})},
+		)},
+	)
+
+	parameterType := types.ObjectType{AttrTypes: map[string]attr.Type{"description": types.StringType, "default": types.StringType}}
+	parameters := types.MapValueMust(parameterType, map[string]attr.Value{
+		"service":  types.ObjectValueMust(parameterType.AttrTypes, map[string]attr.Value{"description": str("Service label"), "default": types.StringNull()}),
+		"quantile": types.ObjectValueMust(parameterType.AttrTypes, map[string]attr.Value{"description": types.StringNull(), "default": str("0.99")}),
+	})
+
+	template := types.ObjectValueMust(
+		map[string]attr.Type{
+			"id":          types.StringType,
+			"name":        types.StringType,
+			"title":       types.StringType,
+			"description": types.StringType,
+			"definition":  types.DynamicType,
+			"parameters":  parameters.Type(context.Background()),
+		},
+		map[string]attr.Value{
+			"id":          types.StringUnknown(),
+			"name":        str("latency"),
+			"title":       str("{{ .service }} p{{ .quantile }} latency"),
+			"description": types.StringNull(),
+			"definition":  types.DynamicValue(definition),
+			"parameters":  parameters,
+		},
+	)
+	return types.DynamicValue(template)
+}
+
+func renderedPromql(t *testing.T, widget types.Dynamic) (string, string) {
+	t.Helper()
+	attributes := widget.UnderlyingValue().(basetypes.ObjectValue).Attributes()
+	lineChart := attributes["definition"].(basetypes.ObjectValue).Attributes()["line_chart"].(basetypes.ObjectValue)
+	queryDefinition := lineChart.Attributes()["query_definitions"].(basetypes.TupleValue).Elements()[0].(basetypes.ObjectValue)
+	metrics := queryDefinition.Attributes()["query"].(basetypes.ObjectValue).Attributes()["metrics"].(basetypes.ObjectValue)
+	return attributes["title"].(basetypes.StringValue).ValueString(), metrics.Attributes()["promql_query"].(basetypes.StringValue).ValueString()
+}
+
+func TestRenderDashboardWidget(t *testing.T) {
+	ctx := context.Background()
+
+	widget, err := renderDashboardWidget(ctx, testWidgetTemplate(t), map[string]types.String{"service": types.StringValue("checkout")})
+	if err != nil {
+		t.Fatalf("renderDashboardWidget returned an error: %s", err.Text)
+	}
+	title, promql := renderedPromql(t, widget)
+	if title != "checkout p0.99 latency" {
+		t.Errorf("title = %q", title)
+	}
+	if want := `histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket{service="checkout", namespace=~"{{ namespace }}"}[5m])))`; promql != want {
+		t.Errorf("promql = %s, want %s", promql, want)
+	}
+	if _, ok := widget.UnderlyingValue().(basetypes.ObjectValue).Attributes()["id"]; ok {
+		t.Error("the rendered widget should not carry the template id")
+	}
+
+	widget, err = renderDashboardWidget(ctx, testWidgetTemplate(t), map[string]types.String{"service": types.StringValue("billing"), "quantile": types.StringValue("0.5")})
+	if err != nil {
+		t.Fatalf("renderDashboardWidget returned an error: %s", err.Text)
+	}
+	if title, _ := renderedPromql(t, widget); title != "billing p0.5 latency" {
+		t.Errorf("title = %q, want the passed quantile to override the default", title)
+	}
+}
+
+func TestRenderDashboardWidgetErrors(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		arguments map[string]types.String
+		want      string
+	}{
+		{map[string]types.String{}, `parameter "service" of "latency" has no default and must be passed`},
+		{map[string]types.String{"service": types.StringValue("checkout"), "region": types.StringValue("eu")}, `"latency" does not declare the parameter "region"`},
+	}
+	for _, tc := range cases {
+		if _, err := renderDashboardWidget(ctx, testWidgetTemplate(t), tc.arguments); err == nil || !strings.Contains(err.Text, tc.want) {
+			t.Errorf("renderDashboardWidget(%v) error = %v, want %q", tc.arguments, err, tc.want)
+		}
+	}
+
+	if _, err := renderDashboardWidget(ctx, types.DynamicValue(types.StringValue("latency")), nil); err == nil {
+		t.Error("expected an error for a template that is not an object")
+	}
+}
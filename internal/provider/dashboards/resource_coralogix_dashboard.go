@@ -28,7 +28,9 @@ import (
 	dashboardwidgets "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_widgets"
 	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
 
+	cxsdkOpenapi "github.com/coralogix/coralogix-management-sdk/go/openapi/cxsdk"
 	"github.com/coralogix/coralogix-management-sdk/go/openapi/dashboardjson"
+	dbfs "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/dashboard_folders_service"
 	dashboardservice "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/dashboard_service"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -38,6 +40,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/nsf/jsondiff"
 )
 
 var (
@@ -63,8 +66,9 @@ type DashboardResourceModel struct {
 	ID           types.String                     `tfsdk:"id"`
 	Name         types.String                     `tfsdk:"name"`
 	Description  types.String                     `tfsdk:"description"`
-	Layout       types.Object                     `tfsdk:"layout"`    //DashboardLayoutModel
-	Variables    types.List                       `tfsdk:"variables"` //DashboardVariableModel
+	Layout       types.Object                     `tfsdk:"layout"`      //DashboardLayoutModel
+	AutoLayout   types.Object                     `tfsdk:"auto_layout"` //DashboardAutoLayoutModel
+	Variables    types.List                       `tfsdk:"variables"`   //DashboardVariableModel
 	VariablesV2  types.List                       `tfsdk:"variables_v2"`
 	Filters      types.List                       `tfsdk:"filters"` //DashboardFilterModel
 	TimeFrame    *dashboardwidgets.TimeFrameModel `tfsdk:"time_frame"`
@@ -538,7 +542,9 @@ func NewDashboardResource() resource.Resource {
 }
 
 type DashboardResource struct {
-	openAPIClient *dashboardOpenAPIClient
+	openAPIClient   *dashboardOpenAPIClient
+	foldersClient   *dbfs.DashboardFoldersServiceAPIService
+	queryValidation clientset.QueryValidation
 }
 
 func (r DashboardResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
@@ -617,6 +623,7 @@ func upgradeDashboardStateV3ToV4(ctx context.Context, req resource.UpgradeStateR
 		Name:         priorState.Name,
 		Description:  priorState.Description,
 		Layout:       priorState.Layout,
+		AutoLayout:   types.ObjectNull(autoLayoutModelAttr()),
 		Variables:    priorState.Variables,
 		VariablesV2:  types.ListNull(dashboardVariablesV2ElementType()),
 		Filters:      priorState.Filters,
@@ -735,6 +742,7 @@ func upgradeDashboardStateV2ToV3(ctx context.Context, req resource.UpgradeStateR
 		Name:        priorStateData.Name,
 		Description: priorStateData.Description,
 		Layout:      newLayout,
+		AutoLayout:  types.ObjectNull(autoLayoutModelAttr()),
 		Variables:   priorStateData.Variables,
 		VariablesV2: priorStateData.VariablesV2,
 		Filters:     priorStateData.Filters,
@@ -901,6 +909,7 @@ func upgradeDashboardStateV1ToV2(ctx context.Context, req resource.UpgradeStateR
 		Name:        priorStateData.Name,
 		Description: priorStateData.Description,
 		Layout:      priorStateData.Layout,
+		AutoLayout:  types.ObjectNull(autoLayoutModelAttr()),
 		Variables:   priorStateData.Variables,
 		Filters:     priorStateData.Filters,
 		TimeFrame:   timeframe,
@@ -1005,6 +1014,10 @@ func (r DashboardResource) Create(ctx context.Context, req resource.CreateReques
 		resp.Diagnostics.Append(diags...)
 		return
 	}
+	resp.Diagnostics.Append(r.resolveDashboardFolderPath(ctx, dashboard)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	accessPolicy := dashboardAccessPolicyForRequest(plan.AccessPolicy)
 	log.Printf("[INFO] Creating new Dashboard: %s", dashboardLogString(dashboard))
@@ -1088,6 +1101,30 @@ func (r DashboardResource) replaceDashboard(ctx context.Context, dashboard *dash
 	return r.openAPIClient.Replace(ctx, dashboard, accessPolicy)
 }
 
+// resolveDashboardFolderPath places the dashboard in the existing folder at
+// folder.path, such as one created by coralogix_dashboards_folder_tree. A path
+// that does not exist yet is left to the dashboards service to create.
+func (r DashboardResource) resolveDashboardFolderPath(ctx context.Context, dashboard *dashboardservice.Dashboard) diag.Diagnostics {
+	if dashboard.FolderPath == nil || r.foldersClient == nil {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	listResult, httpResponse, err := r.foldersClient.DashboardFoldersServiceListDashboardFolders(ctx).Execute()
+	if err != nil {
+		diags.AddError("Error listing dashboards folders", utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Read", nil))
+		return diags
+	}
+	folder, ok := dashboardsFoldersByPath(listResult.GetFolder())[strings.Join(dashboard.FolderPath.GetSegments(), "/")]
+	if !ok {
+		log.Printf("[INFO] Dashboards folder %q does not exist, it is created with the dashboard", strings.Join(dashboard.FolderPath.GetSegments(), "/"))
+		return diags
+	}
+	dashboard.FolderId = &dashboardservice.UUID{Value: folder.Id}
+	dashboard.FolderPath = nil
+	return diags
+}
+
 func dashboardLogString(dashboard any) string {
 	content, err := json.Marshal(dashboard)
 	if err != nil {
@@ -1109,6 +1146,9 @@ func extractDashboard(ctx context.Context, plan DashboardResourceModel) (*dashbo
 		return dashboard, nil
 	}
 	layout, diags := expandDashboardLayout(ctx, plan.Layout)
+	if !utils.ObjIsNullOrUnknown(plan.AutoLayout) {
+		layout, diags = expandDashboardAutoLayout(ctx, plan.Name, plan.AutoLayout)
+	}
 	if diags.HasError() {
 		return nil, diags
 	}
@@ -3838,7 +3878,12 @@ func flattenDashboard(ctx context.Context, plan DashboardResourceModel, response
 		return flattenContentJSONDashboard(ctx, plan, response.Dashboard, flattenedAccessPolicy)
 	}
 
-	layout, diags := flattenDashboardLayout(ctx, &dashboard.Layout)
+	layout, autoLayout := types.ObjectNull(layoutModelAttr()), types.ObjectNull(autoLayoutModelAttr())
+	if utils.ObjIsNullOrUnknown(plan.AutoLayout) {
+		layout, diags = flattenDashboardLayout(ctx, &dashboard.Layout)
+	} else {
+		autoLayout, diags = flattenDashboardAutoLayout(ctx, plan.AutoLayout, &dashboard.Layout)
+	}
 	if diags.HasError() {
 		log.Printf("[ERROR] ERROR flattenDashboardLayout: %s", diags.Errors())
 		return nil, diags
@@ -3879,6 +3924,7 @@ func flattenDashboard(ctx context.Context, plan DashboardResourceModel, response
 		Name:         types.StringValue(dashboard.GetName()),
 		Description:  utils.StringPointerToTypeString(dashboard.Description),
 		Layout:       layout,
+		AutoLayout:   autoLayout,
 		Variables:    variables,
 		VariablesV2:  variablesV2,
 		Filters:      filters,
@@ -3920,6 +3966,7 @@ func flattenContentJSONDashboard(ctx context.Context, plan DashboardResourceMode
 		Name:         types.StringNull(),
 		Description:  types.StringNull(),
 		Layout:       types.ObjectNull(layoutModelAttr()),
+		AutoLayout:   types.ObjectNull(autoLayoutModelAttr()),
 		Variables:    types.ListNull(types.ObjectType{AttrTypes: dashboardsVariablesModelAttr()}),
 		VariablesV2:  types.ListNull(dashboardVariablesV2ElementType()),
 		Filters:      types.ListNull(types.ObjectType{AttrTypes: dashboardsFiltersModelAttr()}),
@@ -7409,12 +7456,49 @@ func (r *DashboardResource) Read(ctx context.Context, req resource.ReadRequest,
 		resp.Diagnostics.Append(diags...)
 		return
 	}
+	if !flattenedDashboard.ContentJson.IsNull() {
+		flattenedDashboard.ContentJson, diags = refreshDashboardContentJSON(flattenedDashboard.ContentJson, getDashboardResp.Dashboard)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
 	state = *flattenedDashboard
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
+// refreshDashboardContentJSON keeps the content_json of the state while the
+// dashboard in Coralogix is equivalent to it, and otherwise returns the
+// dashboard as the API returned it, so that the plan shows the difference and
+// the update restores the configured dashboard in place.
+// The folder is left out of the comparison, the API returns the folder ID
+// where content_json may use a path or the folder attribute.
+func refreshDashboardContentJSON(contentJSON types.String, dashboard *dashboardservice.Dashboard) (types.String, diag.Diagnostics) {
+	remoteDashboard := *dashboard
+	remoteDashboard.FolderId, remoteDashboard.FolderPath = nil, nil
+	remote, err := json.Marshal(&remoteDashboard)
+	if err != nil {
+		return contentJSON, diag.Diagnostics{diag.NewErrorDiagnostic("Error Flatten Dashboard", err.Error())}
+	}
+	configuredDashboard := new(dashboardservice.Dashboard)
+	if err := dashboardjson.Unmarshal([]byte(contentJSON.ValueString()), configuredDashboard); err != nil {
+		return contentJSON, diag.Diagnostics{diag.NewErrorDiagnostic("Error Unmarshal Dashboard", err.Error())}
+	}
+	configuredDashboard.FolderId, configuredDashboard.FolderPath = nil, nil
+	configured, err := json.Marshal(configuredDashboard)
+	if err != nil {
+		return contentJSON, diag.Diagnostics{diag.NewErrorDiagnostic("Error Flatten Dashboard", err.Error())}
+	}
+
+	difference, _, err := dashboardschema.CompareContentJSON(string(remote), string(configured))
+	if err != nil || difference == jsondiff.FullMatch {
+		return contentJSON, nil
+	}
+	return types.StringValue(string(remote)), nil
+}
+
 func (r *DashboardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Retrieve values from plan
 	var plan DashboardResourceModel
@@ -7435,6 +7519,10 @@ func (r *DashboardResource) Update(ctx context.Context, req resource.UpdateReque
 		resp.Diagnostics.Append(diags...)
 		return
 	}
+	resp.Diagnostics.Append(r.resolveDashboardFolderPath(ctx, dashboard)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if plan.ID.IsNull() || plan.ID.IsUnknown() || plan.ID.ValueString() == "" {
 		resp.Diagnostics.AddError("Error updating Dashboard", "Dashboard ID is unavailable in the Terraform plan")
 		return
@@ -7510,4 +7598,6 @@ func (r *DashboardResource) Configure(_ context.Context, req resource.ConfigureR
 	}
 
 	r.openAPIClient = newDashboardOpenAPIClient(clientSet.Dashboards())
+	r.foldersClient = clientSet.DashboardsFolders()
+	r.queryValidation = clientSet.QueryValidation()
 }
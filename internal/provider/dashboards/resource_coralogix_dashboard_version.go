@@ -0,0 +1,565 @@
+// Copyright 2024 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	dashboardschema "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_schema"
+	dashboardwidgets "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_widgets"
+
+	"github.com/coralogix/coralogix-management-sdk/go/openapi/dashboardjson"
+	dashboardservice "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/dashboard_service"
+	"github.com/google/uuid"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsf/jsondiff"
+)
+
+var (
+	_ resource.ResourceWithConfigure  = &DashboardVersionResource{}
+	_ resource.ResourceWithModifyPlan = &DashboardVersionResource{}
+)
+
+func NewDashboardVersionResource() resource.Resource {
+	return &DashboardVersionResource{}
+}
+
+type DashboardVersionResource struct {
+	openAPIClient *dashboardOpenAPIClient
+}
+
+type DashboardVersionResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	DashboardID  types.String `tfsdk:"dashboard_id"`
+	Triggers     types.Map    `tfsdk:"triggers"`
+	KeepVersions types.Int64  `tfsdk:"keep_versions"`
+	FolderID     types.String `tfsdk:"folder_id"`
+	RollbackTo   types.Int64  `tfsdk:"rollback_to"`
+	Versions     types.List   `tfsdk:"versions"` //DashboardVersionModel
+}
+
+type DashboardVersionModel struct {
+	Version     types.Int64  `tfsdk:"version"`
+	SnapshotID  types.String `tfsdk:"snapshot_id"`
+	ContentJson types.String `tfsdk:"content_json"`
+	RecordedAt  types.String `tfsdk:"recorded_at"`
+}
+
+func dashboardVersionModelAttr() map[string]attr.Type {
+	return map[string]attr.Type{
+		"version":      types.Int64Type,
+		"snapshot_id":  types.StringType,
+		"content_json": types.StringType,
+		"recorded_at":  types.StringType,
+	}
+}
+
+func (r *DashboardVersionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_version"
+}
+
+func (r *DashboardVersionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.openAPIClient = newDashboardOpenAPIClient(clientSet.Dashboards())
+}
+
+func (r *DashboardVersionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of the dashboard.",
+			},
+			"dashboard_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "ID of the dashboard to record.",
+			},
+			"triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that record a new version of the dashboard whenever they change, for example the `content_json` of the `coralogix_dashboard` or a release number.",
+			},
+			"keep_versions": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(10),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				MarkdownDescription: "Number of versions to keep. The oldest versions are dropped first, together with their snapshot dashboards. Defaults to 10.",
+			},
+			"folder_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the dashboards folder the snapshot dashboards are stored in. Defaults to the folder of the dashboard. Changing it only affects the versions recorded afterwards.",
+			},
+			"rollback_to": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Version the dashboard is kept at. While set, the dashboard is replaced with this version whenever it differs from it, for example after it was edited in the Coralogix UI. " +
+					"IDs and default values are ignored when comparing.",
+			},
+			"versions": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of the version, starting at 1.",
+						},
+						"snapshot_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the snapshot dashboard holding the version.",
+						},
+						"content_json": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The dashboard as JSON, in the format of `coralogix_dashboard.content_json`.",
+						},
+						"recorded_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Time the version was recorded, in RFC 3339 format.",
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Recorded versions of the dashboard, oldest first.",
+			},
+		},
+		MarkdownDescription: "Records versions of a Coralogix dashboard and rolls the dashboard back to one of them. " +
+			"A version is recorded when the resource is created and whenever `triggers` change. Each version is stored as a snapshot dashboard named `<dashboard name> (version <n>)` in `folder_id`, " +
+			"and snapshot dashboards deleted in Coralogix drop their version. Deleting the resource deletes the snapshot dashboards; " +
+			"the dashboard itself is only changed while `rollback_to` is set.",
+	}
+}
+
+func (r *DashboardVersionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state DashboardVersionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Triggers.Equal(state.Triggers) || !plan.KeepVersions.Equal(state.KeepVersions) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("versions"), types.ListUnknown(types.ObjectType{AttrTypes: dashboardVersionModelAttr()}))...)
+	}
+}
+
+func (r *DashboardVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DashboardVersionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	live, err := r.openAPIClient.Get(ctx, plan.DashboardID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting Dashboard", err.Error())
+		return
+	}
+
+	versions, content, diags := r.recordVersion(ctx, plan, nil, live.Dashboard)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.rollback(ctx, plan, versions, content)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.DashboardID
+	plan.Versions, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: dashboardVersionModelAttr()}, versions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DashboardVersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DashboardVersionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.DashboardID.ValueString()
+	live, err := r.getDashboardContent(ctx, id)
+	if err != nil {
+		if errors.Is(err, errDashboardOpenAPINotFound) {
+			resp.Diagnostics.AddWarning(
+				fmt.Sprintf("Dashboard %q is in state, but no longer exists in Coralogix backend", id),
+				fmt.Sprintf("The versions of %s will be recorded again when you apply", id),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading Dashboard", err.Error())
+		return
+	}
+
+	var versions []DashboardVersionModel
+	resp.Diagnostics.Append(state.Versions.ElementsAs(ctx, &versions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	versions, diags := r.existingVersions(ctx, versions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Versions, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: dashboardVersionModelAttr()}, versions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The state holds the version the dashboard is at, so that a dashboard
+	// edited since the rollback shows up as a change to rollback_to.
+	if !state.RollbackTo.IsNull() && !dashboardContentEqual(live, findDashboardVersion(versions, state.RollbackTo.ValueInt64())) {
+		state.RollbackTo = matchingDashboardVersion(versions, live)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DashboardVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state DashboardVersionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var versions []DashboardVersionModel
+	resp.Diagnostics.Append(state.Versions.ElementsAs(ctx, &versions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	live, err := r.openAPIClient.Get(ctx, plan.DashboardID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting Dashboard", err.Error())
+		return
+	}
+	var content string
+	var diags diag.Diagnostics
+	if !plan.Triggers.Equal(state.Triggers) {
+		versions, content, diags = r.recordVersion(ctx, plan, versions, live.Dashboard)
+	} else {
+		versions, diags = r.keepVersions(ctx, versions, plan.KeepVersions.ValueInt64())
+		if !diags.HasError() {
+			content, err = dashboardContent(live.Dashboard)
+			if err != nil {
+				diags.AddError("Error getting Dashboard", err.Error())
+			}
+		}
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.rollback(ctx, plan, versions, content)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.DashboardID
+	plan.Versions, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: dashboardVersionModelAttr()}, versions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DashboardVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DashboardVersionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The dashboard itself is left as it is, only the snapshots are deleted.
+	var versions []DashboardVersionModel
+	resp.Diagnostics.Append(state.Versions.ElementsAs(ctx, &versions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	_, diags := r.keepVersions(ctx, versions, 0)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *DashboardVersionResource) getDashboardContent(ctx context.Context, id string) (string, error) {
+	response, err := r.openAPIClient.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return dashboardContent(response.Dashboard)
+}
+
+func dashboardContent(dashboard *dashboardservice.Dashboard) (string, error) {
+	content, err := json.Marshal(dashboard)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// recordVersion stores the live dashboard as the next version, in a snapshot
+// dashboard, and drops the oldest versions beyond keep_versions. It also
+// returns the content of the live dashboard.
+func (r *DashboardVersionResource) recordVersion(ctx context.Context, plan DashboardVersionResourceModel, versions []DashboardVersionModel, live *dashboardservice.Dashboard) ([]DashboardVersionModel, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	content, err := dashboardContent(live)
+	if err != nil {
+		diags.AddError("Error getting Dashboard", err.Error())
+		return versions, "", diags
+	}
+
+	folderID := plan.FolderID
+	if folderID.IsNull() && live.FolderId != nil {
+		folderID = types.StringPointerValue(live.FolderId.Value)
+	}
+	version := nextDashboardVersion(versions)
+	snapshot, err := dashboardVersionSnapshot(content, version, folderID)
+	if err != nil {
+		diags.AddError("Error creating dashboard snapshot", err.Error())
+		return versions, "", diags
+	}
+	log.Printf("[INFO] Recording version %d of Dashboard %s", version, plan.DashboardID.ValueString())
+	response, err := r.openAPIClient.Create(ctx, snapshot, nil)
+	if err != nil {
+		diags.AddError("Error creating dashboard snapshot", err.Error())
+		return versions, "", diags
+	}
+
+	versions = recordDashboardVersion(versions, content, response.GetDashboardId(), 0, time.Now())
+	versions, diags = r.keepVersions(ctx, versions, plan.KeepVersions.ValueInt64())
+	return versions, content, diags
+}
+
+// keepVersions drops the oldest versions beyond keep and deletes their
+// snapshot dashboards.
+func (r *DashboardVersionResource) keepVersions(ctx context.Context, versions []DashboardVersionModel, keep int64) ([]DashboardVersionModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	for len(versions) > 0 && int64(len(versions)) > keep {
+		if snapshotID := versions[0].SnapshotID.ValueString(); snapshotID != "" {
+			log.Printf("[INFO] Deleting snapshot %s of version %d", snapshotID, versions[0].Version.ValueInt64())
+			if err := r.openAPIClient.Delete(ctx, snapshotID); err != nil {
+				diags.AddError("Error deleting dashboard snapshot", err.Error())
+				return versions, diags
+			}
+		}
+		versions = versions[1:]
+	}
+	return versions, diags
+}
+
+// existingVersions drops the versions whose snapshot dashboard was deleted.
+func (r *DashboardVersionResource) existingVersions(ctx context.Context, versions []DashboardVersionModel) ([]DashboardVersionModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	existing := make([]DashboardVersionModel, 0, len(versions))
+	for _, version := range versions {
+		if snapshotID := version.SnapshotID.ValueString(); snapshotID != "" {
+			if _, err := r.openAPIClient.Get(ctx, snapshotID); errors.Is(err, errDashboardOpenAPINotFound) {
+				log.Printf("[INFO] Snapshot %s of version %d no longer exists", snapshotID, version.Version.ValueInt64())
+				continue
+			} else if err != nil {
+				diags.AddError("Error reading dashboard snapshot", err.Error())
+				return versions, diags
+			}
+		}
+		existing = append(existing, version)
+	}
+	return existing, diags
+}
+
+// dashboardVersionSnapshot is the dashboard in content as a new dashboard
+// named after the version, in the folder with folderID. The IDs of its
+// sections, rows and widgets are generated again, as they cannot be shared
+// with the recorded dashboard.
+func dashboardVersionSnapshot(content string, version int64, folderID types.String) (*dashboardservice.Dashboard, error) {
+	var value map[string]any
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		return nil, err
+	}
+	delete(value, "id")
+	snapshotContent, err := json.Marshal(withNewDashboardIDs(value))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := new(dashboardservice.Dashboard)
+	if err := dashboardjson.Unmarshal(snapshotContent, snapshot); err != nil {
+		return nil, err
+	}
+	snapshot.Name = fmt.Sprintf("%s (version %d)", snapshot.Name, version)
+	snapshot.FolderId, snapshot.FolderPath = nil, nil
+	if !folderID.IsNull() && !folderID.IsUnknown() {
+		snapshot.FolderId = dashboardwidgets.ExpandDashboardUUID(folderID)
+	}
+	return snapshot, nil
+}
+
+// withNewDashboardIDs replaces the value of every {"id": {"value": ...}}.
+func withNewDashboardIDs(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, property := range v {
+			if id, ok := property.(map[string]any); ok && key == "id" {
+				if _, ok := id["value"].(string); ok {
+					id["value"] = uuid.NewString()
+					continue
+				}
+			}
+			v[key] = withNewDashboardIDs(property)
+		}
+		return v
+	case []any:
+		for i, element := range v {
+			v[i] = withNewDashboardIDs(element)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// rollback replaces the dashboard with the version in rollback_to, unless it
+// already matches it.
+func (r *DashboardVersionResource) rollback(ctx context.Context, plan DashboardVersionResourceModel, versions []DashboardVersionModel, live string) diag.Diagnostics {
+	if plan.RollbackTo.IsNull() || plan.RollbackTo.IsUnknown() {
+		return nil
+	}
+
+	content := findDashboardVersion(versions, plan.RollbackTo.ValueInt64())
+	if content == "" {
+		var kept []int64
+		for _, version := range versions {
+			kept = append(kept, version.Version.ValueInt64())
+		}
+		return diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root("rollback_to"), "Unknown dashboard version",
+			fmt.Sprintf("version %d is not kept, the kept versions are %v", plan.RollbackTo.ValueInt64(), kept))}
+	}
+	if dashboardContentEqual(live, content) {
+		return nil
+	}
+
+	dashboard := new(dashboardservice.Dashboard)
+	if err := dashboardjson.Unmarshal([]byte(content), dashboard); err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Error unmarshalling dashboard version", err.Error())}
+	}
+	dashboard.SetId(plan.DashboardID.ValueString())
+	log.Printf("[INFO] Rolling back Dashboard %s to version %d", plan.DashboardID.ValueString(), plan.RollbackTo.ValueInt64())
+	if err := r.openAPIClient.Replace(ctx, dashboard, nil); err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Error rolling back Dashboard", err.Error())}
+	}
+	return nil
+}
+
+// recordDashboardVersion appends content, stored in the snapshot dashboard
+// with snapshotID, as the next version, unless it is empty, and drops the
+// oldest versions beyond keep.
+func recordDashboardVersion(versions []DashboardVersionModel, content, snapshotID string, keep int64, now time.Time) []DashboardVersionModel {
+	if content != "" {
+		versions = append(versions, DashboardVersionModel{
+			Version:     types.Int64Value(nextDashboardVersion(versions)),
+			SnapshotID:  types.StringValue(snapshotID),
+			ContentJson: types.StringValue(content),
+			RecordedAt:  types.StringValue(now.UTC().Format(time.RFC3339)),
+		})
+	}
+	if excess := int64(len(versions)) - keep; keep > 0 && excess > 0 {
+		versions = versions[excess:]
+	}
+	return versions
+}
+
+func nextDashboardVersion(versions []DashboardVersionModel) int64 {
+	if len(versions) == 0 {
+		return 1
+	}
+	return versions[len(versions)-1].Version.ValueInt64() + 1
+}
+
+func findDashboardVersion(versions []DashboardVersionModel, version int64) string {
+	for _, v := range versions {
+		if v.Version.ValueInt64() == version {
+			return v.ContentJson.ValueString()
+		}
+	}
+	return ""
+}
+
+// matchingDashboardVersion returns the newest version with the content of the
+// live dashboard, or null if it matches none.
+func matchingDashboardVersion(versions []DashboardVersionModel, live string) types.Int64 {
+	for i := len(versions) - 1; i >= 0; i-- {
+		if dashboardContentEqual(live, versions[i].ContentJson.ValueString()) {
+			return versions[i].Version
+		}
+	}
+	return types.Int64Null()
+}
+
+func dashboardContentEqual(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	difference, _, err := dashboardschema.CompareContentJSON(a, b)
+	return err == nil && difference == jsondiff.FullMatch
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRecordDashboardVersion(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	versions := recordDashboardVersion(nil, `{"name":"first"}`, "", 2, now)
+	versions = recordDashboardVersion(versions, `{"name":"second"}`, "", 2, now)
+	versions = recordDashboardVersion(versions, `{"name":"third"}`, "", 2, now)
+	if len(versions) != 2 || versions[0].Version.ValueInt64() != 2 || versions[1].Version.ValueInt64() != 3 {
+		t.Fatalf("versions = %v, want versions 2 and 3", versions)
+	}
+	if recordedAt := versions[1].RecordedAt.ValueString(); recordedAt != "2025-03-01T11:00:00Z" {
+		t.Errorf("recorded_at = %s, want the time in UTC", recordedAt)
+	}
+
+	versions = recordDashboardVersion(versions, "", "", 1, now)
+	if len(versions) != 1 || findDashboardVersion(versions, 3) != `{"name":"third"}` {
+		t.Errorf("lowering keep_versions should only keep the newest version, got %v", versions)
+	}
+}
+
+func TestMatchingDashboardVersion(t *testing.T) {
+	now := time.Now()
+	versions := recordDashboardVersion(nil, `{"name":"curated","layout":{"sections":[{"id":{"value":"2f0d1e36-1111-4b44-9a53-96c6d3d5b7c1"}}]}}`, "", 10, now)
+	versions = recordDashboardVersion(versions, `{"name":"edited"}`, "", 10, now)
+
+	if version := matchingDashboardVersion(versions, `{"layout":{"sections":[{"id":{"value":"8b0c0bd6-2222-4a57-8f3c-1f6ac1d02f9e"}}]},"name":"curated","description":""}`); version.ValueInt64() != 1 {
+		t.Errorf("matching version = %v, want 1 when only ids and defaults differ", version)
+	}
+	if version := matchingDashboardVersion(versions, `{"name":"edited again"}`); !version.IsNull() {
+		t.Errorf("matching version = %v, want null for a dashboard matching no version", version)
+	}
+}
+
+func TestWithNewDashboardIDs(t *testing.T) {
+	content := `{"id":"dashboard","folderId":{"value":"folder"},"layout":{"sections":[{"id":{"value":"section"},"rows":[{"id":{"value":"row"},"widgets":[{"id":{"value":"widget"}}]}]}]}}`
+	var value map[string]any
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		t.Fatal(err)
+	}
+
+	withNewDashboardIDs(value)
+	section := value["layout"].(map[string]any)["sections"].([]any)[0].(map[string]any)
+	row := section["rows"].([]any)[0].(map[string]any)
+	widget := row["widgets"].([]any)[0].(map[string]any)
+	for name, id := range map[string]any{"section": section["id"], "row": row["id"], "widget": widget["id"]} {
+		if got := id.(map[string]any)["value"]; got == name {
+			t.Errorf("%s id = %v, want a new id", name, got)
+		}
+	}
+	if value["id"] != "dashboard" || value["folderId"].(map[string]any)["value"] != "folder" {
+		t.Errorf("dashboard id and folder = %v and %v, want them unchanged", value["id"], value["folderId"])
+	}
+}
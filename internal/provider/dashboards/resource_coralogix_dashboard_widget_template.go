@@ -0,0 +1,277 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	dashboardwidgets "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_widgets"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.ResourceWithValidateConfig = &DashboardWidgetTemplateResource{}
+
+	widgetTemplateParameterName   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	widgetTemplateParameterFormat = regexp.MustCompile(`\{\{\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+)
+
+func NewDashboardWidgetTemplateResource() resource.Resource {
+	return &DashboardWidgetTemplateResource{}
+}
+
+// DashboardWidgetTemplateResource only lives in the Terraform state, the
+// Coralogix API has no widget library to store it in.
+type DashboardWidgetTemplateResource struct{}
+
+type DashboardWidgetTemplateResourceModel struct {
+	ID          types.String  `tfsdk:"id"`
+	Name        types.String  `tfsdk:"name"`
+	Title       types.String  `tfsdk:"title"`
+	Description types.String  `tfsdk:"description"`
+	Definition  types.Dynamic `tfsdk:"definition"`
+	Parameters  types.Map     `tfsdk:"parameters"` //DashboardWidgetTemplateParameterModel
+}
+
+type DashboardWidgetTemplateParameterModel struct {
+	Description types.String `tfsdk:"description"`
+	Default     types.String `tfsdk:"default"`
+}
+
+func (r *DashboardWidgetTemplateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_widget_template"
+}
+
+func (r *DashboardWidgetTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Unique identifier for the widget template.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the widget template.",
+			},
+			"title": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Title of the rendered widgets.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the rendered widgets.",
+			},
+			"definition": schema.DynamicAttribute{
+				Required: true,
+				MarkdownDescription: fmt.Sprintf("Widget definition, written like the `definition` of a `coralogix_dashboard` widget. Must contain one of %v.", dashboardwidgets.SupportedWidgetTypes) +
+					" Only the attributes set here are rendered, the dashboard fills in its defaults.",
+			},
+			"parameters": schema.MapNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"description": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Description of the parameter.",
+						},
+						"default": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Value used when the parameter is not passed to `render_dashboard_widget`. Parameters without a default must be passed.",
+						},
+					},
+				},
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(widgetTemplateParameterName, "must be a valid identifier")),
+				},
+				MarkdownDescription: "Parameters of the template, keyed by name. `title`, `description` and the strings of `definition` reference them as `{{ .name }}`.",
+			},
+		},
+		MarkdownDescription: "Reusable widget for `coralogix_dashboard`, parameterized by `{{ .name }}` placeholders. " +
+			"Render it into a dashboard widget with the `render_dashboard_widget` provider function; dashboards rendering a template are updated when the template changes. " +
+			"Dashboard variables keep their `{{ name }}` form and are resolved by the dashboard. The template is only stored in the Terraform state.",
+	}
+}
+
+func (r *DashboardWidgetTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DashboardWidgetTemplateResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if definition, ok := config.Definition.UnderlyingValue().(basetypes.ObjectValue); ok && !definition.IsNull() && !definition.IsUnknown() {
+		widgetTypes := slices.Sorted(maps.Keys(definition.Attributes()))
+		if len(widgetTypes) != 1 || !slices.Contains(dashboardwidgets.SupportedWidgetTypes, widgetTypes[0]) {
+			resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid widget template definition",
+				fmt.Sprintf("definition must contain exactly one of %v, got %v", dashboardwidgets.SupportedWidgetTypes, widgetTypes))
+		}
+	} else if !config.Definition.IsUnknown() && !config.Definition.IsUnderlyingValueUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid widget template definition", "definition must be an object")
+	}
+
+	if config.Parameters.IsUnknown() {
+		return
+	}
+	declared := map[string]bool{}
+	for name := range config.Parameters.Elements() {
+		declared[name] = true
+	}
+	for attribute, value := range map[string]attr.Value{"title": config.Title, "description": config.Description, "definition": config.Definition} {
+		_, err := renderWidgetTemplateValue(ctx, value, func(s string) (string, error) {
+			for _, match := range widgetTemplateParameterFormat.FindAllStringSubmatch(s, -1) {
+				if !declared[match[1]] {
+					return "", fmt.Errorf("%s references the undeclared parameter %q", attribute, match[1])
+				}
+			}
+			return s, nil
+		})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Undeclared widget template parameter", err.Error())
+		}
+	}
+}
+
+func (r *DashboardWidgetTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DashboardWidgetTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(uuid.NewString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DashboardWidgetTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DashboardWidgetTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DashboardWidgetTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DashboardWidgetTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DashboardWidgetTemplateResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// The template only exists in the Terraform state, which removes it.
+}
+
+// renderWidgetTemplateValue returns value with render applied to every known
+// string it contains, keeping the types of all collections and objects.
+func renderWidgetTemplateValue(ctx context.Context, value attr.Value, render func(string) (string, error)) (attr.Value, error) {
+	if value == nil || value.IsNull() || value.IsUnknown() {
+		return value, nil
+	}
+
+	var diags diag.Diagnostics
+	switch v := value.(type) {
+	case basetypes.StringValue:
+		rendered, err := render(v.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		return types.StringValue(rendered), nil
+	case basetypes.DynamicValue:
+		underlying, err := renderWidgetTemplateValue(ctx, v.UnderlyingValue(), render)
+		if err != nil {
+			return nil, err
+		}
+		return types.DynamicValue(underlying), nil
+	case basetypes.ObjectValue:
+		attributes := make(map[string]attr.Value, len(v.Attributes()))
+		for name, attribute := range v.Attributes() {
+			rendered, err := renderWidgetTemplateValue(ctx, attribute, render)
+			if err != nil {
+				return nil, err
+			}
+			attributes[name] = rendered
+		}
+		value, diags = types.ObjectValue(v.AttributeTypes(ctx), attributes)
+	case basetypes.MapValue:
+		elements := make(map[string]attr.Value, len(v.Elements()))
+		for key, element := range v.Elements() {
+			rendered, err := renderWidgetTemplateValue(ctx, element, render)
+			if err != nil {
+				return nil, err
+			}
+			elements[key] = rendered
+		}
+		value, diags = types.MapValue(v.ElementType(ctx), elements)
+	case basetypes.ListValue:
+		elements, err := renderWidgetTemplateElements(ctx, v.Elements(), render)
+		if err != nil {
+			return nil, err
+		}
+		value, diags = types.ListValue(v.ElementType(ctx), elements)
+	case basetypes.SetValue:
+		elements, err := renderWidgetTemplateElements(ctx, v.Elements(), render)
+		if err != nil {
+			return nil, err
+		}
+		value, diags = types.SetValue(v.ElementType(ctx), elements)
+	case basetypes.TupleValue:
+		elements, err := renderWidgetTemplateElements(ctx, v.Elements(), render)
+		if err != nil {
+			return nil, err
+		}
+		value, diags = types.TupleValue(v.ElementTypes(ctx), elements)
+	}
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags.Errors()[0].Detail())
+	}
+	return value, nil
+}
+
+func renderWidgetTemplateElements(ctx context.Context, elements []attr.Value, render func(string) (string, error)) ([]attr.Value, error) {
+	rendered := make([]attr.Value, 0, len(elements))
+	for _, element := range elements {
+		value, err := renderWidgetTemplateValue(ctx, element, render)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, value)
+	}
+	return rendered, nil
+}
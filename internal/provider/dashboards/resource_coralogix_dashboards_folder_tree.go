@@ -0,0 +1,472 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	cxsdkOpenapi "github.com/coralogix/coralogix-management-sdk/go/openapi/cxsdk"
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	dbfs "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/dashboard_folders_service"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.ResourceWithConfigure      = &DashboardsFolderTreeResource{}
+	_ resource.ResourceWithModifyPlan     = &DashboardsFolderTreeResource{}
+	_ resource.ResourceWithValidateConfig = &DashboardsFolderTreeResource{}
+
+	dashboardsFolderPathFormat = regexp.MustCompile(`^[^/]+(/[^/]+)*$`)
+)
+
+func NewDashboardsFolderTreeResource() resource.Resource {
+	return &DashboardsFolderTreeResource{}
+}
+
+type DashboardsFolderTreeResource struct {
+	client *dbfs.DashboardFoldersServiceAPIService
+}
+
+type DashboardsFolderTreeResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Paths   types.Set    `tfsdk:"paths"`
+	Folders types.Map    `tfsdk:"folders"` //DashboardsFolderTreeFolderModel
+}
+
+type DashboardsFolderTreeFolderModel struct {
+	ID       types.String `tfsdk:"id"`
+	ParentId types.String `tfsdk:"parent_id"`
+	Managed  types.Bool   `tfsdk:"managed"`
+}
+
+func dashboardsFolderTreeFolderModelAttr() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":        types.StringType,
+		"parent_id": types.StringType,
+		"managed":   types.BoolType,
+	}
+}
+
+func (r *DashboardsFolderTreeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientSet.DashboardsFolders()
+}
+
+func (r *DashboardsFolderTreeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboards_folder_tree"
+}
+
+func (r *DashboardsFolderTreeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Unique identifier for the folder tree.",
+			},
+			"paths": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(dashboardsFolderPathFormat, "must be a slash-separated folder path without empty segments, e.g. `platform/k8s/nodes`")),
+				},
+				MarkdownDescription: "Slash-separated folder paths, e.g. `platform/k8s/nodes`. Every folder on a path is created when it does not exist yet.",
+			},
+			"folders": schema.MapNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the folder.",
+						},
+						"parent_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the parent folder, null for top level folders.",
+						},
+						"managed": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the folder was created by this tree and is deleted with it. Folders that already existed are left alone.",
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Every folder of the tree keyed by its path, including the parents of the declared `paths`.",
+			},
+		},
+		MarkdownDescription: "Coralogix Custom Dashboards folder hierarchy, declared by folder paths. " +
+			"Missing folders are created top down and the folders created by the tree are deleted with it. " +
+			"Dashboards are placed in a folder of the tree with `folder.path`.",
+	}
+}
+
+func (r *DashboardsFolderTreeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var paths types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("paths"), &paths)...)
+	if resp.Diagnostics.HasError() || paths.IsNull() || paths.IsUnknown() {
+		return
+	}
+
+	for _, element := range paths.Elements() {
+		folderPath, ok := element.(types.String)
+		if !ok || folderPath.IsNull() || folderPath.IsUnknown() {
+			continue
+		}
+		for _, segment := range strings.Split(folderPath.ValueString(), "/") {
+			if strings.TrimSpace(segment) != segment {
+				resp.Diagnostics.AddAttributeError(path.Root("paths"), "Invalid dashboards folder path",
+					fmt.Sprintf("folder %q of %q has leading or trailing spaces", segment, folderPath.ValueString()))
+			}
+		}
+	}
+}
+
+func (r *DashboardsFolderTreeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state DashboardsFolderTreeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.Paths.IsUnknown() {
+		return
+	}
+
+	unknownFolders := types.MapUnknown(types.ObjectType{AttrTypes: dashboardsFolderTreeFolderModelAttr()})
+	for _, element := range plan.Paths.Elements() {
+		if element.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("folders"), unknownFolders)...)
+			return
+		}
+	}
+	paths, diags := dashboardsFolderTreePaths(ctx, plan.Paths)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	// Folders that were added to the paths or deleted outside of Terraform are
+	// only known once the tree is applied.
+	if !slices.Equal(slices.Sorted(maps.Keys(state.Folders.Elements())), slices.Sorted(slices.Values(paths))) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("folders"), unknownFolders)...)
+	}
+}
+
+func (r *DashboardsFolderTreeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DashboardsFolderTreeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	folders, diags := r.applyFolderTree(ctx, plan.Paths, nil)
+	resp.Diagnostics.Append(diags...)
+	if folders == nil {
+		return
+	}
+
+	plan.ID = types.StringValue(uuid.NewString())
+	plan.Folders, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: dashboardsFolderTreeFolderModelAttr()}, folders)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DashboardsFolderTreeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DashboardsFolderTreeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, diags := r.listFoldersByPath(ctx)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	stateFolders := map[string]DashboardsFolderTreeFolderModel{}
+	resp.Diagnostics.Append(state.Folders.ElementsAs(ctx, &stateFolders, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	folders := map[string]DashboardsFolderTreeFolderModel{}
+	for folderPath, folder := range stateFolders {
+		if current, ok := existing[folderPath]; ok && current.GetId() == folder.ID.ValueString() {
+			folders[folderPath] = folder
+		}
+	}
+	state.Folders, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: dashboardsFolderTreeFolderModelAttr()}, folders)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DashboardsFolderTreeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state DashboardsFolderTreeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous := map[string]DashboardsFolderTreeFolderModel{}
+	resp.Diagnostics.Append(state.Folders.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	folders, diags := r.applyFolderTree(ctx, plan.Paths, previous)
+	resp.Diagnostics.Append(diags...)
+	if folders == nil {
+		return
+	}
+
+	if diags.HasError() {
+		// The tree is only partly applied: keep the folders of the state
+		// that were not reached instead of deleting them.
+		for folderPath, folder := range previous {
+			if _, ok := folders[folderPath]; !ok {
+				folders[folderPath] = folder
+			}
+		}
+	} else {
+		removed := map[string]DashboardsFolderTreeFolderModel{}
+		for folderPath, folder := range previous {
+			if _, ok := folders[folderPath]; !ok {
+				removed[folderPath] = folder
+			}
+		}
+		resp.Diagnostics.Append(r.deleteManagedFolders(ctx, removed)...)
+	}
+
+	plan.Folders, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: dashboardsFolderTreeFolderModelAttr()}, folders)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DashboardsFolderTreeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DashboardsFolderTreeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	folders := map[string]DashboardsFolderTreeFolderModel{}
+	resp.Diagnostics.Append(state.Folders.ElementsAs(ctx, &folders, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.deleteManagedFolders(ctx, folders)...)
+}
+
+// applyFolderTree creates the folders of paths that don't exist yet, parents
+// first, and returns every folder of the tree keyed by path. Folders recorded
+// in previous keep their managed flag as long as they still exist. When a
+// folder cannot be created, the folders handled so far are returned with the
+// error, so that the folders already created are recorded in the state.
+func (r *DashboardsFolderTreeResource) applyFolderTree(ctx context.Context, paths types.Set, previous map[string]DashboardsFolderTreeFolderModel) (map[string]DashboardsFolderTreeFolderModel, diag.Diagnostics) {
+	treePaths, diags := dashboardsFolderTreePaths(ctx, paths)
+	if diags.HasError() {
+		return nil, diags
+	}
+	existing, diags := r.listFoldersByPath(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	folders := make(map[string]DashboardsFolderTreeFolderModel, len(treePaths))
+	for _, folderPath := range treePaths {
+		parentPath, name := dashboardsFolderParentPath(folderPath)
+		parentID := types.StringNull()
+		if parentPath != "" {
+			parentID = folders[parentPath].ID
+		}
+
+		if folder, ok := existing[folderPath]; ok {
+			managed := false
+			if prior, ok := previous[folderPath]; ok && prior.ID.ValueString() == folder.GetId() {
+				managed = prior.Managed.ValueBool()
+			}
+			folders[folderPath] = DashboardsFolderTreeFolderModel{
+				ID:       types.StringValue(folder.GetId()),
+				ParentId: parentID,
+				Managed:  types.BoolValue(managed),
+			}
+			continue
+		}
+
+		id := uuid.NewString()
+		rq := dbfs.CreateDashboardFolderRequestDataStructure{
+			Folder: &dbfs.DashboardFolder{
+				Id:       &id,
+				Name:     &name,
+				ParentId: utils.TypeStringToStringPointer(parentID),
+			},
+		}
+		createResult, httpResponse, err := r.client.
+			DashboardFoldersServiceCreateDashboardFolder(ctx).
+			CreateDashboardFolderRequestDataStructure(rq).
+			Execute()
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Error creating dashboards folder %q of coralogix_dashboards_folder_tree", folderPath),
+				utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Create", rq),
+			)
+			return folders, diags
+		}
+		folders[folderPath] = DashboardsFolderTreeFolderModel{
+			ID:       types.StringPointerValue(createResult.FolderId),
+			ParentId: parentID,
+			Managed:  types.BoolValue(true),
+		}
+	}
+	return folders, diags
+}
+
+// deleteManagedFolders deletes the managed folders, children before their
+// parents. Folders that are already gone are skipped.
+func (r *DashboardsFolderTreeResource) deleteManagedFolders(ctx context.Context, folders map[string]DashboardsFolderTreeFolderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	folderPaths := slices.Collect(maps.Keys(folders))
+	slices.SortFunc(folderPaths, func(a, b string) int {
+		return cmp.Or(cmp.Compare(strings.Count(b, "/"), strings.Count(a, "/")), strings.Compare(a, b))
+	})
+	for _, folderPath := range folderPaths {
+		folder := folders[folderPath]
+		if !folder.Managed.ValueBool() {
+			continue
+		}
+		_, httpResponse, err := r.client.DashboardFoldersServiceDeleteDashboardFolder(ctx, folder.ID.ValueString()).Execute()
+		if err != nil && (httpResponse == nil || httpResponse.StatusCode != http.StatusNotFound) {
+			diags.AddError(fmt.Sprintf("Error deleting dashboards folder %q of coralogix_dashboards_folder_tree", folderPath),
+				utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Delete", nil))
+		}
+	}
+	return diags
+}
+
+func (r *DashboardsFolderTreeResource) listFoldersByPath(ctx context.Context) (map[string]dbfs.DashboardFolder, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	listResult, httpResponse, err := r.client.DashboardFoldersServiceListDashboardFolders(ctx).Execute()
+	if err != nil {
+		diags.AddError("Error listing dashboards folders", utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Read", nil))
+		return nil, diags
+	}
+	return dashboardsFoldersByPath(listResult.GetFolder()), diags
+}
+
+// dashboardsFolderTreePaths returns the declared paths together with all
+// their parents, parents first.
+func dashboardsFolderTreePaths(ctx context.Context, paths types.Set) ([]string, diag.Diagnostics) {
+	var declared []string
+	diags := paths.ElementsAs(ctx, &declared, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	treePaths := map[string]bool{}
+	for _, folderPath := range declared {
+		for folderPath != "" {
+			treePaths[folderPath] = true
+			folderPath, _ = dashboardsFolderParentPath(folderPath)
+		}
+	}
+	sorted := slices.Collect(maps.Keys(treePaths))
+	slices.SortFunc(sorted, func(a, b string) int {
+		return cmp.Or(cmp.Compare(strings.Count(a, "/"), strings.Count(b, "/")), strings.Compare(a, b))
+	})
+	return sorted, diags
+}
+
+func dashboardsFolderParentPath(folderPath string) (string, string) {
+	i := strings.LastIndex(folderPath, "/")
+	if i < 0 {
+		return "", folderPath
+	}
+	return folderPath[:i], folderPath[i+1:]
+}
+
+// dashboardsFoldersByPath keys folders by their slash-separated path from the
+// top level folder. When siblings share a name the first one listed wins.
+func dashboardsFoldersByPath(folders []dbfs.DashboardFolder) map[string]dbfs.DashboardFolder {
+	byID := make(map[string]dbfs.DashboardFolder, len(folders))
+	for _, folder := range folders {
+		byID[folder.GetId()] = folder
+	}
+
+	byPath := make(map[string]dbfs.DashboardFolder, len(folders))
+	for _, folder := range folders {
+		segments := []string{folder.GetName()}
+		visited := map[string]bool{folder.GetId(): true}
+		complete := true
+		for parentID := folder.GetParentId(); parentID != ""; {
+			parent, ok := byID[parentID]
+			if !ok || visited[parentID] {
+				// The parent is not listed, the path of the folder is unknown.
+				complete = false
+				break
+			}
+			visited[parentID] = true
+			segments = append(segments, parent.GetName())
+			parentID = parent.GetParentId()
+		}
+		if !complete {
+			continue
+		}
+		slices.Reverse(segments)
+		folderPath := strings.Join(segments, "/")
+		if _, ok := byPath[folderPath]; !ok {
+			byPath[folderPath] = folder
+		}
+	}
+	return byPath
+}
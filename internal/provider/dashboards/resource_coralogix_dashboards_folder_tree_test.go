@@ -0,0 +1,112 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	dbfs "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/dashboard_folders_service"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testDashboardsFolder(id, name, parentID string) dbfs.DashboardFolder {
+	folder := dbfs.DashboardFolder{Id: &id, Name: &name}
+	if parentID != "" {
+		folder.ParentId = &parentID
+	}
+	return folder
+}
+
+func TestDashboardsFoldersByPath(t *testing.T) {
+	byPath := dashboardsFoldersByPath([]dbfs.DashboardFolder{
+		testDashboardsFolder("nodes", "nodes", "k8s"),
+		testDashboardsFolder("platform", "platform", ""),
+		testDashboardsFolder("k8s", "k8s", "platform"),
+		testDashboardsFolder("other-nodes", "nodes", "k8s"),
+		testDashboardsFolder("orphan", "orphan", "missing"),
+		testDashboardsFolder("loop-a", "a", "loop-b"),
+		testDashboardsFolder("loop-b", "b", "loop-a"),
+	})
+
+	if want := []string{"platform", "platform/k8s", "platform/k8s/nodes"}; !reflect.DeepEqual(slices.Sorted(maps.Keys(byPath)), want) {
+		t.Errorf("paths = %q, want %q", slices.Sorted(maps.Keys(byPath)), want)
+	}
+	if id := byPath["platform/k8s/nodes"].GetId(); id != "nodes" {
+		t.Errorf("platform/k8s/nodes = %q, want the first listed sibling", id)
+	}
+}
+
+func TestDashboardsFolderTreePaths(t *testing.T) {
+	paths := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("platform/k8s/nodes"),
+		types.StringValue("platform/k8s/pods"),
+		types.StringValue("teams/payments"),
+	})
+
+	got, diags := dashboardsFolderTreePaths(context.Background(), paths)
+	if diags.HasError() {
+		t.Fatalf("dashboardsFolderTreePaths returned %v", diags)
+	}
+	want := []string{"platform", "teams", "platform/k8s", "teams/payments", "platform/k8s/nodes", "platform/k8s/pods"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %q, want parents first %q", got, want)
+	}
+}
+
+func TestApplyFolderTreeReturnsFoldersCreatedBeforeAnError(t *testing.T) {
+	creates := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"folder":[]}`))
+		case http.MethodPost:
+			creates++
+			if creates > 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"code":500,"message":"create failed"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"folderId":"platform-id"}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	configuration := dbfs.NewConfiguration()
+	configuration.HTTPClient = server.Client()
+	configuration.Servers = dbfs.ServerConfigurations{{URL: server.URL}}
+	r := DashboardsFolderTreeResource{client: dbfs.NewAPIClient(configuration).DashboardFoldersServiceAPI}
+	paths := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("platform/k8s")})
+
+	folders, diags := r.applyFolderTree(context.Background(), paths, nil)
+	if !diags.HasError() {
+		t.Fatal("applyFolderTree diagnostics have no error, want the failed create")
+	}
+	want := map[string]DashboardsFolderTreeFolderModel{
+		"platform": {ID: types.StringValue("platform-id"), ParentId: types.StringNull(), Managed: types.BoolValue(true)},
+	}
+	if !reflect.DeepEqual(folders, want) {
+		t.Errorf("folders = %+v, want the folder created before the error %+v", folders, want)
+	}
+}
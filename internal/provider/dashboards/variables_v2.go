@@ -16,8 +16,11 @@ package dashboards
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	dashboardschema "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_schema"
 	dashboardwidgets "github.com/coralogix/terraform-provider-coralogix/internal/provider/dashboards/dashboard_widgets"
@@ -45,6 +48,7 @@ type variableSourceV2Model struct {
 	Static  *staticSourceV2Model  `tfsdk:"static"`
 	Textbox *textboxSourceV2Model `tfsdk:"textbox"`
 	Query   *querySourceV2Model   `tfsdk:"query"`
+	CSV     *csvSourceV2Model     `tfsdk:"csv"`
 }
 
 type staticSourceV2Model struct {
@@ -53,6 +57,15 @@ type staticSourceV2Model struct {
 	Values               types.List        `tfsdk:"values"`
 }
 
+type csvSourceV2Model struct {
+	Content              types.String      `tfsdk:"content"`
+	ValueColumn          types.String      `tfsdk:"value_column"`
+	LabelColumn          types.String      `tfsdk:"label_column"`
+	DefaultValues        types.List        `tfsdk:"default_values"` // []types.String
+	ValuesOrderDirection types.String      `tfsdk:"values_order_direction"`
+	AllOption            *allOptionV2Model `tfsdk:"all_option"`
+}
+
 type allOptionV2Model struct {
 	IncludeAll types.Bool   `tfsdk:"include_all"`
 	Label      types.String `tfsdk:"label"`
@@ -305,8 +318,15 @@ func expandVariableSourceV2(ctx context.Context, source types.Object) (*dashboar
 			return nil, diags
 		}
 		result.Query = query
+	case model.CSV != nil:
+		static, csvDiags := expandCSVSourceV2(ctx, model.CSV)
+		diags.Append(csvDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		result.Static = static
 	default:
-		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Error expanding variables_v2 source", "source must set exactly one of static, textbox, or query")}
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Error expanding variables_v2 source", "source must set exactly one of static, textbox, query, or csv")}
 	}
 	return result, diags
 }
@@ -350,6 +370,94 @@ func expandStaticValuesV2(ctx context.Context, values types.List) ([]dashboardse
 	return result, nil
 }
 
+// expandCSVSourceV2 turns the rows of a CSV source into the values of a
+// static source, since Coralogix has no variable source reading enrichments.
+func expandCSVSourceV2(ctx context.Context, model *csvSourceV2Model) (*dashboardservice.StaticSource, diag.Diagnostics) {
+	var defaults []string
+	if !model.DefaultValues.IsNull() && !model.DefaultValues.IsUnknown() {
+		if diags := model.DefaultValues.ElementsAs(ctx, &defaults, false); diags.HasError() {
+			return nil, diags
+		}
+	}
+	values, err := csvVariableValuesV2(model.Content.ValueString(), model.ValueColumn.ValueString(), model.LabelColumn.ValueString(), defaults)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Error expanding variables_v2 csv source", err.Error())}
+	}
+	return &dashboardservice.StaticSource{
+		ValuesOrderDirection: requiredEnumValue(model.ValuesOrderDirection, dashboardwidgets.DashboardOrderDirectionSchemaToProtoV2),
+		AllOption:            expandAllOptionV2(model.AllOption),
+		Values:               values,
+	}, nil
+}
+
+func csvVariableValuesV2(content, valueColumn, labelColumn string, defaults []string) ([]dashboardservice.ValueLabel, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV content: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV content has no header row")
+	}
+
+	header := records[0]
+	column := func(name string) (int, error) {
+		for i, h := range header {
+			if strings.TrimSpace(h) == name {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("column %q is not in the CSV header %q", name, header)
+	}
+	valueIndex, err := column(valueColumn)
+	if err != nil {
+		return nil, err
+	}
+	labelIndex := valueIndex
+	if labelColumn != "" {
+		if labelIndex, err = column(labelColumn); err != nil {
+			return nil, err
+		}
+	}
+	field := func(record []string, i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	seen := map[string]bool{}
+	values := make([]dashboardservice.ValueLabel, 0, len(records)-1)
+	for _, record := range records[1:] {
+		value := field(record, valueIndex)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		label := field(record, labelIndex)
+		if label == "" {
+			label = value
+		}
+		entry := dashboardservice.ValueLabel{Value: value, Label: label}
+		if slices.Contains(defaults, value) {
+			isDefault := true
+			entry.IsDefault = &isDefault
+		}
+		values = append(values, entry)
+	}
+	for _, value := range defaults {
+		if !seen[value] {
+			return nil, fmt.Errorf("default value %q is not in the %q column", value, valueColumn)
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("column %q has no values", valueColumn)
+	}
+	return values, nil
+}
+
 func expandAllOptionV2(model *allOptionV2Model) dashboardservice.AllOption {
 	if model == nil {
 		return dashboardservice.AllOption{}
@@ -807,6 +915,7 @@ func flattenDashboardVariablesV2(ctx context.Context, variables []dashboardservi
 		return types.ListNull(elementType), nil
 	}
 
+	csvSources := configuredCSVSourcesV2(ctx, configured)
 	elements := make([]attr.Value, 0, len(variables))
 	var diags diag.Diagnostics
 	for i := range variables {
@@ -815,6 +924,11 @@ func flattenDashboardVariablesV2(ctx context.Context, variables []dashboardservi
 		if elementDiags.HasError() {
 			continue
 		}
+		if source, ok := csvSources[variables[i].Name]; ok && csvSourceMatchesV2(ctx, source, variables[i].Source.Static) {
+			attrs := element.Attributes()
+			attrs["source"] = source
+			element = types.ObjectValueMust(elementType.AttrTypes, attrs)
+		}
 		elements = append(elements, element)
 	}
 	if diags.HasError() {
@@ -823,6 +937,56 @@ func flattenDashboardVariablesV2(ctx context.Context, variables []dashboardservi
 	return types.ListValueMust(elementType, elements), nil
 }
 
+// configuredCSVSourcesV2 returns the sources of the configured variables
+// reading a CSV, by variable name.
+func configuredCSVSourcesV2(ctx context.Context, configured types.List) map[string]types.Object {
+	if configured.IsNull() || configured.IsUnknown() {
+		return nil
+	}
+	var models []DashboardVariableV2Model
+	if diags := configured.ElementsAs(ctx, &models, true); diags.HasError() {
+		return nil
+	}
+	sources := map[string]types.Object{}
+	for _, model := range models {
+		if utils.ObjIsNullOrUnknown(model.Source) || model.Name.IsUnknown() {
+			continue
+		}
+		if csvSource, ok := model.Source.Attributes()["csv"]; ok && !csvSource.IsNull() {
+			sources[model.Name.ValueString()] = model.Source
+		}
+	}
+	return sources
+}
+
+// csvSourceMatchesV2 reports whether the static source stored in Coralogix
+// is still the one the CSV source expands to. When the CSV or the dashboard
+// changed, the static source is kept in the state so the plan shows the drift.
+func csvSourceMatchesV2(ctx context.Context, source types.Object, static *dashboardservice.StaticSource) bool {
+	if static == nil {
+		return false
+	}
+	expanded, diags := expandVariableSourceV2(ctx, source)
+	if diags.HasError() || expanded == nil || expanded.Static == nil {
+		return false
+	}
+	want, got := expanded.Static, static
+	if want.ValuesOrderDirection != got.ValuesOrderDirection ||
+		want.AllOption.IncludeAll != got.AllOption.IncludeAll ||
+		derefOrZero(want.AllOption.Label) != derefOrZero(got.AllOption.Label) ||
+		len(want.Values) != len(got.Values) {
+		return false
+	}
+	for i := range want.Values {
+		if want.Values[i].Value != got.Values[i].Value ||
+			want.Values[i].Label != got.Values[i].Label ||
+			derefOrZero(want.Values[i].IsDefault) != derefOrZero(got.Values[i].IsDefault) {
+			return false
+		}
+	}
+	return true
+}
+
 func flattenDashboardVariableV2(ctx context.Context, variable *dashboardservice.VariableV2, elementType basetypes.ObjectType) (types.Object, diag.Diagnostics) {
 	sourceType := elementType.AttrTypes["source"].(types.ObjectType)
 	valueType := elementType.AttrTypes["value"].(types.ObjectType)
@@ -873,6 +1037,7 @@ func flattenVariableSourceV2(ctx context.Context, source *dashboardservice.Varia
 		"static":  nullValueForType(objectType.AttrTypes["static"]),
 		"textbox": nullValueForType(objectType.AttrTypes["textbox"]),
 		"query":   nullValueForType(objectType.AttrTypes["query"]),
+		"csv":     nullValueForType(objectType.AttrTypes["csv"]),
 	}
 	var diags diag.Diagnostics
 	switch {
@@ -163,6 +163,7 @@ func TestExpandVariableV2OptionalEnumsOmitUnset(t *testing.T) {
 		"static":  nullObjectAttr(sourceType.AttrTypes["static"]),
 		"textbox": textbox,
 		"query":   nullObjectAttr(sourceType.AttrTypes["query"]),
+		"csv":     nullObjectAttr(sourceType.AttrTypes["csv"]),
 	})
 	value := mustSingleStringValueV2(t, valueType, "hello", "hello")
 
@@ -460,6 +461,86 @@ func assertTextboxNumericFractions(t *testing.T) {
 	}
 }
 
+func TestExpandFlattenVariablesV2CSVSource(t *testing.T) {
+	ctx := context.Background()
+	elementType := dashboardVariablesV2ElementType()
+	sourceType := elementType.AttrTypes["source"].(types.ObjectType)
+	valueType := elementType.AttrTypes["value"].(types.ObjectType)
+	csvType := sourceType.AttrTypes["csv"].(types.ObjectType)
+
+	csvSource := func(content string) types.Object {
+		return mustObjectValue(t, sourceType.AttrTypes, map[string]attr.Value{
+			"static":  nullObjectAttr(sourceType.AttrTypes["static"]),
+			"textbox": nullObjectAttr(sourceType.AttrTypes["textbox"]),
+			"query":   nullObjectAttr(sourceType.AttrTypes["query"]),
+			"csv": mustObjectValue(t, csvType.AttrTypes, map[string]attr.Value{
+				"content":                types.StringValue(content),
+				"value_column":           types.StringValue("service"),
+				"label_column":           types.StringValue("team"),
+				"default_values":         mustListValue(t, types.StringType, []attr.Value{types.StringValue("checkout")}),
+				"values_order_direction": types.StringValue("none"),
+				"all_option":             mustAllOptionV2(t, csvType, true),
+			}),
+		})
+	}
+	configured := func(content string) types.List {
+		return mustListValue(t, elementType, []attr.Value{
+			mustVariableV2(t, elementType, "service", "Service", csvSource(content), mustSingleStringValueV2(t, valueType, "checkout", "payments")),
+		})
+	}
+
+	list := configured("team,service\npayments,checkout\npayments,checkout\nsearch,catalog\nsearch,\n")
+	expanded := mustExpandVariablesV2(t, ctx, list)
+	static := expanded[0].Source.Static
+	if static == nil {
+		t.Fatal("expected csv source to expand to a static source")
+	}
+	if len(static.Values) != 2 ||
+		static.Values[0].Value != "checkout" || static.Values[0].Label != "payments" || static.Values[0].IsDefault == nil || !*static.Values[0].IsDefault ||
+		static.Values[1].Value != "catalog" || static.Values[1].Label != "search" || static.Values[1].IsDefault != nil {
+		t.Fatalf("static values = %#v", static.Values)
+	}
+
+	var models []DashboardVariableV2Model
+	mustElementsAs(t, mustFlattenVariablesV2(t, ctx, expanded, list), &models)
+	var source variableSourceV2Model
+	mustObjectAs(t, models[0].Source, &source)
+	if source.CSV == nil || source.Static != nil {
+		t.Fatalf("expected the csv source to be kept, got %#v", source)
+	}
+
+	// A new row in the CSV no longer matches the dashboard, so the static
+	// source is read back and the next plan updates the variable.
+	models = nil
+	mustElementsAs(t, mustFlattenVariablesV2(t, ctx, expanded, configured("team,service\npayments,checkout\nsearch,catalog\nsearch,search-api\n")), &models)
+	source = variableSourceV2Model{}
+	mustObjectAs(t, models[0].Source, &source)
+	if source.CSV != nil || source.Static == nil {
+		t.Fatalf("expected the static source after the CSV changed, got %#v", source)
+	}
+}
+
+func TestCSVVariableValuesV2Errors(t *testing.T) {
+	content := "team,service\npayments,checkout\n"
+	tests := []struct {
+		name, valueColumn, labelColumn string
+		defaults                       []string
+		want                           string
+	}{
+		{"unknown value column", "owner", "", nil, `column "owner" is not in the CSV header ["team" "service"]`},
+		{"unknown label column", "service", "owner", nil, `column "owner" is not in the CSV header ["team" "service"]`},
+		{"unknown default", "service", "", []string{"catalog"}, `default value "catalog" is not in the "service" column`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := csvVariableValuesV2(content, tt.valueColumn, tt.labelColumn, tt.defaults)
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("error = %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
 type variablesV2QueryTypes struct {
 	elementType         types.ObjectType
 	sourceType          types.ObjectType
@@ -607,6 +688,7 @@ func mustSourceWithStaticV2(t *testing.T, sourceType types.ObjectType, static ty
 		"static":  static,
 		"textbox": nullObjectAttr(sourceType.AttrTypes["textbox"]),
 		"query":   nullObjectAttr(sourceType.AttrTypes["query"]),
+		"csv":     nullObjectAttr(sourceType.AttrTypes["csv"]),
 	})
 }
 
@@ -664,6 +746,7 @@ func mustQueryVariableListV2(t *testing.T, typeset variablesV2QueryTypes, name,
 		"static":  nullObjectAttr(typeset.sourceType.AttrTypes["static"]),
 		"textbox": nullObjectAttr(typeset.sourceType.AttrTypes["textbox"]),
 		"query":   query,
+		"csv":     nullObjectAttr(typeset.sourceType.AttrTypes["csv"]),
 	})
 	return mustListValue(t, typeset.elementType, []attr.Value{
 		mustVariableV2(t, typeset.elementType, name, displayName, source, value),
@@ -0,0 +1,84 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var alertTestDataSourceName = "data.coralogix_alert_test.test"
+
+func TestAccCoralogixDataSourceAlertTest(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixDataSourceAlertTest(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(alertTestDataSourceName, "fires", "true"),
+					resource.TestCheckResourceAttr(alertTestDataSourceName, "matched_logs.#", "3"),
+					resource.TestCheckResourceAttr(alertTestDataSourceName, "results.#", "2"),
+					resource.TestCheckResourceAttr(alertTestDataSourceName, "results.0.group.region", "eu"),
+					resource.TestCheckResourceAttr(alertTestDataSourceName, "results.0.value", "2"),
+					resource.TestCheckResourceAttr(alertTestDataSourceName, "results.0.fires", "true"),
+					resource.TestCheckResourceAttr(alertTestDataSourceName, "results.1.group.region", "us"),
+					resource.TestCheckResourceAttr(alertTestDataSourceName, "results.1.fires", "false"),
+					resource.TestCheckNoResourceAttr(alertTestDataSourceName, "denominator_matched_logs"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCoralogixDataSourceAlertTest() string {
+	return `data "coralogix_alert_test" "test" {
+  definition = yamlencode({
+    name     = "payment failures by region"
+    group_by = ["region"]
+    type_definition = {
+      logs_threshold = {
+        logs_filter = {
+          simple_filter = {
+            lucene_query = "message:failed"
+            label_filters = {
+              application_name = [{ value = "check", operation = "STARTS_WITH" }]
+              severities       = ["Error"]
+            }
+          }
+        }
+        rules = [{
+          condition = {
+            threshold      = 1
+            time_window    = "10_MINUTES"
+            condition_type = "MORE_THAN"
+          }
+        }]
+      }
+    }
+  })
+
+  logs = [
+    jsonencode({ applicationName = "checkout", severity = 5, message = "payment failed", region = "eu" }),
+    jsonencode({ applicationName = "checkout", severity = "Error", message = "payment failed", region = "eu" }),
+    jsonencode({ applicationName = "checkout", severity = 5, message = "payment failed", region = "us" }),
+    jsonencode({ applicationName = "checkout", severity = 3, message = "payment succeeded", region = "eu" }),
+    jsonencode({ applicationName = "billing", severity = 5, message = "payment failed", region = "eu" }),
+  ]
+}
+`
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var dashboardQueriesDataSourceName = "data.coralogix_dashboard_queries.test"
+
+func TestAccCoralogixDataSourceDashboardQueries_basic(t *testing.T) {
+	name := dashboardOpenAPIFixtureName(t.Name())
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDashboardDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourceDashboard(name) +
+					testAccCoralogixDataSourceDashboardQueries_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dashboardQueriesDataSourceName, "queries.0.title", "status 4XX"),
+					resource.TestCheckResourceAttr(dashboardQueriesDataSourceName, "queries.0.type", "line_chart"),
+					resource.TestCheckResourceAttr(dashboardQueriesDataSourceName, "queries.0.query_language", "promql"),
+					resource.TestCheckResourceAttr(dashboardQueriesDataSourceName, "queries.0.query", "http_requests_total{status!~\"4..\"}"),
+					resource.TestCheckTypeSetElemNestedAttrs(dashboardQueriesDataSourceName, "queries.*", map[string]string{
+						"title":          "dashboards-api logz",
+						"type":           "data_table",
+						"query_language": "lucene",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCoralogixDataSourceDashboardQueries_content_json(t *testing.T) {
+	fixture := dashboardContentJSONFixtureFor(t, "content_json_dynamic_queries_table.json")
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "coralogix_dashboard_queries" "test" {
+	content_json = file(%q)
+}
+`, fixture.path),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dashboardQueriesDataSourceName, "queries.#", "3"),
+					resource.TestCheckResourceAttr(dashboardQueriesDataSourceName, "queries.1.widget_id", "30000000-0000-4000-8000-000000000002"),
+					resource.TestCheckResourceAttr(dashboardQueriesDataSourceName, "queries.1.type", "dynamic"),
+					resource.TestCheckResourceAttr(dashboardQueriesDataSourceName, "queries.1.query", "up"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCoralogixDataSourceDashboardQueries_read() string {
+	return `data "coralogix_dashboard_queries" "test" {
+	id = coralogix_dashboard.test.id
+}
+`
+}
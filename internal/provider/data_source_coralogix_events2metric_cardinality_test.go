@@ -0,0 +1,81 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var events2MetricCardinalityDataSourceName = "data.coralogix_events2metric_cardinality.test"
+
+func TestAccCoralogixDataSourceEvents2MetricCardinality(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixDataSourceEvents2MetricCardinality(10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(events2MetricCardinalityDataSourceName, "estimated_permutations", "3"),
+					resource.TestCheckResourceAttr(events2MetricCardinalityDataSourceName, "label_cardinalities.pod", "3"),
+					resource.TestCheckResourceAttr(events2MetricCardinalityDataSourceName, "label_cardinalities.code", "2"),
+					resource.TestCheckResourceAttr(events2MetricCardinalityDataSourceName, "lower_bound", "false"),
+					resource.TestCheckResourceAttr(events2MetricCardinalityDataSourceName, "exceeds_limit", "false"),
+				),
+			},
+			{
+				Config: testAccCoralogixDataSourceEvents2MetricCardinality(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(events2MetricCardinalityDataSourceName, "estimated_permutations", "3"),
+					resource.TestCheckResourceAttr(events2MetricCardinalityDataSourceName, "lower_bound", "true"),
+					resource.TestCheckResourceAttr(events2MetricCardinalityDataSourceName, "exceeds_limit", "true"),
+				),
+			},
+			{
+				Config: `data "coralogix_events2metric_cardinality" "test" {
+  metric_labels = { pod = "kubernetes.pod" }
+  logs_query    = { lucene = "status:500" }
+  spans_query   = { lucene = "status:500" }
+}
+`,
+				ExpectError: regexp.MustCompile(`Only\s+one\s+of\s+logs_query\s+and\s+spans_query\s+can\s+be\s+set`),
+			},
+		},
+	})
+}
+
+func testAccCoralogixDataSourceEvents2MetricCardinality(limit int) string {
+	return fmt.Sprintf(`data "coralogix_events2metric_cardinality" "test" {
+  metric_labels = {
+    pod  = "kubernetes.pod"
+    code = "status"
+  }
+  logs_query = {
+    lucene = "status:[400 TO 599]"
+  }
+  permutations_limit = %d
+  samples = [
+    jsonencode({ kubernetes = { pod = "api-1" }, status = 500 }),
+    jsonencode({ kubernetes = { pod = "api-1" }, status = 500 }),
+    jsonencode({ kubernetes = { pod = "api-2" }, status = 503 }),
+    jsonencode({ kubernetes = { pod = "api-3" }, status = 500 }),
+  ]
+}
+`, limit)
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var metricLabelValuesDataSourceName = "data.coralogix_metric_label_values.test"
+
+func TestAccCoralogixDataSourceMetricLabelValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "coralogix_metric_label_values" "test" {
+  label    = "__name__"
+  lookback = "24h"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(metricLabelValuesDataSourceName, "label", "__name__"),
+					resource.TestCheckResourceAttrSet(metricLabelValuesDataSourceName, "values.#"),
+				),
+			},
+			{
+				Config: `data "coralogix_metric_label_values" "test" {
+  label = "job"
+  match = "rate(up[5m])"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid\s+match`),
+			},
+		},
+	})
+}
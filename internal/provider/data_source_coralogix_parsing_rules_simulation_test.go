@@ -0,0 +1,86 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var parsingRulesSimulationDataSourceName = "data.coralogix_parsing_rules_simulation.test"
+
+func TestAccCoralogixDataSourceParsingRulesSimulation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixDataSourceParsingRulesSimulation(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(parsingRulesSimulationDataSourceName, "group_matched", "true"),
+					resource.TestCheckResourceAttr(parsingRulesSimulationDataSourceName, "results.#", "3"),
+					resource.TestCheckResourceAttr(parsingRulesSimulationDataSourceName, "results.0.text", `{"method":"GET","path":"/orders","status":"200"}`),
+					resource.TestCheckResourceAttr(parsingRulesSimulationDataSourceName, "results.0.blocked", "false"),
+					resource.TestCheckResourceAttr(parsingRulesSimulationDataSourceName, "results.0.matched_rules.#", "1"),
+					resource.TestCheckResourceAttr(parsingRulesSimulationDataSourceName, "results.0.matched_rules.0", "access log"),
+					resource.TestCheckResourceAttr(parsingRulesSimulationDataSourceName, "results.1.blocked", "true"),
+					resource.TestCheckResourceAttr(parsingRulesSimulationDataSourceName, "results.1.matched_rules.1", "health checks"),
+					resource.TestCheckResourceAttr(parsingRulesSimulationDataSourceName, "results.2.text", "not an access log"),
+					resource.TestCheckResourceAttr(parsingRulesSimulationDataSourceName, "results.2.matched_rules.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCoralogixDataSourceParsingRulesSimulation() string {
+	return `data "coralogix_parsing_rules_simulation" "test" {
+  rule_group = yamlencode({
+    name         = "access logs"
+    applications = ["nginx"]
+    rule_subgroups = [
+      {
+        rules = [{
+          parse = {
+            name               = "access log"
+            source_field       = "text"
+            destination_field  = "text"
+            regular_expression = "^(?<method>[A-Z]+) (?<path>\\S+) (?<status>\\d{3})$"
+          }
+        }]
+      },
+      {
+        rules = [{
+          block = {
+            name                      = "health checks"
+            source_field              = "text.path"
+            regular_expression        = "^/healthz$"
+            block_all_matching_blocks = true
+          }
+        }]
+      },
+    ]
+  })
+
+  application_name = "nginx"
+  logs = [
+    "GET /orders 200",
+    "GET /healthz 200",
+    "not an access log",
+  ]
+}
+`
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 var (
@@ -125,20 +126,19 @@ func (d *DataEnrichmentDataSource) Read(ctx context.Context, req datasource.Read
 		}
 	}
 
-	var content *string = nil
+	var source *CustomEnrichmentDataModel = nil
 	if customEnrichmentId != nil {
 
 		if data.Custom != nil {
-			content = data.Custom.CustomEnrichmentDataModel.Contents.ValueStringPointer()
+			source = data.Custom.CustomEnrichmentDataModel
 		} else {
-			empty := ""
-			content = &empty
+			source = &CustomEnrichmentDataModel{Contents: basetypes.NewStringValue(""), Columns: basetypes.NewListNull(basetypes.StringType{})}
 		}
 	}
 
 	data = flattenDataEnrichments(enrichments,
 		customEnrichment,
-		content)
+		source)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
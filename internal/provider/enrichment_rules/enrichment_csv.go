@@ -0,0 +1,92 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichment_rules
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// validateEnrichmentCSV reads a custom enrichment table record by record and
+// returns its header. The header must equal columns when they are declared,
+// rows can't be blank and, when keyColumn is set, its values must be set and
+// unique.
+func validateEnrichmentCSV(r io.Reader, columns []string, keyColumn string) ([]string, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("the table is empty, it needs at least a header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	if columns != nil && !slices.Equal(header, columns) {
+		return nil, fmt.Errorf("the header row is %q, columns declares %q", header, columns)
+	}
+	if i := slices.Index(header, ""); i >= 0 {
+		return nil, fmt.Errorf("column %d of the header row is empty", i+1)
+	}
+	for i, name := range header {
+		if slices.Contains(header[i+1:], name) {
+			return nil, fmt.Errorf("the header row has the column %q more than once", name)
+		}
+	}
+	key := -1
+	if keyColumn != "" {
+		if key = slices.Index(header, keyColumn); key < 0 {
+			return nil, fmt.Errorf("key_column %q is not a column of the header row %q", keyColumn, header)
+		}
+	}
+
+	// encoding/csv skips empty lines, so they are found from the gaps
+	// between the lines of consecutive records.
+	lastLine, _ := reader.FieldPos(len(header) - 1)
+	keys := map[string]int{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return header, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if line > lastLine+1 {
+			return nil, fmt.Errorf("line %d: blank row", lastLine+1)
+		}
+		endLine, _ := reader.FieldPos(len(record) - 1)
+		lastLine = endLine + strings.Count(record[len(record)-1], "\n")
+
+		if !slices.ContainsFunc(record, func(field string) bool { return strings.TrimSpace(field) != "" }) {
+			return nil, fmt.Errorf("line %d: blank row", line)
+		}
+		if key < 0 {
+			continue
+		}
+		value := record[key]
+		if strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("line %d: key column %q is empty", line, keyColumn)
+		}
+		if first, ok := keys[value]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %q in column %q, first seen on line %d", line, value, keyColumn, first)
+		}
+		keys[value] = line
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichment_rules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateEnrichmentCSV(t *testing.T) {
+	for _, tc := range []struct {
+		name, table string
+		columns     []string
+		keyColumn   string
+		err         string
+	}{
+		{name: "valid", table: "\ufeffip,team\n10.0.0.1,payments\n10.0.0.2,\"search\nand ranking\"\n10.0.0.3,search\n", columns: []string{"ip", "team"}, keyColumn: "ip"},
+		{name: "no trailing newline", table: "ip,team\n10.0.0.1,payments", keyColumn: "ip"},
+		{name: "empty", table: "", keyColumn: "ip", err: "the table is empty"},
+		{name: "header mismatch", table: "ip,owner\n10.0.0.1,payments\n", columns: []string{"ip", "team"}, err: `the header row is ["ip" "owner"], columns declares ["ip" "team"]`},
+		{name: "duplicate header", table: "ip,ip\n10.0.0.1,10.0.0.2\n", err: `the header row has the column "ip" more than once`},
+		{name: "unknown key column", table: "ip,team\n", keyColumn: "host", err: `key_column "host" is not a column`},
+		{name: "empty line", table: "ip,team\n10.0.0.1,payments\n\n10.0.0.2,search\n", err: "line 3: blank row"},
+		{name: "empty fields", table: "ip,team\n10.0.0.1,payments\n , \n", err: "line 3: blank row"},
+		{name: "missing field", table: "ip,team\n10.0.0.1\n", err: "wrong number of fields"},
+		{name: "empty key", table: "ip,team\n,payments\n", keyColumn: "ip", err: `line 2: key column "ip" is empty`},
+		{name: "duplicate key", table: "ip,team\n10.0.0.1,payments\n10.0.0.2,search\n10.0.0.1,search\n", keyColumn: "ip", err: `line 4: duplicate key "10.0.0.1" in column "ip", first seen on line 2`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			header, err := validateEnrichmentCSV(strings.NewReader(tc.table), tc.columns, tc.keyColumn)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("error = %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []string{"ip", "team"}; !reflect.DeepEqual(header, want) {
+				t.Errorf("header = %q, want %q", header, want)
+			}
+		})
+	}
+}
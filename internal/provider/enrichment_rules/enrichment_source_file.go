@@ -0,0 +1,99 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichment_rules
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// enrichmentSourceFile summarizes a CSV file uploaded from source_file. Only
+// the summary is kept in the state, never the contents.
+type enrichmentSourceFile struct {
+	sha256 string
+	size   int64
+	rows   int64
+}
+
+// summarizeEnrichmentSourceFile reads the file at path in chunks, so large
+// files are hashed without holding them in memory.
+func summarizeEnrichmentSourceFile(path string) (*enrichmentSourceFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	counter := &enrichmentLineCounter{}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(hash, counter), f)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	lines := counter.lines
+	if size > 0 && counter.last != '\n' {
+		lines++
+	}
+	// The first line is the header of the CSV.
+	rows := max(lines-1, 0)
+	return &enrichmentSourceFile{sha256: hex.EncodeToString(hash.Sum(nil)), size: size, rows: rows}, nil
+}
+
+// planEnrichmentSourceFile summarizes the file at path, which is uploaded the
+// same way as file_content and must fit in the same limit.
+func planEnrichmentSourceFile(path string) (*enrichmentSourceFile, error) {
+	summary, err := summarizeEnrichmentSourceFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if summary.size > int64(fileContentLimit) {
+		return nil, fmt.Errorf("source_file expected to be no longer than %d characters, got %d characters", fileContentLimit, summary.size)
+	}
+	return summary, nil
+}
+
+// readEnrichmentSourceFile returns the contents of the file at path to
+// upload, and fails when the file changed since its SHA-256 was planned. The
+// data set API takes the contents in a single string, so the file is read
+// whole; the plan keeps it within fileContentLimit.
+func readEnrichmentSourceFile(path, plannedSHA256 string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	if plannedSHA256 != "" && hex.EncodeToString(sum[:]) != plannedSHA256 {
+		return "", fmt.Errorf("%s changed since the plan was made: its SHA-256 is %x, the plan expected %s. Plan again to upload it", path, sum, plannedSHA256)
+	}
+	return string(content), nil
+}
+
+type enrichmentLineCounter struct {
+	lines int64
+	last  byte
+}
+
+func (c *enrichmentLineCounter) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		c.lines += int64(bytes.Count(p, []byte{'\n'}))
+		c.last = p[len(p)-1]
+	}
+	return len(p), nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichment_rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSummarizeEnrichmentSourceFile(t *testing.T) {
+	for _, tc := range []struct {
+		name, content string
+		rows          int64
+	}{
+		{"empty", "", 0},
+		{"header only", "ip,team\n", 0},
+		{"trailing newline", "ip,team\n10.0.0.1,payments\n10.0.0.2,search\n", 2},
+		{"no trailing newline", "ip,team\n10.0.0.1,payments\n10.0.0.2,search", 2},
+		{"larger than a read buffer", "ip,team\n" + strings.Repeat("10.0.0.1,payments\n", 10_000), 10_000},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "table.csv")
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			summary, err := summarizeEnrichmentSourceFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if summary.rows != tc.rows || summary.size != int64(len(tc.content)) {
+				t.Errorf("rows = %d, size = %d, want %d, %d", summary.rows, summary.size, tc.rows, len(tc.content))
+			}
+
+			content, err := readEnrichmentSourceFile(path, summary.sha256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if content != tc.content {
+				t.Errorf("read %q, want %q", content, tc.content)
+			}
+		})
+	}
+}
+
+func TestReadEnrichmentSourceFileChangedSincePlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "table.csv")
+	if err := os.WriteFile(path, []byte("ip,team\n10.0.0.1,payments\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	summary, err := summarizeEnrichmentSourceFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("ip,team\n10.0.0.1,search\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := readEnrichmentSourceFile(path, summary.sha256); err == nil || !strings.Contains(err.Error(), "changed since the plan") {
+		t.Fatalf("error = %v, want the file to be reported as changed", err)
+	}
+}
+
+func TestPlanEnrichmentSourceFileLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "table.csv")
+	content := "ip,team\n" + strings.Repeat("10.0.0.1,payments\n", fileContentLimit/18+1)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := planEnrichmentSourceFile(path); err == nil || !strings.Contains(err.Error(), "no longer than 1000000 characters") {
+		t.Fatalf("error = %v, want the file to be over the limit", err)
+	}
+}
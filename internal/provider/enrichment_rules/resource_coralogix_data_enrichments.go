@@ -3,7 +3,9 @@ package enrichment_rules
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"slices"
 	"strconv"
 	"strings"
@@ -18,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -26,11 +29,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 var (
-	_ resource.ResourceWithConfigure   = &DataEnrichmentsResource{}
-	_ resource.ResourceWithImportState = &DataEnrichmentsResource{}
+	_ resource.ResourceWithConfigure      = &DataEnrichmentsResource{}
+	_ resource.ResourceWithImportState    = &DataEnrichmentsResource{}
+	_ resource.ResourceWithModifyPlan     = &DataEnrichmentsResource{}
+	_ resource.ResourceWithValidateConfig = &DataEnrichmentsResource{}
+
+	customEnrichmentDataPath = path.Root(CUSTOM_TYPE).AtName("custom_enrichment_data")
 )
 
 const (
@@ -98,6 +106,14 @@ type CustomEnrichmentDataModel struct {
 	Description types.String `tfsdk:"description"`
 	Version     types.Int64  `tfsdk:"version"`
 	Contents    types.String `tfsdk:"contents"`
+
+	SourceFile      types.String `tfsdk:"source_file"`
+	SourceSha256    types.String `tfsdk:"source_sha256"`
+	SourceSizeBytes types.Int64  `tfsdk:"source_size_bytes"`
+	SourceRows      types.Int64  `tfsdk:"source_rows"`
+
+	Columns   types.List   `tfsdk:"columns"` // []types.String
+	KeyColumn types.String `tfsdk:"key_column"`
 }
 
 func (e AwsEnrichmentFieldModel) GetId() uint32 {
@@ -178,7 +194,8 @@ Outer:
 		state := DataEnrichmentsModel{
 			Custom: &CustomEnrichmentFieldsModel{
 				CustomEnrichmentDataModel: &CustomEnrichmentDataModel{
-					ID: types.Int64Value(val),
+					ID:      types.Int64Value(val),
+					Columns: types.ListNull(types.StringType),
 				},
 			},
 		}
@@ -334,8 +351,43 @@ func (r *DataEnrichmentsResource) Schema(_ context.Context, _ resource.SchemaReq
 								Description: "The version of the enrichment data.",
 							},
 							"contents": schema.StringAttribute{
-								Required:    true,
+								Optional:    true,
 								Description: "The file contents to upload. Use Terraform's functions to read from disk.",
+								Validators: []validator.String{
+									stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("source_file")),
+								},
+							},
+							"source_file": schema.StringAttribute{
+								Optional: true,
+								MarkdownDescription: "Path of a CSV file to upload instead of `contents`. Only the SHA-256, size and row count of the file are kept in the state, " +
+									"and the file is uploaded again when its SHA-256 changes, so large files don't bloat the state and plans. The file is read whole into memory when it is uploaded.",
+							},
+							"source_sha256": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "SHA-256 of the `source_file` contents, in hex.",
+							},
+							"source_size_bytes": schema.Int64Attribute{
+								Computed:            true,
+								MarkdownDescription: "Size of `source_file` in bytes.",
+							},
+							"source_rows": schema.Int64Attribute{
+								Computed:            true,
+								MarkdownDescription: "Number of rows of `source_file`, without the header row.",
+							},
+							"columns": schema.ListAttribute{
+								Optional:    true,
+								ElementType: types.StringType,
+								MarkdownDescription: "Columns of the table, in order. When set, the table is checked at plan time: its header row must equal `columns`, " +
+									"it can't have blank rows and `fields[].selected_columns` must be among `columns`.",
+								Validators: []validator.List{
+									listvalidator.SizeAtLeast(1),
+									listvalidator.UniqueValues(),
+								},
+							},
+							"key_column": schema.StringAttribute{
+								Optional: true,
+								MarkdownDescription: "Column whose values identify the rows of the table. When set, the table is checked at plan time like with `columns`, " +
+									"and every row must have a unique, non-empty value in `key_column`.",
 							},
 						},
 					},
@@ -355,6 +407,121 @@ func (r *DataEnrichmentsResource) Schema(_ context.Context, _ resource.SchemaReq
 	}
 }
 
+func (r *DataEnrichmentsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, customEnrichmentDataPath, &data)...)
+	if resp.Diagnostics.HasError() || data.IsNull() || data.IsUnknown() {
+		return
+	}
+	var sourceFile types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, customEnrichmentDataPath.AtName("source_file"), &sourceFile)...)
+	if resp.Diagnostics.HasError() || sourceFile.IsUnknown() {
+		return
+	}
+
+	sha256, size, rows := types.StringNull(), types.Int64Null(), types.Int64Null()
+	if !sourceFile.IsNull() {
+		summary, err := summarizeEnrichmentSourceFile(sourceFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(customEnrichmentDataPath.AtName("source_file"), "Error reading source_file", err.Error())
+			return
+		}
+		sha256, size, rows = types.StringValue(summary.sha256), types.Int64Value(summary.size), types.Int64Value(summary.rows)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, customEnrichmentDataPath.AtName("source_sha256"), sha256)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, customEnrichmentDataPath.AtName("source_size_bytes"), size)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, customEnrichmentDataPath.AtName("source_rows"), rows)...)
+}
+
+func (r *DataEnrichmentsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, customEnrichmentDataPath, &data)...)
+	if resp.Diagnostics.HasError() || data.IsNull() || data.IsUnknown() {
+		return
+	}
+	var custom CustomEnrichmentDataModel
+	resp.Diagnostics.Append(data.As(ctx, &custom, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() || (custom.Columns.IsNull() && custom.KeyColumn.IsNull()) || custom.KeyColumn.IsUnknown() {
+		return
+	}
+
+	var columns []string
+	if !custom.Columns.IsNull() {
+		if custom.Columns.IsUnknown() {
+			return
+		}
+		for _, column := range custom.Columns.Elements() {
+			column, ok := column.(types.String)
+			if !ok || column.IsUnknown() {
+				return
+			}
+			columns = append(columns, column.ValueString())
+		}
+	}
+	keyColumn := custom.KeyColumn.ValueString()
+	if columns != nil && keyColumn != "" && !slices.Contains(columns, keyColumn) {
+		resp.Diagnostics.AddAttributeError(customEnrichmentDataPath.AtName("key_column"), "Invalid key_column",
+			fmt.Sprintf("key_column %q is not one of columns %q", keyColumn, columns))
+		return
+	}
+
+	header := columns
+	table, tablePath := openCustomEnrichmentTable(custom)
+	if table != nil {
+		defer table.Close()
+		var err error
+		if header, err = validateEnrichmentCSV(table, columns, keyColumn); err != nil {
+			resp.Diagnostics.AddAttributeError(tablePath, "Invalid custom enrichment table", err.Error())
+			return
+		}
+	}
+	if header == nil {
+		return
+	}
+
+	var fields types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(CUSTOM_TYPE).AtName("fields"), &fields)...)
+	if resp.Diagnostics.HasError() || fields.IsNull() || fields.IsUnknown() {
+		return
+	}
+	var fieldModels []EnrichmentFieldModel
+	resp.Diagnostics.Append(fields.ElementsAs(ctx, &fieldModels, false)...)
+	for i, field := range fieldModels {
+		for _, column := range field.SelectedColumns.Elements() {
+			column, ok := column.(types.String)
+			if !ok || column.IsNull() || column.IsUnknown() || slices.Contains(header, column.ValueString()) {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(path.Root(CUSTOM_TYPE).AtName("fields").AtListIndex(i).AtName("selected_columns"), "Unknown selected column",
+				fmt.Sprintf("%q is not a column of the custom enrichment table, the columns are %q", column.ValueString(), header))
+		}
+	}
+}
+
+// openCustomEnrichmentTable returns the table of the custom enrichment and the
+// attribute it comes from, or nil when it isn't known yet. A source_file that
+// can't be opened is reported by ModifyPlan.
+func openCustomEnrichmentTable(custom CustomEnrichmentDataModel) (io.ReadCloser, path.Path) {
+	switch {
+	case !custom.SourceFile.IsNull():
+		if custom.SourceFile.IsUnknown() {
+			return nil, path.Empty()
+		}
+		f, err := os.Open(custom.SourceFile.ValueString())
+		if err != nil {
+			return nil, path.Empty()
+		}
+		return f, customEnrichmentDataPath.AtName("source_file")
+	case !custom.Contents.IsNull() && !custom.Contents.IsUnknown():
+		return io.NopCloser(strings.NewReader(custom.Contents.ValueString())), customEnrichmentDataPath.AtName("contents")
+	}
+	return nil, path.Empty()
+}
+
 func enrichmentFieldSchema() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
 		"name": schema.StringAttribute{
@@ -382,7 +549,11 @@ func (r *DataEnrichmentsResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 	// First, upload the custom enrichment (if provided)
-	upload := extractCustomEnrichmentsDataCreate(plan)
+	upload, err := extractCustomEnrichmentsDataCreate(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(customEnrichmentDataPath.AtName("source_file"), "Error reading source_file", err.Error())
+		return
+	}
 	var customId *int64 = nil
 	var uploadResult *cess.CustomEnrichment
 	if upload != nil {
@@ -419,14 +590,14 @@ func (r *DataEnrichmentsResource) Create(ctx context.Context, req resource.Creat
 		)
 		return
 	}
-	var content *string = nil
-	if plan.Custom != nil && plan.Custom.CustomEnrichmentDataModel != nil {
-		content = plan.Custom.CustomEnrichmentDataModel.Contents.ValueStringPointer()
+	var source *CustomEnrichmentDataModel = nil
+	if plan.Custom != nil {
+		source = plan.Custom.CustomEnrichmentDataModel
 	}
 	state := flattenDataEnrichments(result.Enrichments,
 		uploadResult,
 		// the data isn't actually returned from the request, so we have to keep the state happy like that
-		content)
+		source)
 
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -451,7 +622,11 @@ func (r *DataEnrichmentsResource) Update(ctx context.Context, req resource.Updat
 	}
 
 	// First, upload/update the custom enrichment (if provided)
-	upload := extractCustomEnrichmentsDataUpdate(plan)
+	upload, err := extractCustomEnrichmentsDataUpdate(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(customEnrichmentDataPath.AtName("source_file"), "Error reading source_file", err.Error())
+		return
+	}
 	var uploadResult *cess.CustomEnrichment
 	if upload != nil {
 		result, httpResponse, err := r.custom_enrichments_client.
@@ -494,13 +669,13 @@ func (r *DataEnrichmentsResource) Update(ctx context.Context, req resource.Updat
 		)
 		return
 	}
-	var content *string = nil
-	if plan.Custom != nil && plan.Custom.CustomEnrichmentDataModel != nil {
-		content = plan.Custom.CustomEnrichmentDataModel.Contents.ValueStringPointer()
+	var source *CustomEnrichmentDataModel = nil
+	if plan.Custom != nil {
+		source = plan.Custom.CustomEnrichmentDataModel
 	}
 	state = flattenDataEnrichments(result.Enrichments,
 		uploadResult,
-		content)
+		source)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
@@ -573,13 +748,13 @@ func (r *DataEnrichmentsResource) Read(ctx context.Context, req resource.ReadReq
 		}
 	}
 
-	var content *string = nil
+	var source *CustomEnrichmentDataModel = nil
 	if customEnrichmentId != nil {
-		content = state.Custom.CustomEnrichmentDataModel.Contents.ValueStringPointer()
+		source = state.Custom.CustomEnrichmentDataModel
 	}
 	state = flattenDataEnrichments(enrichments,
 		customEnrichment,
-		content)
+		source)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -627,8 +802,12 @@ func getCustomEnrichmentId(state *DataEnrichmentsModel) *int64 {
 	return nil
 }
 
-func extractCustomEnrichmentsDataCreate(plan *DataEnrichmentsModel) *cess.CreateCustomEnrichmentRequest {
+func extractCustomEnrichmentsDataCreate(plan *DataEnrichmentsModel) (*cess.CreateCustomEnrichmentRequest, error) {
 	if plan.Custom != nil {
+		contents, err := extractCustomEnrichmentContents(plan.Custom.CustomEnrichmentDataModel)
+		if err != nil {
+			return nil, err
+		}
 		ext := "csv"
 		return &cess.CreateCustomEnrichmentRequest{
 			Name:        plan.Custom.CustomEnrichmentDataModel.Name.ValueString(),
@@ -636,15 +815,19 @@ func extractCustomEnrichmentsDataCreate(plan *DataEnrichmentsModel) *cess.Create
 			File: cess.File{
 				Extension: &ext,
 				Name:      plan.Custom.CustomEnrichmentDataModel.Name.ValueStringPointer(),
-				Textual:   plan.Custom.CustomEnrichmentDataModel.Contents.ValueStringPointer(),
+				Textual:   contents,
 			},
-		}
+		}, nil
 	}
-	return nil
+	return nil, nil
 }
 
-func extractCustomEnrichmentsDataUpdate(plan *DataEnrichmentsModel) *cess.UpdateCustomEnrichmentRequest {
+func extractCustomEnrichmentsDataUpdate(plan *DataEnrichmentsModel) (*cess.UpdateCustomEnrichmentRequest, error) {
 	if plan.Custom != nil {
+		contents, err := extractCustomEnrichmentContents(plan.Custom.CustomEnrichmentDataModel)
+		if err != nil {
+			return nil, err
+		}
 		ext := "csv"
 		return &cess.UpdateCustomEnrichmentRequest{
 			CustomEnrichmentId: plan.Custom.CustomEnrichmentDataModel.ID.ValueInt64(),
@@ -653,11 +836,24 @@ func extractCustomEnrichmentsDataUpdate(plan *DataEnrichmentsModel) *cess.Update
 			File: cess.File{
 				Extension: &ext,
 				Name:      plan.Custom.CustomEnrichmentDataModel.Name.ValueStringPointer(),
-				Textual:   plan.Custom.CustomEnrichmentDataModel.Contents.ValueStringPointer(),
+				Textual:   contents,
 			},
-		}
+		}, nil
 	}
-	return nil
+	return nil, nil
+}
+
+// extractCustomEnrichmentContents returns contents, or the contents of
+// source_file when the file still has the planned SHA-256.
+func extractCustomEnrichmentContents(data *CustomEnrichmentDataModel) (*string, error) {
+	if data.SourceFile.IsNull() {
+		return data.Contents.ValueStringPointer(), nil
+	}
+	contents, err := readEnrichmentSourceFile(data.SourceFile.ValueString(), data.SourceSha256.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	return &contents, nil
 }
 
 func extractDataEnrichments(plan *DataEnrichmentsModel) []ess.EnrichmentRequestModel {
@@ -737,20 +933,32 @@ func extractDataEnrichmentsCreate(plan *DataEnrichmentsModel) *ess.EnrichmentsCr
 	return req
 }
 
-func flattenDataEnrichments(enrichments []ess.Enrichment, uploadResp *cess.CustomEnrichment, customEnrichmentContents *string) *DataEnrichmentsModel {
+func flattenDataEnrichments(enrichments []ess.Enrichment, uploadResp *cess.CustomEnrichment, source *CustomEnrichmentDataModel) *DataEnrichmentsModel {
 	id := make([]string, 0)
 	model := &DataEnrichmentsModel{}
 
 	if uploadResp != nil {
+		data := &CustomEnrichmentDataModel{
+			ID:          types.Int64PointerValue(uploadResp.Id),
+			Name:        types.StringPointerValue(uploadResp.Name),
+			Description: types.StringPointerValue(uploadResp.Description),
+			Version:     types.Int64PointerValue(uploadResp.Version),
+			Columns:     types.ListNull(types.StringType),
+		}
+		// The contents aren't returned by the API, they are kept from the
+		// plan or the state along with the summary of source_file.
+		if source != nil {
+			data.Contents = source.Contents
+			data.SourceFile = source.SourceFile
+			data.SourceSha256 = source.SourceSha256
+			data.SourceSizeBytes = source.SourceSizeBytes
+			data.SourceRows = source.SourceRows
+			data.Columns = source.Columns
+			data.KeyColumn = source.KeyColumn
+		}
 		model.Custom = &CustomEnrichmentFieldsModel{
-			CustomEnrichmentDataModel: &CustomEnrichmentDataModel{
-				ID:          types.Int64PointerValue(uploadResp.Id),
-				Name:        types.StringPointerValue(uploadResp.Name),
-				Description: types.StringPointerValue(uploadResp.Description),
-				Version:     types.Int64PointerValue(uploadResp.Version),
-				Contents:    types.StringPointerValue(customEnrichmentContents),
-			},
-			Fields: []EnrichmentFieldModel{},
+			CustomEnrichmentDataModel: data,
+			Fields:                    []EnrichmentFieldModel{},
 		}
 		model.ID = types.StringValue(strconv.FormatInt(*uploadResp.Id, 10))
 	}
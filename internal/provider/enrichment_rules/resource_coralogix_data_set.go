@@ -62,6 +62,7 @@ func ResourceCoralogixDataSet() *schema.Resource {
 		},
 		Description:        "**Note:** Data Sets will be removed in version 5.0.0 of the Terraform Provider. Please use `coralogix_data_enrichments` instead.",
 		Schema:             DataSetSchema(),
+		CustomizeDiff:      customizeDataSetSourceFileDiff,
 		DeprecationMessage: "Data Sets will be removed in version 5.0.0 of the Terraform Provider. Please use `coralogix_data_enrichments` instead.",
 	}
 }
@@ -84,7 +85,7 @@ func DataSetSchema() map[string]*schema.Schema {
 		"file_content": {
 			Type:         schema.TypeString,
 			Optional:     true,
-			ExactlyOneOf: []string{"file_content", "uploaded_file"},
+			ExactlyOneOf: []string{"file_content", "uploaded_file", "source_file"},
 			ValidateFunc: fileContentNoLongerThan,
 		},
 		"uploaded_file": {
@@ -110,11 +111,59 @@ func DataSetSchema() map[string]*schema.Schema {
 				},
 			},
 			Optional:     true,
-			ExactlyOneOf: []string{"file_content", "uploaded_file"},
+			ExactlyOneOf: []string{"file_content", "uploaded_file", "source_file"},
+		},
+		"source_file": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"file_content", "uploaded_file", "source_file"},
+			Description: fmt.Sprintf("Path of a CSV file to upload, of at most %d characters. Only the SHA-256, size and row count of the file are kept in the state, "+
+				"and the file is uploaded again when its SHA-256 changes. The file is read whole into memory when it is uploaded.", fileContentLimit),
+		},
+		"source_sha256": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "SHA-256 of the source_file contents, in hex.",
+		},
+		"source_size_bytes": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Size of source_file in bytes.",
+		},
+		"source_rows": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of rows of source_file, without the header row.",
 		},
 	}
 }
 
+// customizeDataSetSourceFileDiff plans the summary of source_file, so a
+// change of the file contents shows as a change of source_sha256 and is
+// uploaded.
+func customizeDataSetSourceFileDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("source_file") {
+		return nil
+	}
+	summary := &enrichmentSourceFile{}
+	if path, ok := d.GetOk("source_file"); ok {
+		var err error
+		if summary, err = planEnrichmentSourceFile(path.(string)); err != nil {
+			return err
+		}
+	}
+	if d.Get("source_sha256").(string) == summary.sha256 {
+		return nil
+	}
+	if err := d.SetNew("source_sha256", summary.sha256); err != nil {
+		return err
+	}
+	if err := d.SetNew("source_size_bytes", int(summary.size)); err != nil {
+		return err
+	}
+	return d.SetNew("source_rows", int(summary.rows))
+}
+
 func fileContentNoLongerThan(i interface{}, k string) ([]string, []error) {
 	v, ok := i.(string)
 	if !ok {
@@ -301,6 +350,10 @@ func expandFileAndModificationTime(d *schema.ResourceData) (*cxsdk.File, string,
 }
 
 func expandFileContent(d *schema.ResourceData) (fileContent string, modificationTime string, err error) {
+	if sourceFile, ok := d.GetOk("source_file"); ok {
+		fileContent, err := readEnrichmentSourceFile(sourceFile.(string), d.Get("source_sha256").(string))
+		return fileContent, "", err
+	}
 	if fileContent, ok := d.GetOk("file_content"); !ok {
 		uploadedFile := d.Get("uploaded_file").([]interface{})[0].(map[string]interface{})
 		content := make([]string, 0)
@@ -0,0 +1,306 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events2metrics
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// e2mCardinalityMaxPermutations bounds the permutations listed when there is
+// no permutations_limit to compare with.
+const e2mCardinalityMaxPermutations = 10000
+
+var (
+	_ datasource.DataSourceWithConfigure      = &Events2MetricCardinalityDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &Events2MetricCardinalityDataSource{}
+)
+
+func NewEvents2MetricCardinalityDataSource() datasource.DataSource {
+	return &Events2MetricCardinalityDataSource{}
+}
+
+type Events2MetricCardinalityDataSource struct {
+	client *clientset.DataprimeClient
+}
+
+type Events2MetricCardinalityDataSourceModel struct {
+	MetricLabels          types.Map        `tfsdk:"metric_labels"` // map[string]string
+	LogsQuery             *LogsQueryModel  `tfsdk:"logs_query"`
+	SpansQuery            *SpansQueryModel `tfsdk:"spans_query"`
+	PermutationsLimit     types.Int64      `tfsdk:"permutations_limit"`
+	Samples               types.List       `tfsdk:"samples"` // []types.String
+	Lookback              types.String     `tfsdk:"lookback"`
+	EstimatedPermutations types.Int64      `tfsdk:"estimated_permutations"`
+	LabelCardinalities    types.Map        `tfsdk:"label_cardinalities"` // map[string]int64
+	LowerBound            types.Bool       `tfsdk:"lower_bound"`
+	ExceedsLimit          types.Bool       `tfsdk:"exceeds_limit"`
+}
+
+func (d *Events2MetricCardinalityDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_events2metric_cardinality"
+}
+
+func (d *Events2MetricCardinalityDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientSet.Dataprime()
+}
+
+func (d *Events2MetricCardinalityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	stringSet := func(description string) schema.SetAttribute {
+		return schema.SetAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: description + " Supports the `filter:startsWith:xxx`, `filter:endsWith:xxx` and `filter:contains:xxx` patterns.",
+		}
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Estimates the number of label permutations a `coralogix_events2metric` produces, so a metric that would exceed its `permutations.limit` is caught while planning instead of after logs flow. " +
+			"The permutations are counted in `samples` when they are set, and otherwise in the events of the last `lookback` matched by `logs_query` or `spans_query`, queried with DataPrime. " +
+			"A warning is reported when the estimate exceeds `permutations_limit`.",
+		Attributes: map[string]schema.Attribute{
+			"metric_labels": schema.MapAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels of the events2metric, mapping target labels to source fields, e.g. `coralogix_events2metric.example.metric_labels`.",
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+			"logs_query": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"lucene": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Lucene query of the logs.",
+					},
+					"applications": stringSet("Application names of the logs."),
+					"subsystems":   stringSet("Subsystem names of the logs."),
+					"severities": schema.SetAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(stringvalidator.OneOf(validSeverities...)),
+						},
+						MarkdownDescription: fmt.Sprintf("Severities of the logs. Can be one of %q.", validSeverities),
+					},
+				},
+				MarkdownDescription: "Logs query of the events2metric, e.g. `coralogix_events2metric.example.logs_query`. Conflicts with `spans_query`.",
+			},
+			"spans_query": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"lucene": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Lucene query of the spans.",
+					},
+					"applications": stringSet("Application names of the spans."),
+					"subsystems":   stringSet("Subsystem names of the spans."),
+					"actions":      stringSet("Operation names of the spans."),
+					"services":     stringSet("Service names of the spans."),
+				},
+				MarkdownDescription: "Spans query of the events2metric, e.g. `coralogix_events2metric.example.spans_query`. Conflicts with `logs_query`.",
+			},
+			"permutations_limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Permutations limit to compare the estimate with, e.g. `coralogix_events2metric.example.permutations.limit`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"samples": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "JSON objects of sample events, e.g. from `file()` and `split()`. When set, the permutations are counted in the samples, without calling Coralogix, " +
+					"and the samples are expected to match the query, which isn't evaluated.",
+			},
+			"lookback": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Duration of the events queried when `samples` aren't set, e.g. `6h`. Defaults to `24h`.",
+			},
+			"estimated_permutations": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of distinct combinations of the label values.",
+			},
+			"label_cardinalities": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "Number of distinct values of every target label.",
+			},
+			"lower_bound": schema.BoolAttribute{
+				Computed: true,
+				MarkdownDescription: fmt.Sprintf("Whether counting stopped early, one permutation past `permutations_limit` or at %d permutations without a limit. "+
+					"The estimates are then lower bounds.", e2mCardinalityMaxPermutations),
+			},
+			"exceeds_limit": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether `estimated_permutations` exceeds `permutations_limit`. False without a limit.",
+			},
+		},
+	}
+}
+
+func (d *Events2MetricCardinalityDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var logsQuery, spansQuery types.Object
+	var samples types.List
+	var lookback types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("logs_query"), &logsQuery)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("spans_query"), &spansQuery)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("samples"), &samples)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("lookback"), &lookback)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !logsQuery.IsNull() && !spansQuery.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("spans_query"), "Conflicting queries", "Only one of logs_query and spans_query can be set.")
+	}
+	if samples.IsNull() && logsQuery.IsNull() && spansQuery.IsNull() {
+		resp.Diagnostics.AddError("Missing events", "One of samples, logs_query and spans_query must be set.")
+	}
+	if !lookback.IsNull() && !lookback.IsUnknown() {
+		if duration, err := time.ParseDuration(lookback.ValueString()); err != nil || duration <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("lookback"), "Invalid lookback", fmt.Sprintf("lookback must be a positive duration such as 6h, got %q", lookback.ValueString()))
+		}
+	}
+}
+
+func (d *Events2MetricCardinalityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *Events2MetricCardinalityDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var labels map[string]string
+	resp.Diagnostics.Append(data.MetricLabels.ElementsAs(ctx, &labels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	limit := e2mCardinalityMaxPermutations
+	if !data.PermutationsLimit.IsNull() {
+		limit = int(data.PermutationsLimit.ValueInt64()) + 1
+	}
+
+	var source e2mPermutationsSource
+	if !data.Samples.IsNull() {
+		var samples []string
+		resp.Diagnostics.Append(data.Samples.ElementsAs(ctx, &samples, false)...)
+		events := make([]map[string]any, 0, len(samples))
+		for i, sample := range samples {
+			var event map[string]any
+			if err := json.Unmarshal([]byte(sample), &event); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("samples").AtListIndex(i), "Invalid sample", fmt.Sprintf("samples must be JSON objects: %s", err))
+				continue
+			}
+			events = append(events, event)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		source = e2mSamplePermutations{events: events}
+	} else {
+		lookback := 24 * time.Hour
+		if !data.Lookback.IsNull() {
+			lookback, _ = time.ParseDuration(data.Lookback.ValueString())
+		}
+		source = e2mDataprimePermutations{client: d.client, lookback: lookback}
+	}
+
+	estimate, err := estimateE2MCardinality(ctx, source, expandE2MCardinalityQuery(ctx, data), labels, limit)
+	if err != nil {
+		resp.Diagnostics.AddError("Error estimating the events2metric cardinality", err.Error())
+		return
+	}
+
+	cardinalities := make(map[string]int64, len(estimate.labelValues))
+	for label, values := range estimate.labelValues {
+		cardinalities[label] = int64(values)
+	}
+	var diags diag.Diagnostics
+	data.LabelCardinalities, diags = types.MapValueFrom(ctx, types.Int64Type, cardinalities)
+	resp.Diagnostics.Append(diags...)
+	data.EstimatedPermutations = types.Int64Value(int64(estimate.permutations))
+	data.LowerBound = types.BoolValue(estimate.lowerBound)
+	data.ExceedsLimit = types.BoolValue(!data.PermutationsLimit.IsNull() && int64(estimate.permutations) > data.PermutationsLimit.ValueInt64())
+
+	if data.ExceedsLimit.ValueBool() {
+		labelsByValues := slices.SortedFunc(maps.Keys(estimate.labelValues), func(a, b string) int {
+			return cmp.Or(estimate.labelValues[b]-estimate.labelValues[a], strings.Compare(a, b))
+		})
+		top := make([]string, 0, 3)
+		for _, label := range labelsByValues[:min(3, len(labelsByValues))] {
+			top = append(top, fmt.Sprintf("%s (%d values)", label, estimate.labelValues[label]))
+		}
+		resp.Diagnostics.AddWarning("Events2Metric permutations limit exceeded",
+			fmt.Sprintf("The labels have at least %d permutations, more than the permutations limit of %d. The labels with the most values are %s.",
+				estimate.permutations, data.PermutationsLimit.ValueInt64(), strings.Join(top, ", ")))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func expandE2MCardinalityQuery(ctx context.Context, data *Events2MetricCardinalityDataSourceModel) e2mCardinalityQuery {
+	switch {
+	case data.LogsQuery != nil:
+		return e2mCardinalityQuery{
+			lucene:       data.LogsQuery.Lucene.ValueString(),
+			applications: utils.TypeStringSetToStringSlice(ctx, data.LogsQuery.Applications),
+			subsystems:   utils.TypeStringSetToStringSlice(ctx, data.LogsQuery.Subsystems),
+			severities:   utils.TypeStringSetToStringSlice(ctx, data.LogsQuery.Severities),
+		}
+	case data.SpansQuery != nil:
+		return e2mCardinalityQuery{
+			spans:        true,
+			lucene:       data.SpansQuery.Lucene.ValueString(),
+			applications: utils.TypeStringSetToStringSlice(ctx, data.SpansQuery.Applications),
+			subsystems:   utils.TypeStringSetToStringSlice(ctx, data.SpansQuery.Subsystems),
+			actions:      utils.TypeStringSetToStringSlice(ctx, data.SpansQuery.Actions),
+			services:     utils.TypeStringSetToStringSlice(ctx, data.SpansQuery.Services),
+		}
+	}
+	return e2mCardinalityQuery{}
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events2metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+)
+
+// e2mCardinalityQuery selects the events an events2metric converts.
+type e2mCardinalityQuery struct {
+	spans        bool
+	lucene       string
+	applications []string
+	subsystems   []string
+	severities   []string
+	actions      []string
+	services     []string
+}
+
+// e2mPermutationsSource lists the distinct combinations of the values of
+// fields in the events matched by query, stopping after limit combinations.
+type e2mPermutationsSource interface {
+	Permutations(ctx context.Context, query e2mCardinalityQuery, fields []string, limit int) ([][]string, error)
+}
+
+type e2mCardinalityEstimate struct {
+	permutations int
+	// labelValues counts the distinct values of every target label.
+	labelValues map[string]int
+	// lowerBound is set when the source stopped at the limit, so there may
+	// be more permutations and label values.
+	lowerBound bool
+}
+
+// estimateE2MCardinality counts the permutations of labels, which map target
+// labels to source fields, in the events of source.
+func estimateE2MCardinality(ctx context.Context, source e2mPermutationsSource, query e2mCardinalityQuery, labels map[string]string, limit int) (*e2mCardinalityEstimate, error) {
+	targets := slices.Sorted(maps.Keys(labels))
+	fields := make([]string, len(targets))
+	for i, target := range targets {
+		fields[i] = labels[target]
+	}
+
+	permutations, err := source.Permutations(ctx, query, fields, limit)
+	if err != nil {
+		return nil, err
+	}
+	estimate := &e2mCardinalityEstimate{
+		permutations: len(permutations),
+		labelValues:  make(map[string]int, len(targets)),
+		lowerBound:   len(permutations) >= limit,
+	}
+	for i, target := range targets {
+		values := map[string]bool{}
+		for _, permutation := range permutations {
+			values[permutation[i]] = true
+		}
+		estimate.labelValues[target] = len(values)
+	}
+	return estimate, nil
+}
+
+// e2mSamplePermutations reads the permutations of sample events. The samples
+// are expected to match the query, which isn't evaluated.
+type e2mSamplePermutations struct {
+	events []map[string]any
+}
+
+func (s e2mSamplePermutations) Permutations(_ context.Context, _ e2mCardinalityQuery, fields []string, limit int) ([][]string, error) {
+	seen := map[string]bool{}
+	var permutations [][]string
+	for _, event := range s.events {
+		if len(permutations) == limit {
+			break
+		}
+		values := make([]string, len(fields))
+		for i, field := range fields {
+			value, _ := e2mEventField(event, field)
+			values[i] = e2mLabelValue(value)
+		}
+		key, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[string(key)] {
+			seen[string(key)] = true
+			permutations = append(permutations, values)
+		}
+	}
+	return permutations, nil
+}
+
+// e2mEventField looks up a source field such as kubernetes.pod_name in an
+// event, trying keys containing dots before nested objects.
+func e2mEventField(event map[string]any, field string) (any, bool) {
+	if value, ok := event[field]; ok {
+		return value, true
+	}
+	for i := range len(field) {
+		if field[i] != '.' {
+			continue
+		}
+		if nested, ok := event[field[:i]].(map[string]any); ok {
+			if value, ok := e2mEventField(nested, field[i+1:]); ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// e2mLabelValue formats a field value as a metric label value. Missing
+// fields have an empty value.
+func e2mLabelValue(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+}
+
+// e2mDataprimePermutations queries the permutations of the events of the
+// last lookback with DataPrime.
+type e2mDataprimePermutations struct {
+	client   *clientset.DataprimeClient
+	lookback time.Duration
+}
+
+func (s e2mDataprimePermutations) Permutations(ctx context.Context, query e2mCardinalityQuery, fields []string, limit int) ([][]string, error) {
+	end := time.Now()
+	results, err := s.client.Query(ctx, e2mDataprimeQuery(query, fields, limit), end.Add(-s.lookback), end)
+	if err != nil {
+		return nil, err
+	}
+	permutations := make([][]string, 0, len(results))
+	for _, result := range results {
+		values := make([]string, len(fields))
+		for i := range fields {
+			values[i] = e2mLabelValue(result[fmt.Sprintf("l%d", i)])
+		}
+		permutations = append(permutations, values)
+	}
+	return permutations, nil
+}
+
+// e2mDataprimeQuery translates the query of an events2metric to a DataPrime
+// query listing the distinct values of fields, aliased l0, l1 and so on.
+func e2mDataprimeQuery(query e2mCardinalityQuery, fields []string, limit int) string {
+	source := "logs"
+	if query.spans {
+		source = "spans"
+	}
+	stages := []string{"source " + source}
+	if query.lucene != "" {
+		stages = append(stages, "lucene "+dataprimeString(query.lucene))
+	}
+
+	var filters []string
+	addFilter := func(keypath string, values []string, filter func(keypath, value string) string) {
+		if len(values) == 0 {
+			return
+		}
+		alternatives := make([]string, len(values))
+		for i, value := range values {
+			alternatives[i] = filter(keypath, value)
+		}
+		filters = append(filters, "("+strings.Join(alternatives, " || ")+")")
+	}
+	addFilter("$l.applicationname", query.applications, dataprimeLabelFilter)
+	addFilter("$l.subsystemname", query.subsystems, dataprimeLabelFilter)
+	addFilter("$m.severity", query.severities, func(keypath, value string) string {
+		return keypath + " == " + strings.ToUpper(value)
+	})
+	addFilter("$l.operationname", query.actions, dataprimeLabelFilter)
+	addFilter("$l.servicename", query.services, dataprimeLabelFilter)
+	if len(filters) > 0 {
+		stages = append(stages, "filter "+strings.Join(filters, " && "))
+	}
+
+	labels := make([]string, len(fields))
+	for i, field := range fields {
+		labels[i] = fmt.Sprintf("%s as l%d", dataprimeKeypath(field), i)
+	}
+	stages = append(stages, "distinct "+strings.Join(labels, ", "), fmt.Sprintf("limit %d", limit))
+	return strings.Join(stages, " | ")
+}
+
+// dataprimeLabelFilter matches an application, subsystem, action or service
+// name, supporting the filter:startsWith:, filter:endsWith: and
+// filter:contains: patterns of events2metric queries.
+func dataprimeLabelFilter(keypath, value string) string {
+	for pattern, function := range map[string]string{"filter:startsWith:": "startsWith", "filter:endsWith:": "endsWith", "filter:contains:": "contains"} {
+		if argument, ok := strings.CutPrefix(value, pattern); ok {
+			return fmt.Sprintf("%s.%s(%s)", keypath, function, dataprimeString(argument))
+		}
+	}
+	return keypath + " == " + dataprimeString(value)
+}
+
+var dataprimeIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// dataprimeKeypath returns the keypath of a source field in the user data,
+// quoting the segments that aren't identifiers.
+func dataprimeKeypath(field string) string {
+	keypath := "$d"
+	for _, segment := range strings.Split(field, ".") {
+		if dataprimeIdentifier.MatchString(segment) {
+			keypath += "." + segment
+		} else {
+			keypath += "[" + dataprimeString(segment) + "]"
+		}
+	}
+	return keypath
+}
+
+func dataprimeString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events2metrics
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestEstimateE2MCardinalityFromSamples(t *testing.T) {
+	source := e2mSamplePermutations{events: []map[string]any{
+		{"kubernetes": map[string]any{"pod": "api-1"}, "status": 200.0},
+		{"kubernetes": map[string]any{"pod": "api-1"}, "status": 200.0},
+		{"kubernetes": map[string]any{"pod": "api-2"}, "status": 500.0},
+		{"kubernetes.pod": "api-3", "status": 200.0},
+		{"status": 404.0},
+	}}
+	labels := map[string]string{"pod": "kubernetes.pod", "code": "status"}
+
+	estimate, err := estimateE2MCardinality(context.Background(), source, e2mCardinalityQuery{}, labels, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.permutations != 4 || estimate.lowerBound {
+		t.Errorf("permutations = %d, lowerBound = %v, want 4, false", estimate.permutations, estimate.lowerBound)
+	}
+	if want := map[string]int{"pod": 4, "code": 3}; !reflect.DeepEqual(estimate.labelValues, want) {
+		t.Errorf("labelValues = %v, want %v", estimate.labelValues, want)
+	}
+
+	estimate, err = estimateE2MCardinality(context.Background(), source, e2mCardinalityQuery{}, labels, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.permutations != 3 || !estimate.lowerBound {
+		t.Errorf("permutations = %d, lowerBound = %v, want 3, true", estimate.permutations, estimate.lowerBound)
+	}
+}
+
+func TestE2MDataprimeQuery(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		query  e2mCardinalityQuery
+		fields []string
+		want   string
+	}{
+		{
+			name: "logs",
+			query: e2mCardinalityQuery{
+				lucene:       `status:500 AND path:"/it's"`,
+				applications: []string{"api", "filter:startsWith:web-"},
+				severities:   []string{"Error", "Critical"},
+			},
+			fields: []string{"kubernetes.pod_name", "http.status-code"},
+			want: `source logs | lucene 'status:500 AND path:"/it\'s"'` +
+				` | filter ($l.applicationname == 'api' || $l.applicationname.startsWith('web-')) && ($m.severity == ERROR || $m.severity == CRITICAL)` +
+				` | distinct $d.kubernetes.pod_name as l0, $d.http['status-code'] as l1 | limit 101`,
+		},
+		{
+			name:   "spans",
+			query:  e2mCardinalityQuery{spans: true, services: []string{"filter:contains:checkout"}, actions: []string{"GET /cart"}},
+			fields: []string{"tags.region"},
+			want:   `source spans | filter ($l.operationname == 'GET /cart') && ($l.servicename.contains('checkout')) | distinct $d.tags.region as l0 | limit 101`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := e2mDataprimeQuery(tc.query, tc.fields, 101); got != tc.want {
+				t.Errorf("e2mDataprimeQuery() =\n%s\nwant\n%s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,608 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events2metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	cxsdkOpenapi "github.com/coralogix/coralogix-management-sdk/go/openapi/cxsdk"
+	e2ms "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/events2metrics_service"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ resource.ResourceWithConfigure  = &Events2MetricsSetResource{}
+	_ resource.ResourceWithModifyPlan = &Events2MetricsSetResource{}
+)
+
+type Events2MetricsSetResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	YamlContent    types.String `tfsdk:"yaml_content"`
+	Events2Metrics types.Map    `tfsdk:"events2metrics"` // map[string]types.String
+}
+
+func NewEvents2MetricsSetResource() resource.Resource {
+	return &Events2MetricsSetResource{}
+}
+
+type Events2MetricsSetResource struct {
+	client *e2ms.Events2MetricsServiceAPIService
+}
+
+func (r *Events2MetricsSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_events2metrics_set"
+}
+
+func (r *Events2MetricsSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientSet.Events2Metrics()
+}
+
+func (r *Events2MetricsSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Events2Metrics set ID.",
+			},
+			"yaml_content": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					events2MetricsSetYamlContentValidator{},
+				},
+				MarkdownDescription: "YAML or JSON document mapping a stable key to an events2metric definition. " +
+					"Each definition uses the attributes of `coralogix_events2metric` (except `id`), such as `metric_fields` with their `aggregations`, `metric_labels` and `logs_query` or `spans_query`. " +
+					"`name` defaults to the key. Events2Metrics are created, replaced and deleted by key, so renaming a key replaces that events2metric. " +
+					"Events2Metrics changed outside of Terraform are compared on the attributes their definition sets, and show as a change of `yaml_content` that replaces them.",
+			},
+			"events2metrics": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The ID of each managed events2metric, by its key in `yaml_content`.",
+			},
+		},
+		MarkdownDescription: "Manages a whole set of Coralogix Events2Metrics from a single YAML or JSON document, such as a generated metrics catalog. " +
+			"For more info please review - https://coralogix.com/docs/user-guides/monitoring-and-insights/events2metrics/.",
+	}
+}
+
+// ModifyPlan keeps the IDs of events2metrics whose key is still in the
+// document, so the plan only shows the keys that are added or removed.
+func (r *Events2MetricsSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan Events2MetricsSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.YamlContent.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("events2metrics"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	definitions, diags := parseEvents2MetricsSetDocument(plan.YamlContent.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids := map[string]string{}
+	if !req.State.Raw.IsNull() {
+		var state Events2MetricsSetResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ids, diags = utils.TypeMapToStringMap(ctx, state.Events2Metrics)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	e2mIDs, diags := plannedEvents2MetricsSetIDs(definitions, ids)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("events2metrics"), e2mIDs)...)
+}
+
+func (r *Events2MetricsSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *Events2MetricsSetResourceModel
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	definitions, diags := parseEvents2MetricsSetDocument(plan.YamlContent.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, diags := reconcileEvents2MetricsSet(ctx, r.client, nil, definitions, map[string]string{})
+	resp.Diagnostics.Append(diags...)
+
+	plan.ID = types.StringValue(uuid.NewString())
+	e2mIDs, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	plan.Events2Metrics = e2mIDs
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *Events2MetricsSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *Events2MetricsSetResourceModel
+	if diags := req.State.Get(ctx, &state); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, diags := utils.TypeMapToStringMap(ctx, state.Events2Metrics)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, remote, diags := refreshEvents2MetricsSetIDs(ctx, r.client, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	yamlContent, diags := refreshEvents2MetricsSetDocument(ctx, state.YamlContent.ValueString(), remote)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	e2mIDs, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	state.YamlContent = types.StringValue(yamlContent)
+	state.Events2Metrics = e2mIDs
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *Events2MetricsSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *Events2MetricsSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definitions, diags := parseEvents2MetricsSetDocument(plan.YamlContent.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	priorDefinitions, diags := parseEvents2MetricsSetDocument(state.YamlContent.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	ids, diags := utils.TypeMapToStringMap(ctx, state.Events2Metrics)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ids, diags = reconcileEvents2MetricsSet(ctx, r.client, priorDefinitions, definitions, ids)
+	resp.Diagnostics.Append(diags...)
+
+	e2mIDs, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	plan.Events2Metrics = e2mIDs
+	if resp.Diagnostics.HasError() {
+		// Keep the prior document so the next apply compares against what
+		// was actually applied, while still tracking the events2metrics that exist.
+		plan.YamlContent = state.YamlContent
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *Events2MetricsSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state Events2MetricsSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, diags := utils.TypeMapToStringMap(ctx, state.Events2Metrics)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	for _, key := range utils.GetKeys(ids) {
+		resp.Diagnostics.Append(deleteEvents2MetricsSetE2M(ctx, r.client, key, ids[key])...)
+	}
+}
+
+// refreshEvents2MetricsSetIDs drops the IDs of events2metrics that no longer
+// exist in Coralogix, with a warning, so the next apply recreates them. It also
+// returns the events2metrics that exist, by key.
+func refreshEvents2MetricsSetIDs(ctx context.Context, client *e2ms.Events2MetricsServiceAPIService, ids map[string]string) (map[string]string, map[string]e2ms.E2M, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	result := make(map[string]string, len(ids))
+	remote := make(map[string]e2ms.E2M, len(ids))
+	for _, key := range utils.GetKeys(ids) {
+		id := ids[key]
+		getResp, httpResponse, err := client.Events2MetricServiceGetE2M(ctx, id).Execute()
+		if err == nil {
+			result[key] = id
+			if getResp != nil {
+				remote[key] = getResp.E2m
+			}
+			continue
+		}
+		if responseStatus(httpResponse) == http.StatusNotFound {
+			diags.AddWarning(
+				fmt.Sprintf("coralogix_events2metrics_set events2metric %q (%s) is in state, but no longer exists in Coralogix backend", key, id),
+				fmt.Sprintf("%s will be recreated when you apply", key),
+			)
+			continue
+		}
+		diags.AddError("Error reading coralogix_events2metrics_set",
+			utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Read", id),
+		)
+		return nil, nil, diags
+	}
+	return result, remote, diags
+}
+
+// refreshEvents2MetricsSetDocument compares each events2metric with its
+// definition in the document, the same way coralogix_events2metric compares it
+// with its configuration. The attributes a definition leaves out are not
+// compared. The definitions of events2metrics changed outside of Terraform are
+// replaced by the remote values, so the next plan shows the change and the
+// next apply replaces those events2metrics.
+func refreshEvents2MetricsSetDocument(ctx context.Context, content string, remote map[string]e2ms.E2M) (string, diag.Diagnostics) {
+	definitions, diags := parseEvents2MetricsSetDocument(content)
+	if diags.HasError() {
+		return content, diags
+	}
+
+	var schemaResp resource.SchemaResponse
+	(&Events2MetricResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	drifted := map[string]json.RawMessage{}
+	for _, key := range slices.Sorted(maps.Keys(remote)) {
+		definition, ok := definitions[key]
+		if !ok {
+			continue
+		}
+		e2m := remote[key]
+		model, dg := flattenE2M(ctx, &e2m)
+		if dg.HasError() {
+			diags.Append(events2MetricsSetKeyDiagnostics(key, dg)...)
+			continue
+		}
+		remoteDefinition, changed, dg := utils.DriftJSONWithSchema(ctx, schemaResp.Schema, definition, model)
+		if dg.HasError() {
+			diags.Append(events2MetricsSetKeyDiagnostics(key, dg)...)
+			continue
+		}
+		if changed {
+			drifted[key] = remoteDefinition
+		}
+	}
+	if diags.HasError() || len(drifted) == 0 {
+		return content, diags
+	}
+
+	document, dg := replaceEvents2MetricsSetDefinitions(content, drifted)
+	diags.Append(dg...)
+	return document, diags
+}
+
+// replaceEvents2MetricsSetDefinitions re-encodes a yaml_content document as
+// YAML, with the given definitions replaced.
+func replaceEvents2MetricsSetDefinitions(content string, definitions map[string]json.RawMessage) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var document map[string]any
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		diags.AddError("Error on unmarshal yaml_content", err.Error())
+		return content, diags
+	}
+	for key, definition := range definitions {
+		var decoded any
+		if err := json.Unmarshal(definition, &decoded); err != nil {
+			diags.AddError("Error on unmarshal yaml_content", err.Error())
+			return content, diags
+		}
+		document[key] = decoded
+	}
+	encoded, err := yaml.Marshal(document)
+	if err != nil {
+		diags.AddError("Error on marshal yaml_content", err.Error())
+		return content, diags
+	}
+	return string(encoded), diags
+}
+
+func deleteEvents2MetricsSetE2M(ctx context.Context, client *e2ms.Events2MetricsServiceAPIService, key, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	_, httpResponse, err := client.Events2MetricServiceDeleteE2M(ctx, id).Execute()
+	if err != nil && responseStatus(httpResponse) != http.StatusNotFound {
+		diags.AddError(fmt.Sprintf("Error deleting events2metric %q of coralogix_events2metrics_set", key),
+			utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Delete", id),
+		)
+	}
+	return diags
+}
+
+// reconcileEvents2MetricsSet creates the events2metrics that have no ID yet,
+// replaces the ones whose definition changed since the prior document and
+// deletes the ones whose key was removed. It returns the IDs of every
+// events2metric that exists afterwards, including when some of the calls
+// failed.
+func reconcileEvents2MetricsSet(ctx context.Context, client *e2ms.Events2MetricsServiceAPIService, prior, definitions map[string]json.RawMessage, ids map[string]string) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	result := make(map[string]string, len(definitions))
+
+	for _, key := range utils.GetKeys(ids) {
+		if _, ok := definitions[key]; ok {
+			continue
+		}
+		if d := deleteEvents2MetricsSetE2M(ctx, client, key, ids[key]); d.HasError() {
+			diags.Append(d...)
+			result[key] = ids[key]
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(definitions)) {
+		id, exists := ids[key]
+		if exists && events2MetricsSetDefinitionsEqual(prior[key], definitions[key]) {
+			result[key] = id
+			continue
+		}
+		if exists {
+			result[key] = id
+		}
+
+		model, dg := decodeEvents2MetricsSetDefinition(ctx, key, definitions[key])
+		if dg.HasError() {
+			diags.Append(dg...)
+			continue
+		}
+
+		if exists {
+			model.ID = types.StringValue(id)
+			e2m, dg := extractUpdateE2M(ctx, *model)
+			if dg.HasError() {
+				diags.Append(events2MetricsSetKeyDiagnostics(key, dg)...)
+				continue
+			}
+			_, httpResponse, err := client.Events2MetricServiceReplaceE2M(ctx).E2M1(e2m).Execute()
+			if err != nil {
+				diags.AddError(fmt.Sprintf("Error replacing events2metric %q of coralogix_events2metrics_set", key),
+					utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Replace", e2m),
+				)
+			}
+			continue
+		}
+
+		params, dg := extractCreateE2M(ctx, *model)
+		if dg.HasError() {
+			diags.Append(events2MetricsSetKeyDiagnostics(key, dg)...)
+			continue
+		}
+		createResp, httpResponse, err := client.Events2MetricServiceCreateE2M(ctx).E2MCreateParams(params).Execute()
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Error creating events2metric %q of coralogix_events2metrics_set", key),
+				utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Create", params),
+			)
+			continue
+		}
+		if createResp == nil || createResp.E2m == nil {
+			diags.AddError(fmt.Sprintf("Error creating events2metric %q of coralogix_events2metrics_set", key),
+				"Create response did not include an Events2Metric")
+			continue
+		}
+		result[key] = createResp.E2m.GetId()
+	}
+
+	return result, diags
+}
+
+// parseEvents2MetricsSetDocument splits a yaml_content document into the JSON
+// encoding of each events2metric definition, by key. JSON is valid YAML, so
+// both are accepted. Definitions without a name are named after their key.
+func parseEvents2MetricsSetDocument(content string) (map[string]json.RawMessage, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var document map[string]any
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		diags.AddError("Error on unmarshal yaml_content", err.Error())
+		return nil, diags
+	}
+
+	definitions := make(map[string]json.RawMessage, len(document))
+	for key, definition := range document {
+		attributes, ok := definition.(map[string]any)
+		if !ok {
+			diags.AddError("Invalid yaml_content", fmt.Sprintf("events2metric %q must be a mapping of events2metric attributes", key))
+			continue
+		}
+		if _, ok := attributes["id"]; ok {
+			diags.AddError("Invalid yaml_content", fmt.Sprintf("events2metric %q sets \"id\", which is computed by Coralogix", key))
+			continue
+		}
+		if _, ok := attributes["name"]; !ok {
+			attributes["name"] = key
+		}
+		encoded, err := json.Marshal(attributes)
+		if err != nil {
+			diags.AddError("Invalid yaml_content", fmt.Sprintf("events2metric %q: %s", key, err))
+			continue
+		}
+		definitions[key] = encoded
+	}
+
+	return definitions, diags
+}
+
+// decodeEvents2MetricsSetDefinition decodes one definition against the
+// coralogix_events2metric schema, and checks what the resource checks with its
+// schema validators.
+func decodeEvents2MetricsSetDefinition(ctx context.Context, key string, definition json.RawMessage) (*Events2MetricResourceModel, diag.Diagnostics) {
+	var schemaResp resource.SchemaResponse
+	(&Events2MetricResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	var model Events2MetricResourceModel
+	if diags := utils.DecodeJSONWithSchema(ctx, schemaResp.Schema, definition, &model); diags.HasError() {
+		return nil, events2MetricsSetKeyDiagnostics(key, diags)
+	}
+
+	var diags diag.Diagnostics
+	if (model.LogsQuery == nil) == (model.SpansQuery == nil) {
+		diags.AddError(fmt.Sprintf("events2metric %q: Invalid definition", key), "Exactly one of \"logs_query\" or \"spans_query\" must be defined.")
+	}
+	if !events2MetricNameRegex.MatchString(model.Name.ValueString()) {
+		diags.AddError(fmt.Sprintf("events2metric %q: Invalid definition", key), "Invalid metric name, name may only contain ASCII letters and digits, as well as underscores and colons.")
+	}
+	var fields map[string]MetricFieldModel
+	diags.Append(model.MetricFields.ElementsAs(ctx, &fields, false)...)
+	for _, name := range utils.GetKeys(fields) {
+		aggregations := fields[name].Aggregations
+		if aggregations == nil {
+			continue
+		}
+		if samples := aggregations.Samples; samples != nil {
+			if _, ok := schemaToAPIAggregationSampleType[samples.Type.ValueString()]; !ok {
+				diags.AddError(fmt.Sprintf("events2metric %q: Invalid definition", key), fmt.Sprintf("metric_fields %q: samples type must be one of %q.", name, validSampleTypes))
+			}
+		}
+		if histogram := aggregations.Histogram; histogram != nil && histogram.Buckets.IsNull() {
+			diags.AddError(fmt.Sprintf("events2metric %q: Invalid definition", key), fmt.Sprintf("metric_fields %q: histogram requires \"buckets\".", name))
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+	return &model, nil
+}
+
+var events2MetricNameRegex = regexp.MustCompile(`^[A-Za-z\d_:-]+$`)
+
+func events2MetricsSetKeyDiagnostics(key string, diags diag.Diagnostics) diag.Diagnostics {
+	var keyed diag.Diagnostics
+	for _, d := range diags {
+		summary := fmt.Sprintf("events2metric %q: %s", key, d.Summary())
+		if d.Severity() == diag.SeverityError {
+			keyed.AddError(summary, d.Detail())
+		} else {
+			keyed.AddWarning(summary, d.Detail())
+		}
+	}
+	return keyed
+}
+
+func events2MetricsSetDefinitionsEqual(prior, current json.RawMessage) bool {
+	if prior == nil {
+		return false
+	}
+	var p, c any
+	if json.Unmarshal(prior, &p) != nil || json.Unmarshal(current, &c) != nil {
+		return false
+	}
+	return reflect.DeepEqual(p, c)
+}
+
+func plannedEvents2MetricsSetIDs(definitions map[string]json.RawMessage, ids map[string]string) (types.Map, diag.Diagnostics) {
+	keys := slices.Sorted(maps.Keys(definitions))
+	elements := make(map[string]attr.Value, len(keys))
+	for _, key := range keys {
+		if id, ok := ids[key]; ok {
+			elements[key] = types.StringValue(id)
+		} else {
+			elements[key] = types.StringUnknown()
+		}
+	}
+	return types.MapValue(types.StringType, elements)
+}
+
+type events2MetricsSetYamlContentValidator struct{}
+
+func (v events2MetricsSetYamlContentValidator) Description(_ context.Context) string {
+	return "validate yaml_content"
+}
+
+func (v events2MetricsSetYamlContentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v events2MetricsSetYamlContentValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	definitions, diags := parseEvents2MetricsSetDocument(req.ConfigValue.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	for _, key := range slices.Sorted(maps.Keys(definitions)) {
+		model, diags := decodeEvents2MetricsSetDefinition(ctx, key, definitions[key])
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			continue
+		}
+		if _, diags := extractCreateE2M(ctx, *model); diags.HasError() {
+			resp.Diagnostics.Append(events2MetricsSetKeyDiagnostics(key, diags)...)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events2metrics
+
+import (
+	"context"
+	"testing"
+
+	e2ms "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/events2metrics_service"
+)
+
+const events2MetricsSetTestDocument = `
+request_latency:
+  description: Request latency from nginx logs
+  logs_query:
+    lucene: "path:/api/*"
+    applications: ["nginx"]
+    severities: ["Info"]
+  metric_fields:
+    latency:
+      source_field: request_time
+      aggregations:
+        avg:
+          enable: true
+        histogram:
+          enable: true
+          buckets: [0.1, 0.5, 1, 5]
+  metric_labels:
+    status: status
+checkout_spans:
+  name: checkout_spans_total
+  spans_query:
+    services: ["checkout"]
+  metric_fields:
+    duration:
+      source_field: duration
+      aggregations:
+        count:
+          enable: true
+`
+
+func TestParseEvents2MetricsSetDocument(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		definitions, diags := parseEvents2MetricsSetDocument(events2MetricsSetTestDocument)
+		if diags.HasError() {
+			t.Fatalf("parseEvents2MetricsSetDocument returned diagnostics: %v", diags)
+		}
+		if len(definitions) != 2 {
+			t.Fatalf("parseEvents2MetricsSetDocument returned %d definitions, want 2", len(definitions))
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		definitions, diags := parseEvents2MetricsSetDocument(`{"a": {"logs_query": {}, "metric_fields": {"f": {"source_field": "f"}}}}`)
+		if diags.HasError() {
+			t.Fatalf("parseEvents2MetricsSetDocument returned diagnostics: %v", diags)
+		}
+		if len(definitions) != 1 {
+			t.Fatalf("parseEvents2MetricsSetDocument returned %d definitions, want 1", len(definitions))
+		}
+	})
+
+	t.Run("id is rejected", func(t *testing.T) {
+		_, diags := parseEvents2MetricsSetDocument("a:\n  id: abc\n")
+		if !diags.HasError() {
+			t.Fatal("expected an error for a definition that sets id")
+		}
+	})
+
+	t.Run("definition must be a mapping", func(t *testing.T) {
+		_, diags := parseEvents2MetricsSetDocument("a: not-an-events2metric\n")
+		if !diags.HasError() {
+			t.Fatal("expected an error for a scalar definition")
+		}
+	})
+}
+
+func TestDecodeEvents2MetricsSetDefinition(t *testing.T) {
+	ctx := context.Background()
+	definitions, diags := parseEvents2MetricsSetDocument(events2MetricsSetTestDocument)
+	if diags.HasError() {
+		t.Fatalf("parseEvents2MetricsSetDocument returned diagnostics: %v", diags)
+	}
+
+	model, diags := decodeEvents2MetricsSetDefinition(ctx, "request_latency", definitions["request_latency"])
+	if diags.HasError() {
+		t.Fatalf("decodeEvents2MetricsSetDefinition returned diagnostics: %v", diags)
+	}
+	params, diags := extractCreateE2M(ctx, *model)
+	if diags.HasError() {
+		t.Fatalf("extractCreateE2M returned diagnostics: %v", diags)
+	}
+	if params.LogsQuery == nil || params.GetType() != e2ms.E2MTYPE_E2_M_TYPE_LOGS2_METRICS {
+		t.Fatal("the request_latency definition must create a logs events2metric")
+	}
+	if params.Name != "request_latency" {
+		t.Errorf("Name = %q, want the key", params.Name)
+	}
+	if len(params.MetricFields) != 1 || len(params.MetricFields[0].Aggregations) != 2 {
+		t.Fatalf("MetricFields = %v, want one field with two aggregations", params.MetricFields)
+	}
+	for _, aggregation := range params.MetricFields[0].Aggregations {
+		if aggregation.GetAggType() == e2ms.AGGTYPE_AGG_TYPE_HISTOGRAM && len(aggregation.Histogram.Buckets) != 4 {
+			t.Errorf("Buckets = %v, want 4 buckets", aggregation.Histogram.Buckets)
+		}
+	}
+
+	model, diags = decodeEvents2MetricsSetDefinition(ctx, "checkout_spans", definitions["checkout_spans"])
+	if diags.HasError() {
+		t.Fatalf("decodeEvents2MetricsSetDefinition returned diagnostics: %v", diags)
+	}
+	if model.Name.ValueString() != "checkout_spans_total" || model.SpansQuery == nil {
+		t.Errorf("Name = %s, SpansQuery = %v, want a named spans events2metric", model.Name, model.SpansQuery)
+	}
+
+	for name, definition := range map[string]string{
+		"unknown attribute": `{"name": "a", "logs_query": {}, "metric_fields": {"f": {"source_field": "f"}}, "unknown_attribute": true}`,
+		"both queries":      `{"name": "a", "logs_query": {}, "spans_query": {}, "metric_fields": {"f": {"source_field": "f"}}}`,
+		"no query":          `{"name": "a", "metric_fields": {"f": {"source_field": "f"}}}`,
+		"invalid name":      `{"name": "a b", "logs_query": {}}`,
+		"sample type":       `{"name": "a", "logs_query": {}, "metric_fields": {"f": {"source_field": "f", "aggregations": {"samples": {"type": "Avg"}}}}}`,
+		"histogram buckets": `{"name": "a", "logs_query": {}, "metric_fields": {"f": {"source_field": "f", "aggregations": {"histogram": {"enable": true}}}}}`,
+	} {
+		if _, diags := decodeEvents2MetricsSetDefinition(ctx, "broken", []byte(definition)); !diags.HasError() {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPlannedEvents2MetricsSetIDs(t *testing.T) {
+	definitions, diags := parseEvents2MetricsSetDocument(events2MetricsSetTestDocument)
+	if diags.HasError() {
+		t.Fatalf("parseEvents2MetricsSetDocument returned diagnostics: %v", diags)
+	}
+
+	ids, diags := plannedEvents2MetricsSetIDs(definitions, map[string]string{"request_latency": "id-1", "removed": "id-2"})
+	if diags.HasError() {
+		t.Fatalf("plannedEvents2MetricsSetIDs returned diagnostics: %v", diags)
+	}
+	elements := ids.Elements()
+	if len(elements) != 2 {
+		t.Fatalf("plannedEvents2MetricsSetIDs returned %d elements, want 2", len(elements))
+	}
+	if elements["request_latency"].String() != `"id-1"` {
+		t.Errorf("request_latency = %s, want the existing ID", elements["request_latency"])
+	}
+	if !elements["checkout_spans"].IsUnknown() {
+		t.Errorf("checkout_spans = %s, want unknown", elements["checkout_spans"])
+	}
+}
+
+func TestRefreshEvents2MetricsSetDocument(t *testing.T) {
+	countType := e2ms.AGGTYPE_AGG_TYPE_COUNT
+	checkoutSpans := func(services ...string) e2ms.E2M {
+		return e2ms.E2M{
+			Id:   ptr("id-1"),
+			Name: "checkout_spans_total",
+			SpansQuery: &e2ms.V2SpansQuery{
+				ServiceFilters: services,
+			},
+			MetricFields: []e2ms.V2MetricField{{
+				TargetBaseMetricName: "duration",
+				SourceField:          "duration",
+				Aggregations: []e2ms.V2Aggregation{
+					{AggType: &countType, Enabled: ptr(true), TargetMetricName: ptr("duration_count")},
+				},
+			}},
+		}
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		document, diags := refreshEvents2MetricsSetDocument(context.Background(), events2MetricsSetTestDocument, map[string]e2ms.E2M{
+			"checkout_spans": checkoutSpans("checkout"),
+		})
+		if diags.HasError() {
+			t.Fatalf("refreshEvents2MetricsSetDocument returned diagnostics: %v", diags)
+		}
+		if document != events2MetricsSetTestDocument {
+			t.Errorf("document = %s, want it unchanged", document)
+		}
+	})
+
+	t.Run("changed outside of terraform", func(t *testing.T) {
+		document, diags := refreshEvents2MetricsSetDocument(context.Background(), events2MetricsSetTestDocument, map[string]e2ms.E2M{
+			"checkout_spans": checkoutSpans("payments"),
+		})
+		if diags.HasError() {
+			t.Fatalf("refreshEvents2MetricsSetDocument returned diagnostics: %v", diags)
+		}
+
+		definitions, diags := parseEvents2MetricsSetDocument(document)
+		if diags.HasError() {
+			t.Fatalf("parseEvents2MetricsSetDocument returned diagnostics: %v", diags)
+		}
+		model, diags := decodeEvents2MetricsSetDefinition(context.Background(), "checkout_spans", definitions["checkout_spans"])
+		if diags.HasError() {
+			t.Fatalf("decodeEvents2MetricsSetDefinition returned diagnostics: %v", diags)
+		}
+		if got := model.SpansQuery.Services.String(); got != `["payments"]` {
+			t.Errorf("spans_query.services = %s, want the remote services", got)
+		}
+		prior, _ := parseEvents2MetricsSetDocument(events2MetricsSetTestDocument)
+		if !events2MetricsSetDefinitionsEqual(prior["request_latency"], definitions["request_latency"]) {
+			t.Errorf("request_latency = %s, want %s", definitions["request_latency"], prior["request_latency"])
+		}
+	})
+}
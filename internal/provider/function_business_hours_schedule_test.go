@@ -0,0 +1,69 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCoralogixFunctionBusinessHoursSchedule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `locals {
+  active_on = provider::coralogix::business_hours_schedule("Europe/Berlin", ["monday", "Friday"], "09:00", "17:30", "2025-07-01T12:00:00Z")
+}
+
+output "utc_offset" {
+  value = local.active_on.utc_offset
+}
+
+output "days_of_week" {
+  value = join(",", sort(local.active_on.days_of_week))
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("utc_offset", "+0200"),
+					resource.TestCheckOutput("days_of_week", "Friday,Monday"),
+				),
+			},
+			{
+				Config: `output "utc_offset" {
+  value = provider::coralogix::business_hours_schedule("Europe/Berlin", ["Monday"], "09:00", "17:30", "2025-01-15T12:00:00Z").utc_offset
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("utc_offset", "+0100"),
+				),
+			},
+			{
+				Config: `output "active_on" {
+  value = provider::coralogix::business_hours_schedule("Mars/Olympus_Mons", ["Monday"], "09:00", "17:30", "2025-01-15T12:00:00Z")
+}
+`,
+				ExpectError: regexp.MustCompile(`is not an IANA time zone`),
+			},
+		},
+	})
+}
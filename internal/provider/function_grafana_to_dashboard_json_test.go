@@ -0,0 +1,69 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCoralogixFunctionGrafanaToDashboardJSON(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	filePath := filepath.Join(wd, "testdata", "dashboards", "grafana_dashboard.json")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDashboardDestroy(t),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`locals {
+  converted = provider::coralogix::grafana_to_dashboard_json(file(%q))
+}
+
+resource "coralogix_dashboard" test {
+  content_json = local.converted.content_json
+}
+
+output "warnings" {
+  value = length(local.converted.warnings)
+}
+`, filePath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dashboardResourceName, "id"),
+					resource.TestCheckOutput("warnings", "5"),
+				),
+			},
+			{
+				Config: `output "content_json" {
+  value = provider::coralogix::grafana_to_dashboard_json("{\"panels\": []}").content_json
+}
+`,
+				ExpectError: regexp.MustCompile(`has no title`),
+			},
+		},
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure      = &MetricLabelValuesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &MetricLabelValuesDataSource{}
+)
+
+func NewMetricLabelValuesDataSource() datasource.DataSource {
+	return &MetricLabelValuesDataSource{}
+}
+
+type MetricLabelValuesDataSource struct {
+	client *clientset.MetricsClient
+}
+
+type MetricLabelValuesDataSourceModel struct {
+	Label    types.String `tfsdk:"label"`
+	Regex    types.String `tfsdk:"regex"`
+	Match    types.String `tfsdk:"match"`
+	Start    types.String `tfsdk:"start"`
+	End      types.String `tfsdk:"end"`
+	Lookback types.String `tfsdk:"lookback"`
+	Values   types.List   `tfsdk:"values"` // []types.String
+}
+
+func (m *MetricLabelValuesDataSourceModel) filter() metricSeriesFilterModel {
+	return metricSeriesFilterModel{regex: m.Regex, match: m.Match, start: m.Start, end: m.End, lookback: m.Lookback}
+}
+
+func (d *MetricLabelValuesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metric_label_values"
+}
+
+func (d *MetricLabelValuesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientSet.Metrics()
+}
+
+func (d *MetricLabelValuesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := metricSeriesFilterAttributes("label values")
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"label": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Name of the label, e.g. `service`.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"values": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Sorted values of the label.",
+		},
+	})
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the values of a metric label in Coralogix from the Prometheus compatible metrics API, " +
+			"e.g. to create an alert per service with `for_each`.",
+		Attributes: attributes,
+	}
+}
+
+func (d *MetricLabelValuesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data MetricLabelValuesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateMetricSeriesFilter(data.filter())...)
+}
+
+func (d *MetricLabelValuesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *MetricLabelValuesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	values, err := d.client.LabelValues(ctx, data.Label.ValueString(), expandMetricSeriesFilter(data.filter()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading coralogix_metric_label_values", err.Error())
+		return
+	}
+	values, err = filterMetricValues(values, data.filter())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading coralogix_metric_label_values", err.Error())
+		return
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	data.Values = list
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
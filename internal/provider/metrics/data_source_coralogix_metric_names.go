@@ -0,0 +1,123 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure      = &MetricNamesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &MetricNamesDataSource{}
+)
+
+func NewMetricNamesDataSource() datasource.DataSource {
+	return &MetricNamesDataSource{}
+}
+
+type MetricNamesDataSource struct {
+	client *clientset.MetricsClient
+}
+
+type MetricNamesDataSourceModel struct {
+	Regex    types.String `tfsdk:"regex"`
+	Match    types.String `tfsdk:"match"`
+	Start    types.String `tfsdk:"start"`
+	End      types.String `tfsdk:"end"`
+	Lookback types.String `tfsdk:"lookback"`
+	Names    types.List   `tfsdk:"names"` // []types.String
+}
+
+func (m *MetricNamesDataSourceModel) filter() metricSeriesFilterModel {
+	return metricSeriesFilterModel{regex: m.Regex, match: m.Match, start: m.Start, end: m.End, lookback: m.Lookback}
+}
+
+func (d *MetricNamesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metric_names"
+}
+
+func (d *MetricNamesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientSet.Metrics()
+}
+
+func (d *MetricNamesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := metricSeriesFilterAttributes("metric names")
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"names": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Sorted names of the metrics.",
+		},
+	})
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the names of the metrics in Coralogix from the Prometheus compatible metrics API, " +
+			"e.g. to look up the metrics referenced by dashboards, alerts, SLOs and recording rules, or to create resources per metric with `for_each`.",
+		Attributes: attributes,
+	}
+}
+
+func (d *MetricNamesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data MetricNamesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateMetricSeriesFilter(data.filter())...)
+}
+
+func (d *MetricNamesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *MetricNamesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names, err := d.client.LabelValues(ctx, "__name__", expandMetricSeriesFilter(data.filter()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading coralogix_metric_names", err.Error())
+		return
+	}
+	names, err = filterMetricValues(names, data.filter())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading coralogix_metric_names", err.Error())
+		return
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	data.Names = list
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// metricSeriesFilterAttributes are the filters of the metric names and label
+// values data sources.
+func metricSeriesFilterAttributes(values string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"regex": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("RE2 regular expression the %s must fully match, as with the `=~` PromQL matcher.", values),
+		},
+		"match": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Series selector restricting the %s to the matching series, e.g. `http_requests_total{env=\"prod\"}`.", values),
+		},
+		"start": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Start of the time range of the series, as an RFC 3339 timestamp. Conflicts with `lookback`.",
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRoot("lookback")),
+			},
+		},
+		"end": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "End of the time range of the series, as an RFC 3339 timestamp. Defaults to now.",
+		},
+		"lookback": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Duration of the time range of the series before `end`, e.g. `24h`. Without `start` and `lookback`, the default time range of the metrics API is used.",
+		},
+	}
+}
+
+// metricSeriesFilterModel holds the metricSeriesFilterAttributes of a data
+// source model.
+type metricSeriesFilterModel struct {
+	regex    types.String
+	match    types.String
+	start    types.String
+	end      types.String
+	lookback types.String
+}
+
+// validateMetricSeriesFilter checks the known filters of a configuration.
+func validateMetricSeriesFilter(filter metricSeriesFilterModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	known := func(value types.String) bool { return !value.IsNull() && !value.IsUnknown() }
+	if known(filter.regex) {
+		if _, err := regexp.Compile(filter.regex.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root("regex"), "Invalid regex", err.Error())
+		}
+	}
+	if known(filter.match) {
+		if err := validateSeriesSelector(filter.match.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root("match"), "Invalid match", err.Error())
+		}
+	}
+	for name, value := range map[string]types.String{"start": filter.start, "end": filter.end} {
+		if known(value) {
+			if _, err := time.Parse(time.RFC3339, value.ValueString()); err != nil {
+				diags.AddAttributeError(path.Root(name), "Invalid "+name, fmt.Sprintf("%s must be an RFC 3339 timestamp such as 2025-01-02T15:04:05Z, got %q", name, value.ValueString()))
+			}
+		}
+	}
+	if known(filter.lookback) {
+		if duration, err := time.ParseDuration(filter.lookback.ValueString()); err != nil || duration <= 0 {
+			diags.AddAttributeError(path.Root("lookback"), "Invalid lookback", fmt.Sprintf("lookback must be a positive duration such as 24h, got %q", filter.lookback.ValueString()))
+		}
+	}
+	return diags
+}
+
+func validateSeriesSelector(match string) error {
+	expr, err := utils.ParsePromQLExpr(match)
+	if err != nil {
+		return err
+	}
+	selector, ok := expr.(*utils.PromQLVectorSelector)
+	if !ok || selector.Offset != "" || selector.At != "" {
+		return fmt.Errorf("%q is not a series selector such as http_requests_total{env=\"prod\"}", match)
+	}
+	return nil
+}
+
+func expandMetricSeriesFilter(filter metricSeriesFilterModel) clientset.MetricSeriesFilter {
+	var result clientset.MetricSeriesFilter
+	if match := filter.match.ValueString(); match != "" {
+		result.Match = []string{match}
+	}
+	if !filter.end.IsNull() {
+		result.End, _ = time.Parse(time.RFC3339, filter.end.ValueString())
+	}
+	if !filter.start.IsNull() {
+		result.Start, _ = time.Parse(time.RFC3339, filter.start.ValueString())
+	} else if !filter.lookback.IsNull() {
+		if result.End.IsZero() {
+			result.End = time.Now()
+		}
+		lookback, _ := time.ParseDuration(filter.lookback.ValueString())
+		result.Start = result.End.Add(-lookback)
+	}
+	return result
+}
+
+// filterMetricValues returns the sorted values fully matching the regex of
+// the filter, or all of them without one.
+func filterMetricValues(values []string, filter metricSeriesFilterModel) ([]string, error) {
+	result := slices.Clone(values)
+	if !filter.regex.IsNull() {
+		regex, err := regexp.Compile("^(?:" + filter.regex.ValueString() + ")$")
+		if err != nil {
+			return nil, err
+		}
+		result = slices.DeleteFunc(result, func(value string) bool { return !regex.MatchString(value) })
+	}
+	slices.Sort(result)
+	return slices.Compact(result), nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateMetricSeriesFilter(t *testing.T) {
+	valid := metricSeriesFilterModel{
+		regex:    types.StringValue("http_.*_total"),
+		match:    types.StringValue(`{__name__=~"http_.*", env="prod"}`),
+		start:    types.StringValue("2025-01-01T00:00:00Z"),
+		end:      types.StringValue("2025-01-02T00:00:00+02:00"),
+		lookback: types.StringNull(),
+	}
+	if diags := validateMetricSeriesFilter(valid); diags.HasError() {
+		t.Errorf("validateMetricSeriesFilter() = %v", diags)
+	}
+
+	invalid := metricSeriesFilterModel{
+		regex:    types.StringValue("http_(.*"),
+		match:    types.StringValue("rate(up[5m])"),
+		start:    types.StringValue("2025-01-01"),
+		end:      types.StringUnknown(),
+		lookback: types.StringValue("-1h"),
+	}
+	if diags := validateMetricSeriesFilter(invalid); diags.ErrorsCount() != 4 {
+		t.Errorf("validateMetricSeriesFilter() = %v, want 4 errors", diags)
+	}
+}
+
+func TestExpandMetricSeriesFilter(t *testing.T) {
+	filter := expandMetricSeriesFilter(metricSeriesFilterModel{
+		match:    types.StringValue("up"),
+		end:      types.StringValue("2025-01-02T00:00:00Z"),
+		lookback: types.StringValue("6h"),
+	})
+	end := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	if !reflect.DeepEqual(filter.Match, []string{"up"}) || !filter.End.Equal(end) || !filter.Start.Equal(end.Add(-6*time.Hour)) {
+		t.Errorf("expandMetricSeriesFilter() = %+v", filter)
+	}
+}
+
+func TestFilterMetricValues(t *testing.T) {
+	values, err := filterMetricValues([]string{"up", "http_requests_total", "http_errors_total", "http_requests_total", "xhttp_requests_total"},
+		metricSeriesFilterModel{regex: types.StringValue("http_.*_total")})
+	if err != nil || !reflect.DeepEqual(values, []string{"http_errors_total", "http_requests_total"}) {
+		t.Errorf("filterMetricValues() = %q, %v", values, err)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parsing_rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+var _ datasource.DataSource = &ParsingRulesSimulationDataSource{}
+
+func NewParsingRulesSimulationDataSource() datasource.DataSource {
+	return &ParsingRulesSimulationDataSource{}
+}
+
+type ParsingRulesSimulationDataSource struct{}
+
+type ParsingRulesSimulationDataSourceModel struct {
+	RuleGroup       types.String `tfsdk:"rule_group"`
+	Logs            types.List   `tfsdk:"logs"` // []types.String
+	ApplicationName types.String `tfsdk:"application_name"`
+	SubsystemName   types.String `tfsdk:"subsystem_name"`
+	Severity        types.String `tfsdk:"severity"`
+	GroupMatched    types.Bool   `tfsdk:"group_matched"`
+	Results         types.List   `tfsdk:"results"` // []ParsingRulesSimulationResultModel
+}
+
+type ParsingRulesSimulationResultModel struct {
+	Text         types.String `tfsdk:"text"`
+	Blocked      types.Bool   `tfsdk:"blocked"`
+	MatchedRules types.List   `tfsdk:"matched_rules"` // []types.String
+	Metadata     types.Map    `tfsdk:"metadata"`      // map[string]string
+	Timestamp    types.String `tfsdk:"timestamp"`
+}
+
+func parsingRulesSimulationResultAttr() map[string]attr.Type {
+	return map[string]attr.Type{
+		"text":          types.StringType,
+		"blocked":       types.BoolType,
+		"matched_rules": types.ListType{ElemType: types.StringType},
+		"metadata":      types.MapType{ElemType: types.StringType},
+		"timestamp":     types.StringType,
+	}
+}
+
+func (d *ParsingRulesSimulationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parsing_rules_simulation"
+}
+
+func (d *ParsingRulesSimulationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a `coralogix_parsing_rules` rule group over sample logs, without calling Coralogix, and reports the transformed logs and the rules that matched them. " +
+			"Subgroups run in the order they are declared and, within a subgroup, only the first active rule matching a log is applied. A blocked log is not processed further. " +
+			"Regular expressions are evaluated with Go RE2, `(?<name>...)` groups are accepted as `(?P<name>...)`, so lookarounds and backreferences are rejected. " +
+			"Fields are `text` or `text.<key>` paths in the JSON object of the log; rules reading or writing keys of a log that is not a JSON object don't match it.",
+		Attributes: map[string]schema.Attribute{
+			"rule_group": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "YAML or JSON rule group using the `coralogix_parsing_rules` attributes, e.g. `jsonencode(coralogix_parsing_rules.example)`. " +
+					"Schema defaults apply to attributes the rule group leaves out.",
+			},
+			"logs": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Log texts to run the rule group over.",
+			},
+			"application_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Application name of the logs, matched against `applications`. When not set, `applications` is not checked.",
+			},
+			"subsystem_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Subsystem name of the logs, matched against `subsystems`. When not set, `subsystems` is not checked.",
+			},
+			"severity": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Severity of the logs, matched against `severities`. When not set, `severities` is not checked. Can be one of %q.", parsingRulesValidSeverities),
+				Validators: []validator.String{
+					stringvalidator.OneOf(parsingRulesValidSeverities...),
+				},
+			},
+			"group_matched": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the rule group is active and its `applications`, `subsystems` and `severities` match the logs. When false, the logs are returned unchanged.",
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Result for every log, in the order of `logs`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"text": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Text of the log after the rules. JSON objects are re-encoded with sorted keys once a rule changes them.",
+						},
+						"blocked": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether a `block` rule blocked the log.",
+						},
+						"matched_rules": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Names of the rules applied to the log, one per subgroup at most.",
+						},
+						"metadata": schema.MapAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Metadata fields set by `json_extract` rules, by `destination_field`.",
+						},
+						"timestamp": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Timestamp set by an `extract_timestamp` rule, in RFC 3339 format. Null when no rule set it.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ParsingRulesSimulationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *ParsingRulesSimulationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, diags := parseParsingRulesSimulationGroup(ctx, data.RuleGroup.ValueString())
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	subgroups, err := compileParsingRulesSimulation(group.RuleSubgroups)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("rule_group"), "Invalid rule group", err.Error())
+		return
+	}
+
+	var texts []string
+	resp.Diagnostics.Append(data.Logs.ElementsAs(ctx, &texts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matched := (group.Active.IsNull() || group.Active.ValueBool()) && simulatedRuleMatchers(
+		utils.TypeStringSliceToStringSlice(group.Applications),
+		utils.TypeStringSliceToStringSlice(group.Subsystems),
+		utils.TypeStringSliceToStringSlice(group.Severities),
+		data.ApplicationName.ValueString(), data.SubsystemName.ValueString(), data.Severity.ValueString(),
+	)
+	if !matched {
+		subgroups = nil
+	}
+	data.GroupMatched = types.BoolValue(matched)
+
+	results := make([]ParsingRulesSimulationResultModel, 0, len(texts))
+	for _, log := range simulateParsingRules(subgroups, texts) {
+		result := ParsingRulesSimulationResultModel{
+			Text:      types.StringValue(log.text),
+			Blocked:   types.BoolValue(log.blocked),
+			Timestamp: types.StringNull(),
+		}
+		if log.timestamp != nil {
+			result.Timestamp = types.StringValue(log.timestamp.Format(time.RFC3339Nano))
+		}
+		var diags diag.Diagnostics
+		result.MatchedRules, diags = types.ListValueFrom(ctx, types.StringType, append([]string{}, log.matched...))
+		resp.Diagnostics.Append(diags...)
+		result.Metadata, diags = types.MapValueFrom(ctx, types.StringType, log.metadata)
+		resp.Diagnostics.Append(diags...)
+		results = append(results, result)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Results, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: parsingRulesSimulationResultAttr()}, results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseParsingRulesSimulationGroup decodes a YAML or JSON rule group against
+// the coralogix_parsing_rules schema.
+func parseParsingRulesSimulationGroup(ctx context.Context, content string) (*ParsingRulesModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var definition map[string]any
+	if err := yaml.Unmarshal([]byte(content), &definition); err != nil {
+		diags.AddAttributeError(path.Root("rule_group"), "Error on unmarshal rule group", err.Error())
+		return nil, diags
+	}
+	encoded, err := json.Marshal(definition)
+	if err != nil {
+		diags.AddAttributeError(path.Root("rule_group"), "Invalid rule group", err.Error())
+		return nil, diags
+	}
+
+	var schemaResp resource.SchemaResponse
+	(&ParsingRulesResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	var group ParsingRulesModel
+	if d := utils.DecodeJSONWithSchema(ctx, schemaResp.Schema, encoded, &group); d.HasError() {
+		for _, e := range d.Errors() {
+			diags.AddAttributeError(path.Root("rule_group"), e.Summary(), e.Detail())
+		}
+		return nil, diags
+	}
+	return &group, nil
+}
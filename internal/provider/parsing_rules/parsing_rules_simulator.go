@@ -0,0 +1,550 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parsing_rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// javaNamedGroup matches the (?<name> named groups of PCRE and Java, which
+// RE2 only knows as (?P<name>.
+var javaNamedGroup = regexp.MustCompile(`\(\?<([A-Za-z_][A-Za-z0-9_]*)>`)
+
+// simulatedLog is a log going through a simulated rule group. body is the
+// decoded JSON object of the log text, or nil when the text is not one.
+type simulatedLog struct {
+	text      string
+	body      map[string]any
+	metadata  map[string]string
+	timestamp *time.Time
+	blocked   bool
+	matched   []string
+}
+
+func newSimulatedLog(text string) *simulatedLog {
+	log := &simulatedLog{metadata: map[string]string{}}
+	log.setText(text)
+	return log
+}
+
+func (l *simulatedLog) setText(text string) {
+	var body map[string]any
+	if err := json.Unmarshal([]byte(text), &body); err == nil && body != nil {
+		l.body = body
+	} else {
+		l.body = nil
+	}
+	l.text = text
+}
+
+func (l *simulatedLog) setBody(body map[string]any) {
+	l.body = body
+	l.text = simulatedJSON(body)
+}
+
+// field returns the value of a source field, "text" for the whole log text
+// or "text.<path>" for a key of its JSON object.
+func (l *simulatedLog) field(name string) (string, bool) {
+	if name == "text" {
+		return l.text, true
+	}
+	value, ok := l.lookup(name)
+	if !ok {
+		return "", false
+	}
+	if s, ok := value.(string); ok {
+		return s, true
+	}
+	return simulatedJSON(value), true
+}
+
+func (l *simulatedLog) lookup(name string) (any, bool) {
+	keys, ok := simulatedFieldPath(name)
+	if !ok || l.body == nil {
+		return nil, false
+	}
+	var value any = l.body
+	for _, key := range keys {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// set writes value to a destination field and reports whether it could. Keys
+// of the JSON object are created as needed, logs whose text is not a JSON
+// object only accept "text".
+func (l *simulatedLog) set(name string, value any) bool {
+	if name == "text" {
+		switch value := value.(type) {
+		case string:
+			l.setText(value)
+		case map[string]any:
+			l.setBody(value)
+		default:
+			l.setText(simulatedJSON(value))
+		}
+		return true
+	}
+	keys, ok := simulatedFieldPath(name)
+	if !ok || l.body == nil {
+		return false
+	}
+	object := l.body
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := object[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			object[key] = next
+		}
+		object = next
+	}
+	object[keys[len(keys)-1]] = value
+	l.setBody(l.body)
+	return true
+}
+
+func (l *simulatedLog) remove(name string) {
+	keys, ok := simulatedFieldPath(name)
+	if !ok || l.body == nil {
+		return
+	}
+	object := l.body
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := object[key].(map[string]any)
+		if !ok {
+			return
+		}
+		object = next
+	}
+	delete(object, keys[len(keys)-1])
+	l.setBody(l.body)
+}
+
+func simulatedFieldPath(name string) ([]string, bool) {
+	path, ok := strings.CutPrefix(name, "text.")
+	if !ok || path == "" {
+		return nil, false
+	}
+	return strings.Split(path, "."), true
+}
+
+func simulatedJSON(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// simulatedRule applies one parsing rule to a log and reports whether the
+// rule matched it.
+type simulatedRule struct {
+	name  string
+	apply func(log *simulatedLog) bool
+}
+
+// compileParsingRulesSimulation turns the active rules of every active
+// subgroup into simulated rules. Regular expressions use Go RE2 syntax, with
+// (?<name> groups accepted as (?P<name>.
+func compileParsingRulesSimulation(subgroups []RuleSubgroupsModel) ([][]simulatedRule, error) {
+	result := make([][]simulatedRule, 0, len(subgroups))
+	for g, subgroup := range subgroups {
+		if !subgroup.Active.IsNull() && !subgroup.Active.ValueBool() {
+			continue
+		}
+		rules := make([]simulatedRule, 0, len(subgroup.Rules))
+		for i, rule := range subgroup.Rules {
+			compiled, active, err := compileSimulatedRule(rule)
+			if err != nil {
+				return nil, fmt.Errorf("rule_subgroups[%d].rules[%d]: %w", g, i, err)
+			}
+			if active {
+				rules = append(rules, compiled)
+			}
+		}
+		result = append(result, rules)
+	}
+	return result, nil
+}
+
+func compileSimulatedRule(rule RuleSubgroupModel) (simulatedRule, bool, error) {
+	active := func(value interface{ IsNull() bool }, enabled bool) bool {
+		return value.IsNull() || enabled
+	}
+	switch {
+	case rule.Parse != nil:
+		r := rule.Parse
+		re, err := compileRuleRegex(r.RegularExpression.ValueString())
+		if err != nil {
+			return simulatedRule{}, false, err
+		}
+		source, destination := r.SourceField.ValueString(), r.DestinationField.ValueString()
+		return simulatedRule{r.Name.ValueString(), func(log *simulatedLog) bool {
+			value, ok := log.field(source)
+			if !ok {
+				return false
+			}
+			groups, ok := simulatedNamedGroups(re, value)
+			return ok && log.set(destination, groups)
+		}}, active(r.Active, r.Active.ValueBool()), nil
+
+	case rule.Extract != nil:
+		r := rule.Extract
+		re, err := compileRuleRegex(r.RegularExpression.ValueString())
+		if err != nil {
+			return simulatedRule{}, false, err
+		}
+		source := r.SourceField.ValueString()
+		return simulatedRule{r.Name.ValueString(), func(log *simulatedLog) bool {
+			value, ok := log.field(source)
+			if !ok || log.body == nil {
+				return false
+			}
+			groups, ok := simulatedNamedGroups(re, value)
+			if !ok {
+				return false
+			}
+			for name, group := range groups {
+				log.body[name] = group
+			}
+			log.setBody(log.body)
+			return true
+		}}, active(r.Active, r.Active.ValueBool()), nil
+
+	case rule.Replace != nil:
+		r := rule.Replace
+		re, err := compileRuleRegex(r.RegularExpression.ValueString())
+		if err != nil {
+			return simulatedRule{}, false, err
+		}
+		source, destination, replacement := r.SourceField.ValueString(), r.DestinationField.ValueString(), r.ReplacementString.ValueString()
+		return simulatedRule{r.Name.ValueString(), func(log *simulatedLog) bool {
+			value, ok := log.field(source)
+			if !ok || !re.MatchString(value) {
+				return false
+			}
+			return log.set(destination, re.ReplaceAllString(value, replacement))
+		}}, active(r.Active, r.Active.ValueBool()), nil
+
+	case rule.Block != nil:
+		r := rule.Block
+		re, err := compileRuleRegex(r.RegularExpression.ValueString())
+		if err != nil {
+			return simulatedRule{}, false, err
+		}
+		source := r.SourceField.ValueString()
+		blockMatching := r.BlockMatchingLogs.IsNull() || r.BlockMatchingLogs.ValueBool()
+		return simulatedRule{r.Name.ValueString(), func(log *simulatedLog) bool {
+			value, _ := log.field(source)
+			if re.MatchString(value) != blockMatching {
+				return false
+			}
+			log.blocked = true
+			return true
+		}}, active(r.Active, r.Active.ValueBool()), nil
+
+	case rule.JsonExtract != nil:
+		r := rule.JsonExtract
+		key, destination := r.JsonKey.ValueString(), r.DestinationField.ValueString()
+		destinationText := r.DestinationFieldText.ValueString()
+		if destinationText == "" {
+			destinationText = "text"
+		}
+		return simulatedRule{r.Name.ValueString(), func(log *simulatedLog) bool {
+			value, ok := log.field("text." + key)
+			if !ok {
+				return false
+			}
+			if strings.EqualFold(destination, "text") {
+				return log.set(destinationText, value)
+			}
+			log.metadata[simulatedMetadataField(destination)] = value
+			return true
+		}}, active(r.Active, r.Active.ValueBool()), nil
+
+	case rule.ExtractTimestamp != nil:
+		r := rule.ExtractTimestamp
+		source, standard, format := r.SourceField.ValueString(), r.FieldFormatStandard.ValueString(), r.TimeFormat.ValueString()
+		parse, err := simulatedTimestampParser(standard, format)
+		if err != nil {
+			return simulatedRule{}, false, err
+		}
+		return simulatedRule{r.Name.ValueString(), func(log *simulatedLog) bool {
+			value, ok := log.field(source)
+			if !ok {
+				return false
+			}
+			timestamp, err := parse(value)
+			if err != nil {
+				return false
+			}
+			log.timestamp = &timestamp
+			return true
+		}}, active(r.Active, r.Active.ValueBool()), nil
+
+	case rule.RemoveFields != nil:
+		r := rule.RemoveFields
+		fields := make([]string, 0, len(r.ExcludedFields))
+		for _, field := range r.ExcludedFields {
+			fields = append(fields, field.ValueString())
+		}
+		return simulatedRule{r.Name.ValueString(), func(log *simulatedLog) bool {
+			if log.body == nil {
+				return false
+			}
+			for _, field := range fields {
+				log.remove("text." + strings.TrimPrefix(field, "text."))
+			}
+			return true
+		}}, active(r.Active, r.Active.ValueBool()), nil
+
+	case rule.JsonStringify != nil:
+		r := rule.JsonStringify
+		source, destination, keepSource := r.SourceField.ValueString(), r.DestinationField.ValueString(), r.KeepSourceField.ValueBool()
+		return simulatedRule{r.Name.ValueString(), func(log *simulatedLog) bool {
+			value, ok := log.lookup(source)
+			if !ok {
+				return false
+			}
+			if !keepSource {
+				log.remove(source)
+			}
+			return log.set(destination, simulatedJSON(value))
+		}}, active(r.Active, r.Active.ValueBool()), nil
+
+	case rule.ParseJsonField != nil:
+		r := rule.ParseJsonField
+		source, destination := r.SourceField.ValueString(), r.DestinationField.ValueString()
+		keepSource := r.KeepSourceField.ValueBool()
+		keepDestination := r.KeepDestinationField.IsNull() || r.KeepDestinationField.ValueBool()
+		return simulatedRule{r.Name.ValueString(), func(log *simulatedLog) bool {
+			value, ok := log.field(source)
+			if !ok {
+				return false
+			}
+			var parsed map[string]any
+			if err := json.Unmarshal([]byte(value), &parsed); err != nil || parsed == nil {
+				return false
+			}
+			if existing, ok := log.lookup(destination); ok && keepDestination {
+				if existing, ok := existing.(map[string]any); ok {
+					for key, value := range parsed {
+						existing[key] = value
+					}
+					parsed = existing
+				}
+			}
+			if !keepSource && source != destination {
+				log.remove(source)
+			}
+			return log.set(destination, parsed)
+		}}, active(r.Active, r.Active.ValueBool()), nil
+	}
+	return simulatedRule{}, false, fmt.Errorf("rule must set one of the rule types")
+}
+
+// compileRuleRegex compiles the regular_expression of a rule. Coralogix
+// evaluates rules with an RE2 compatible engine, so lookarounds and
+// backreferences are rejected, and (?<name> groups are accepted as (?P<name>.
+func compileRuleRegex(expression string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(javaNamedGroup.ReplaceAllString(expression, `(?P<$1>`))
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", expression, err)
+	}
+	return re, nil
+}
+
+func simulatedNamedGroups(re *regexp.Regexp, value string) (map[string]any, bool) {
+	match := re.FindStringSubmatch(value)
+	if match == nil {
+		return nil, false
+	}
+	groups := map[string]any{}
+	for i, name := range re.SubexpNames() {
+		if name != "" && i < len(match) {
+			groups[name] = match[i]
+		}
+	}
+	return groups, true
+}
+
+func simulatedMetadataField(destination string) string {
+	for field := range rulesSchemaDestinationFieldToApiDestinationField {
+		if strings.EqualFold(field, destination) {
+			return field
+		}
+	}
+	return destination
+}
+
+// simulateParsingRules runs every log through the subgroups in order. Within
+// a subgroup the first rule matching the log is applied and the others are
+// skipped. A blocked log is not processed further.
+func simulateParsingRules(subgroups [][]simulatedRule, texts []string) []*simulatedLog {
+	logs := make([]*simulatedLog, 0, len(texts))
+	for _, text := range texts {
+		log := newSimulatedLog(text)
+		for _, rules := range subgroups {
+			if log.blocked {
+				break
+			}
+			for _, rule := range rules {
+				if rule.apply(log) {
+					log.matched = append(log.matched, rule.name)
+					break
+				}
+			}
+		}
+		logs = append(logs, log)
+	}
+	return logs
+}
+
+// simulatedRuleMatchers reports whether a log with the given metadata goes
+// through the rule group. Empty metadata matches every constraint.
+func simulatedRuleMatchers(applications, subsystems, severities []string, application, subsystem, severity string) bool {
+	matches := func(constraints []string, value string) bool {
+		return len(constraints) == 0 || value == "" || slices.Contains(constraints, value)
+	}
+	return matches(applications, application) && matches(subsystems, subsystem) && matches(severities, severity)
+}
+
+func simulatedTimestampParser(standard, format string) (func(string) (time.Time, error), error) {
+	epoch := func(unit time.Duration) func(string) (time.Time, error) {
+		return func(value string) (time.Time, error) {
+			n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return time.Time{}, err
+			}
+			return time.Unix(0, 0).Add(time.Duration(n) * unit).UTC(), nil
+		}
+	}
+	layout := func(layout string) func(string) (time.Time, error) {
+		return func(value string) (time.Time, error) {
+			return time.Parse(layout, strings.TrimSpace(value))
+		}
+	}
+	switch standard {
+	case "secondTS":
+		return epoch(time.Second), nil
+	case "milliTS":
+		return epoch(time.Millisecond), nil
+	case "microTS":
+		return epoch(time.Microsecond), nil
+	case "nanoTS":
+		return epoch(time.Nanosecond), nil
+	case "golang":
+		return layout(format), nil
+	case "strftime", "":
+		goLayout, err := simulatedStrftimeLayout(format)
+		if err != nil {
+			return nil, err
+		}
+		return layout(goLayout), nil
+	case "javaSDF":
+		goLayout, err := simulatedJavaSDFLayout(format)
+		if err != nil {
+			return nil, err
+		}
+		return layout(goLayout), nil
+	}
+	return nil, fmt.Errorf("unknown field_format_standard %q", standard)
+}
+
+var simulatedStrftimeDirectives = map[byte]string{
+	'Y': "2006", 'y': "06", 'm': "01", 'd': "02", 'e': "_2", 'H': "15", 'I': "03", 'M': "04", 'S': "05",
+	'f': "000000", 'L': "000", 'p': "PM", 'b': "Jan", 'h': "Jan", 'B': "January", 'a': "Mon", 'A': "Monday",
+	'z': "-0700", 'Z': "MST", 'j': "002", 'T': "15:04:05", 'D': "01/02/06", 'F': "2006-01-02", '%': "%",
+}
+
+func simulatedStrftimeLayout(format string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+		i++
+		if i == len(format) {
+			return "", fmt.Errorf("time_format %q ends with %%", format)
+		}
+		directive, ok := simulatedStrftimeDirectives[format[i]]
+		if !ok {
+			return "", fmt.Errorf("time_format %q uses %%%c, which the simulation doesn't support", format, format[i])
+		}
+		b.WriteString(directive)
+	}
+	return b.String(), nil
+}
+
+// simulatedJavaSDFPatterns lists the SimpleDateFormat letters by pattern,
+// longest first so "yyyy" wins over "yy".
+var simulatedJavaSDFPatterns = []struct{ pattern, layout string }{
+	{"yyyy", "2006"}, {"yy", "06"}, {"MMMM", "January"}, {"MMM", "Jan"}, {"MM", "01"}, {"M", "1"},
+	{"dd", "02"}, {"d", "2"}, {"HH", "15"}, {"hh", "03"}, {"h", "3"}, {"mm", "04"}, {"m", "4"},
+	{"ss", "05"}, {"s", "5"}, {"SSSSSSSSS", "000000000"}, {"SSSSSS", "000000"}, {"SSS", "000"},
+	{"EEEE", "Monday"}, {"EEE", "Mon"}, {"a", "PM"}, {"XXX", "Z07:00"}, {"XX", "Z0700"}, {"X", "Z07"},
+	{"ZZZ", "-07:00"}, {"Z", "-0700"}, {"z", "MST"}, {"DDD", "002"},
+}
+
+func simulatedJavaSDFLayout(format string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(format); {
+		c := format[i]
+		switch {
+		case c == '\'':
+			// Quoted literal text, '' is a single quote.
+			end := strings.IndexByte(format[i+1:], '\'')
+			if end < 0 {
+				return "", fmt.Errorf("time_format %q has an unterminated quote", format)
+			}
+			if end == 0 {
+				b.WriteByte('\'')
+			} else {
+				b.WriteString(format[i+1 : i+1+end])
+			}
+			i += end + 2
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			matched := false
+			for _, p := range simulatedJavaSDFPatterns {
+				if strings.HasPrefix(format[i:], p.pattern) {
+					b.WriteString(p.layout)
+					i += len(p.pattern)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return "", fmt.Errorf("time_format %q uses %q, which the simulation doesn't support", format, string(c))
+			}
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parsing_rules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func simulateTestRules(t *testing.T, subgroups []RuleSubgroupsModel, texts ...string) []*simulatedLog {
+	t.Helper()
+	compiled, err := compileParsingRulesSimulation(subgroups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return simulateParsingRules(compiled, texts)
+}
+
+func TestSimulateParsingRulesFirstMatchPerSubgroup(t *testing.T) {
+	subgroups := []RuleSubgroupsModel{
+		{Active: types.BoolValue(true), Rules: []RuleSubgroupModel{
+			{Parse: &ParseModel{
+				Name:              types.StringValue("access"),
+				Active:            types.BoolValue(true),
+				SourceField:       types.StringValue("text"),
+				DestinationField:  types.StringValue("text"),
+				RegularExpression: types.StringValue(`^(?<method>[A-Z]+) (?<path>\S+) (?<status>\d{3})$`),
+			}},
+			{Replace: &ReplaceModel{
+				Name:              types.StringValue("never applied after a match"),
+				Active:            types.BoolValue(true),
+				SourceField:       types.StringValue("text"),
+				DestinationField:  types.StringValue("text"),
+				RegularExpression: types.StringValue(`.*`),
+				ReplacementString: types.StringValue("replaced"),
+			}},
+		}},
+		{Active: types.BoolValue(true), Rules: []RuleSubgroupModel{
+			{Block: &BlockModel{
+				Name:              types.StringValue("health checks"),
+				Active:            types.BoolValue(true),
+				SourceField:       types.StringValue("text.path"),
+				RegularExpression: types.StringValue(`^/healthz$`),
+				BlockMatchingLogs: types.BoolValue(true),
+			}},
+		}},
+	}
+
+	logs := simulateTestRules(t, subgroups, "GET /orders 200", "GET /healthz 200", "not an access log")
+
+	if got, want := logs[0].text, `{"method":"GET","path":"/orders","status":"200"}`; got != want {
+		t.Errorf("text = %s, want %s", got, want)
+	}
+	if got, want := logs[0].matched, []string{"access"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("matched = %q, want %q", got, want)
+	}
+	if !logs[1].blocked || !reflect.DeepEqual(logs[1].matched, []string{"access", "health checks"}) {
+		t.Errorf("log 1 blocked = %v, matched = %q", logs[1].blocked, logs[1].matched)
+	}
+	if got, want := logs[2].text, "replaced"; got != want {
+		t.Errorf("text = %s, want %s", got, want)
+	}
+}
+
+func TestSimulateParsingRulesSkipsInactiveRules(t *testing.T) {
+	subgroups := []RuleSubgroupsModel{
+		{Active: types.BoolValue(false), Rules: []RuleSubgroupModel{
+			{Block: &BlockModel{Name: types.StringValue("inactive subgroup"), Active: types.BoolValue(true), SourceField: types.StringValue("text"), RegularExpression: types.StringValue(`.`)}},
+		}},
+		{Active: types.BoolValue(true), Rules: []RuleSubgroupModel{
+			{Block: &BlockModel{Name: types.StringValue("inactive rule"), Active: types.BoolValue(false), SourceField: types.StringValue("text"), RegularExpression: types.StringValue(`.`)}},
+			{Block: &BlockModel{
+				Name:              types.StringValue("allow errors"),
+				Active:            types.BoolValue(true),
+				SourceField:       types.StringValue("text"),
+				RegularExpression: types.StringValue(`error`),
+				BlockMatchingLogs: types.BoolValue(false),
+			}},
+		}},
+	}
+
+	logs := simulateTestRules(t, subgroups, "an error", "all good")
+	if logs[0].blocked || len(logs[0].matched) != 0 {
+		t.Errorf("log 0 blocked = %v, matched = %q", logs[0].blocked, logs[0].matched)
+	}
+	if !logs[1].blocked || !reflect.DeepEqual(logs[1].matched, []string{"allow errors"}) {
+		t.Errorf("log 1 blocked = %v, matched = %q", logs[1].blocked, logs[1].matched)
+	}
+}
+
+func TestSimulateParsingRulesJSONRules(t *testing.T) {
+	subgroups := []RuleSubgroupsModel{
+		{Rules: []RuleSubgroupModel{{JsonExtract: &JsonExtractModel{
+			Name:             types.StringValue("severity"),
+			JsonKey:          types.StringValue("level"),
+			DestinationField: types.StringValue("Severity"),
+		}}}},
+		{Rules: []RuleSubgroupModel{{ExtractTimestamp: &ExtractTimestampModel{
+			Name:                types.StringValue("time"),
+			SourceField:         types.StringValue("text.time"),
+			FieldFormatStandard: types.StringValue("strftime"),
+			TimeFormat:          types.StringValue("%Y-%m-%dT%H:%M:%S%z"),
+		}}}},
+		{Rules: []RuleSubgroupModel{{ParseJsonField: &ParseJsonFieldModel{
+			Name:                 types.StringValue("payload"),
+			SourceField:          types.StringValue("text.payload"),
+			DestinationField:     types.StringValue("text.body"),
+			KeepSourceField:      types.BoolValue(false),
+			KeepDestinationField: types.BoolValue(true),
+		}}}},
+		{Rules: []RuleSubgroupModel{{RemoveFields: &RemoveFieldsModel{
+			Name:           types.StringValue("drop secrets"),
+			ExcludedFields: []types.String{types.StringValue("body.token")},
+		}}}},
+		{Rules: []RuleSubgroupModel{{JsonStringify: &JsonStringifyModel{
+			Name:             types.StringValue("stringify"),
+			SourceField:      types.StringValue("text.body"),
+			DestinationField: types.StringValue("text.body_text"),
+			KeepSourceField:  types.BoolValue(true),
+		}}}},
+	}
+
+	logs := simulateTestRules(t, subgroups, `{"level":"ERROR","time":"2025-03-01T10:00:00+0000","payload":"{\"user\":\"a\",\"token\":\"x\"}"}`)
+
+	want := `{"body":{"user":"a"},"body_text":"{\"user\":\"a\"}","level":"ERROR","time":"2025-03-01T10:00:00+0000"}`
+	if logs[0].text != want {
+		t.Errorf("text = %s, want %s", logs[0].text, want)
+	}
+	if got := logs[0].metadata; !reflect.DeepEqual(got, map[string]string{"severity": "ERROR"}) {
+		t.Errorf("metadata = %v", got)
+	}
+	if logs[0].timestamp == nil || !logs[0].timestamp.Equal(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("timestamp = %v", logs[0].timestamp)
+	}
+	if got, want := logs[0].matched, []string{"severity", "time", "payload", "drop secrets", "stringify"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("matched = %q, want %q", got, want)
+	}
+}
+
+func TestCompileParsingRulesSimulationInvalidRegex(t *testing.T) {
+	_, err := compileParsingRulesSimulation([]RuleSubgroupsModel{
+		{Rules: []RuleSubgroupModel{{Extract: &ExtractModel{
+			Name:              types.StringValue("lookahead"),
+			SourceField:       types.StringValue("text"),
+			RegularExpression: types.StringValue(`(?=foo)`),
+		}}}},
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "rule_subgroups[0].rules[0]: ") {
+		t.Fatalf("error = %v, want an error for rule_subgroups[0].rules[0]", err)
+	}
+}
+
+func TestSimulatedTimestampLayouts(t *testing.T) {
+	for _, tc := range []struct{ standard, format, value string }{
+		{"strftime", "%d/%b/%Y:%H:%M:%S %z", "01/Mar/2025:10:00:00 +0000"},
+		{"javaSDF", "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", "2025-03-01T10:00:00.000Z"},
+		{"golang", time.RFC3339, "2025-03-01T10:00:00Z"},
+		{"secondTS", "", "1740823200"},
+		{"milliTS", "", "1740823200000"},
+	} {
+		parse, err := simulatedTimestampParser(tc.standard, tc.format)
+		if err != nil {
+			t.Fatalf("%s %q: %v", tc.standard, tc.format, err)
+		}
+		got, err := parse(tc.value)
+		if err != nil {
+			t.Fatalf("%s %q: %v", tc.standard, tc.format, err)
+		}
+		if want := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC); !got.Equal(want) {
+			t.Errorf("%s %q: got %v, want %v", tc.standard, tc.format, got, want)
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
@@ -35,6 +36,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -325,9 +327,13 @@ func (r *ParsingRulesResource) Schema(_ context.Context, _ resource.SchemaReques
 				Description: "Rule-group creator.",
 			},
 			"order": schema.Int64Attribute{
-				Optional:    true,
-				Computed:    true,
-				Description: "Determines the index of the rule-group between the other rule-groups. By default, will be added last. (1 based indexing).",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Description: "Determines the index of the rule-group between the other rule-groups. By default, will be added last. (1 based indexing). " +
+					"Leave it unset when the order is managed by coralogix_parsing_rules_order.",
 			},
 			"rule_subgroups": schema.ListNestedAttribute{
 				Optional: true,
@@ -649,14 +655,14 @@ func parseSchemaAttrs() map[string]schema.Attribute {
 	parseSchema := commonRulesAttrs()
 	parseSchema = appendSourceFieldAttrs(parseSchema)
 	parseSchema = appendDestinationFieldAttrs(parseSchema)
-	parseSchema = appendRegularExpressionAttrs(parseSchema)
+	parseSchema = appendRegularExpressionAttrs(parseSchema, true)
 	return parseSchema
 }
 
 func blockAttrs() map[string]schema.Attribute {
 	blockSchema := commonRulesAttrs()
 	blockSchema = appendSourceFieldAttrs(blockSchema)
-	blockSchema = appendRegularExpressionAttrs(blockSchema)
+	blockSchema = appendRegularExpressionAttrs(blockSchema, false)
 	blockSchema["keep_blocked_logs"] = schema.BoolAttribute{
 		Optional:            true,
 		MarkdownDescription: "Determines if to view blocked logs in LiveTail and archive to S3.",
@@ -683,6 +689,9 @@ func jsonExtractAttrs() map[string]schema.Attribute {
 	jsonExtractSchema["destination_field_text"] = schema.StringAttribute{
 		Optional:            true,
 		MarkdownDescription: "Required when destination_field is 'Text'. should be either 'text' or 'text.<some value>'",
+		Validators: []validator.String{
+			destinationFieldTextValidator{},
+		},
 	}
 	jsonExtractSchema["json_key"] = schema.StringAttribute{
 		Required:    true,
@@ -693,7 +702,7 @@ func jsonExtractAttrs() map[string]schema.Attribute {
 
 func replaceAttrs() map[string]schema.Attribute {
 	replaceSchema := commonRulesAttrs()
-	replaceSchema = appendRegularExpressionAttrs(replaceSchema)
+	replaceSchema = appendRegularExpressionAttrs(replaceSchema, false)
 	replaceSchema = appendSourceFieldAttrs(replaceSchema)
 	replaceSchema = appendDestinationFieldAttrs(replaceSchema)
 	replaceSchema["replacement_string"] = schema.StringAttribute{
@@ -749,7 +758,7 @@ func jsonStringifyFieldsAttrs() map[string]schema.Attribute {
 func extractAttrs() map[string]schema.Attribute {
 	extractSchema := commonRulesAttrs()
 	extractSchema = appendSourceFieldAttrs(extractSchema)
-	extractSchema = appendRegularExpressionAttrs(extractSchema)
+	extractSchema = appendRegularExpressionAttrs(extractSchema, true)
 	return extractSchema
 }
 
@@ -817,10 +826,17 @@ func appendDestinationFieldAttrs(m map[string]schema.Attribute) map[string]schem
 	return m
 }
 
-func appendRegularExpressionAttrs(m map[string]schema.Attribute) map[string]schema.Attribute {
+func appendRegularExpressionAttrs(m map[string]schema.Attribute, namedGroups bool) map[string]schema.Attribute {
+	description := "Regular expiration. More info: https://coralogix.com/blog/regex-101/"
+	if namedGroups {
+		description += " Must have at least one named capture group, e.g. `(?<name>...)`."
+	}
 	m["regular_expression"] = schema.StringAttribute{
 		Required:            true,
-		MarkdownDescription: "Regular expiration. More info: https://coralogix.com/blog/regex-101/",
+		MarkdownDescription: description,
+		Validators: []validator.String{
+			regularExpressionValidator{namedGroups: namedGroups},
+		},
 	}
 	return m
 }
@@ -1198,3 +1214,71 @@ func flattenRuleSubGroups(subgroups []prgs.RuleSubgroup) []RuleSubgroupsModel {
 
 	return subgroupRules
 }
+
+// regularExpressionValidator checks that a regular_expression compiles with
+// the regex syntax of parsing rules and, for rules writing the capture groups
+// to fields, that it has named capture groups.
+type regularExpressionValidator struct {
+	namedGroups bool
+}
+
+func (v regularExpressionValidator) Description(_ context.Context) string {
+	if v.namedGroups {
+		return "A regular expression with at least one named capture group."
+	}
+	return "A regular expression."
+}
+
+func (v regularExpressionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regularExpressionValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	re, err := compileRuleRegex(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid regular expression", err.Error())
+		return
+	}
+	if v.namedGroups && !slices.ContainsFunc(re.SubexpNames(), func(name string) bool { return name != "" }) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid regular expression",
+			fmt.Sprintf("regular expression %q has no named capture group, e.g. (?<name>...), to write to the log", req.ConfigValue.ValueString()))
+	}
+}
+
+// destinationFieldTextValidator checks the destination_field_text of a
+// json_extract rule, which is required when destination_field is text.
+type destinationFieldTextValidator struct{}
+
+func (v destinationFieldTextValidator) Description(_ context.Context) string {
+	return "Either text or text.<field>, required when destination_field is text."
+}
+
+func (v destinationFieldTextValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v destinationFieldTextValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() {
+		return
+	}
+	var destinationField types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("destination_field"), &destinationField)...)
+	if resp.Diagnostics.HasError() || destinationField.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.IsNull() {
+		if strings.EqualFold(destinationField.ValueString(), "text") {
+			resp.Diagnostics.AddAttributeError(req.Path, "Missing destination_field_text",
+				"destination_field_text is required when destination_field is text")
+		}
+		return
+	}
+	if field := req.ConfigValue.ValueString(); field != "text" && !strings.HasPrefix(field, "text.") || strings.HasSuffix(field, ".") {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid destination_field_text",
+			fmt.Sprintf("destination_field_text must be text or text.<field>, got %q", field))
+	}
+}
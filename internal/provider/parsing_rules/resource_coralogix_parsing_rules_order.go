@@ -0,0 +1,341 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parsing_rules
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	cxsdkOpenapi "github.com/coralogix/coralogix-management-sdk/go/openapi/cxsdk"
+	prgs "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/rule_groups_service"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &ParsingRulesOrderResource{}
+	_ resource.ResourceWithImportState = &ParsingRulesOrderResource{}
+)
+
+func NewParsingRulesOrderResource() resource.Resource {
+	return &ParsingRulesOrderResource{}
+}
+
+type ParsingRulesOrderResource struct {
+	client *prgs.RuleGroupsServiceAPIService
+}
+
+type ParsingRulesOrderModel struct {
+	ID           types.String `tfsdk:"id"`
+	RuleGroupIds types.List   `tfsdk:"rule_group_ids"` // []types.String
+	FirstOrder   types.Int64  `tfsdk:"first_order"`
+}
+
+// parsingRuleGroupMove is an order change of a rule group, kept to move the
+// group back if a later change fails.
+type parsingRuleGroupMove struct {
+	id            string
+	previousOrder int64
+}
+
+func (r *ParsingRulesOrderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids := strings.Split(req.ID, ",")
+	for i := range ids {
+		ids[i] = strings.TrimSpace(ids[i])
+	}
+	if slices.Contains(ids, "") {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected comma separated rule group IDs, got %q", req.ID))
+		return
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), uuid.NewString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rule_group_ids"), list)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("first_order"), types.Int64Null())...)
+}
+
+func (r *ParsingRulesOrderResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientSet, ok := req.ProviderData.(*clientset.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clientset.ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientSet.ParsingRuleGroups()
+}
+
+func (r *ParsingRulesOrderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parsing_rules_order"
+}
+
+func (r *ParsingRulesOrderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rule_group_ids": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				MarkdownDescription: "IDs of the rule groups, e.g. `coralogix_parsing_rules.example.id`, in the order they run. " +
+					"The groups take the consecutive orders from `first_order`, and the other rule groups are moved after them.",
+			},
+			"first_order": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				MarkdownDescription: "Order of the first rule group (1-based indexing). Defaults to 1, running the groups before any other rule group.",
+			},
+		},
+		MarkdownDescription: "Coralogix parsing rules order. Owns the relative order of a list of `coralogix_parsing_rules` rule groups, so groups managed by different teams can leave their `order` unset. " +
+			"Coralogix has no API to reorder rule groups at once, so changes are not atomic: the groups are moved one at a time, and logs ingested meanwhile can run through a partially applied order. " +
+			"The rule groups are all read before the first order change, and the groups already moved are moved back when a change fails. " +
+			"Reordering the groups outside of Terraform, or placing another rule group between them, is detected as drift. Deleting the resource leaves the groups in place.",
+	}
+}
+
+func (r *ParsingRulesOrderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ParsingRulesOrderModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(plan.RuleGroupIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyOrder(ctx, ids, plan.FirstOrder.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Error creating coralogix_parsing_rules_order", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(uuid.NewString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ParsingRulesOrderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ParsingRulesOrderModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var ids []string
+	resp.Diagnostics.Append(state.RuleGroupIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orders := make(map[string]int64, len(ids))
+	for _, id := range ids {
+		group, httpResponse, err := r.client.RuleGroupsServiceGetRuleGroup(ctx, id).Execute()
+		if err != nil {
+			if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+				resp.Diagnostics.AddWarning(
+					fmt.Sprintf("Rule group %s of coralogix_parsing_rules_order no longer exists in Coralogix backend", id),
+					"The rule group is removed from the state of coralogix_parsing_rules_order",
+				)
+				continue
+			}
+			resp.Diagnostics.AddError("Error reading coralogix_parsing_rules_order",
+				utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Read", nil),
+			)
+			return
+		}
+		orders[id] = group.RuleGroup.GetOrder()
+	}
+
+	if state.FirstOrder.IsNull() && len(orders) > 0 {
+		state.FirstOrder = types.Int64Value(slices.Min(slices.Collect(maps.Values(orders))))
+	}
+	list, diags := types.ListValueFrom(ctx, types.StringType, orderedParsingRuleGroupIds(ids, orders, state.FirstOrder.ValueInt64()))
+	resp.Diagnostics.Append(diags...)
+	state.RuleGroupIds = list
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ParsingRulesOrderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *ParsingRulesOrderModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(plan.RuleGroupIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyOrder(ctx, ids, plan.FirstOrder.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Error updating coralogix_parsing_rules_order", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ParsingRulesOrderResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// The rule groups keep their order, and are reordered by the groups
+	// created or moved later.
+}
+
+// applyOrder moves the rule groups to the consecutive orders from
+// firstOrder. Moving a rule group shifts the groups after its new order, so
+// the groups are moved in list order and read again right before their move.
+// The moves are separate updates, so applyOrder is not atomic: until it
+// returns, the groups run in a partially applied order, and when moving the
+// groups back after a failure fails too, they are left that way. Groups are
+// read again before they are moved back, so that the rollback does not undo
+// changes made to them in the meantime.
+func (r *ParsingRulesOrderResource) applyOrder(ctx context.Context, ids []string, firstOrder int64) error {
+	for _, id := range ids {
+		if _, err := r.getRuleGroup(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	var moves []parsingRuleGroupMove
+	for i, id := range ids {
+		group, err := r.getRuleGroup(ctx, id)
+		if err == nil {
+			order := firstOrder + int64(i)
+			if group.GetOrder() == order {
+				continue
+			}
+			if err = r.setRuleGroupOrder(ctx, group, order); err == nil {
+				moves = append(moves, parsingRuleGroupMove{id: id, previousOrder: group.GetOrder()})
+				continue
+			}
+		}
+
+		var rollbackErrors []string
+		for _, move := range slices.Backward(moves) {
+			current, rollbackErr := r.getRuleGroup(ctx, move.id)
+			if rollbackErr == nil {
+				rollbackErr = r.setRuleGroupOrder(ctx, current, move.previousOrder)
+			}
+			if rollbackErr != nil {
+				rollbackErrors = append(rollbackErrors, rollbackErr.Error())
+			}
+		}
+		if len(rollbackErrors) > 0 {
+			return fmt.Errorf("%s\n\nThe rule groups moved before the failure could not all be moved back:\n%s", err, strings.Join(rollbackErrors, "\n"))
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *ParsingRulesOrderResource) getRuleGroup(ctx context.Context, id string) (*prgs.RuleGroup, error) {
+	result, httpResponse, err := r.client.RuleGroupsServiceGetRuleGroup(ctx, id).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("rule group %s: %s", id, utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Read", nil))
+	}
+	return result.RuleGroup, nil
+}
+
+// setRuleGroupOrder updates the order of group, sending the rest of the rule
+// group as read.
+func (r *ParsingRulesOrderResource) setRuleGroupOrder(ctx context.Context, group *prgs.RuleGroup, order int64) error {
+	rq, err := ruleGroupOrderRequest(group, order)
+	if err != nil {
+		return fmt.Errorf("rule group %s: %s", group.GetId(), err)
+	}
+
+	_, httpResponse, err := r.client.
+		RuleGroupsServiceUpdateRuleGroup(ctx, group.GetId()).
+		RuleGroupsServiceCreateRuleGroupRequest(*rq).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("rule group %s: %s", group.GetId(), utils.FormatOpenAPIErrors(cxsdkOpenapi.NewAPIError(httpResponse, err), "Update", rq))
+	}
+	return nil
+}
+
+// ruleGroupOrderRequest returns the update request of group with order. The
+// group is copied through its JSON encoding rather than through the
+// coralogix_parsing_rules schema, so the fields and rule parameters the schema
+// doesn't cover are sent back unchanged. The IDs of the response are left out,
+// as the request doesn't have them.
+func ruleGroupOrderRequest(group *prgs.RuleGroup, order int64) (*prgs.RuleGroupsServiceCreateRuleGroupRequest, error) {
+	content, err := json.Marshal(group)
+	if err != nil {
+		return nil, err
+	}
+	var rq prgs.RuleGroupsServiceCreateRuleGroupRequest
+	if err := json.Unmarshal(content, &rq); err != nil {
+		return nil, err
+	}
+	rq.Order = &order
+	return &rq, nil
+}
+
+// orderedParsingRuleGroupIds returns the ids whose order falls within the
+// len(ids) orders from firstOrder, sorted by order. A group moved or pushed
+// out of these orders is left out, so the drift shows in the plan.
+func orderedParsingRuleGroupIds(ids []string, orders map[string]int64, firstOrder int64) []string {
+	lastOrder := firstOrder + int64(len(ids)) - 1
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if order, ok := orders[id]; ok && order >= firstOrder && order <= lastOrder {
+			result = append(result, id)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b string) int {
+		return cmp.Compare(orders[a], orders[b])
+	})
+	return result
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parsing_rules
+
+import (
+	"reflect"
+	"testing"
+
+	prgs "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/rule_groups_service"
+)
+
+func TestOrderedParsingRuleGroupIds(t *testing.T) {
+	ids := []string{"a", "b", "c"}
+	cases := []struct {
+		name       string
+		orders     map[string]int64
+		firstOrder int64
+		want       []string
+	}{
+		{"in order", map[string]int64{"a": 1, "b": 2, "c": 3}, 1, []string{"a", "b", "c"}},
+		{"reordered", map[string]int64{"a": 3, "b": 1, "c": 2}, 1, []string{"b", "c", "a"}},
+		{"group placed between", map[string]int64{"a": 1, "b": 3, "c": 4}, 1, []string{"a", "b"}},
+		{"group placed before", map[string]int64{"a": 2, "b": 3, "c": 4}, 1, []string{"a", "b"}},
+		{"from first_order", map[string]int64{"a": 5, "b": 6, "c": 7}, 5, []string{"a", "b", "c"}},
+		{"deleted group", map[string]int64{"a": 1, "c": 2}, 1, []string{"a", "c"}},
+	}
+	for _, tc := range cases {
+		if got := orderedParsingRuleGroupIds(ids, tc.orders, tc.firstOrder); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: orderedParsingRuleGroupIds() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRuleGroupOrderRequest(t *testing.T) {
+	id, name, creator, application := "group-1", "nginx", "team-a", "nginx"
+	hidden, order := true, int64(7)
+	group := &prgs.RuleGroup{
+		Id:      &id,
+		Name:    &name,
+		Creator: &creator,
+		Hidden:  &hidden,
+		Order:   &order,
+		RuleMatchers: []prgs.RuleMatcher{
+			{ApplicationName: &prgs.ApplicationNameConstraint{Value: &application}},
+		},
+	}
+
+	rq, err := ruleGroupOrderRequest(group, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rq.Order == nil || *rq.Order != 2 {
+		t.Errorf("order = %v, want 2", rq.Order)
+	}
+	if *group.Order != 7 {
+		t.Errorf("group order = %d, want the group left unchanged", *group.Order)
+	}
+	if rq.Name == nil || *rq.Name != name || rq.Creator == nil || *rq.Creator != creator || rq.Hidden == nil || !*rq.Hidden {
+		t.Errorf("request = %+v, want the name, creator and hidden of the group", rq)
+	}
+	if !reflect.DeepEqual(rq.RuleMatchers, group.RuleMatchers) {
+		t.Errorf("rule matchers = %+v, want %+v", rq.RuleMatchers, group.RuleMatchers)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parsing_rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRegularExpressionValidator(t *testing.T) {
+	for _, tc := range []struct {
+		expression  string
+		namedGroups bool
+		valid       bool
+	}{
+		{`sql_error_code\s*=\s*28000`, false, true},
+		{`.*{`, false, true},
+		{`(?P<severity>INFO|ERROR)`, true, true},
+		{`(?<severity>INFO|ERROR)`, true, true},
+		{`(INFO|ERROR)`, true, false},
+		{`(?<=user=)\w+`, false, false},
+		{`(\w)\1`, false, false},
+		{`[a-`, false, false},
+	} {
+		req := validator.StringRequest{
+			Path:        path.Root("rule_subgroups").AtListIndex(0).AtName("rules").AtListIndex(1).AtName("parse").AtName("regular_expression"),
+			ConfigValue: types.StringValue(tc.expression),
+		}
+		var resp validator.StringResponse
+		regularExpressionValidator{namedGroups: tc.namedGroups}.ValidateString(context.Background(), req, &resp)
+
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%q: got diagnostics %v, want valid = %v", tc.expression, resp.Diagnostics, tc.valid)
+		}
+		for _, d := range resp.Diagnostics.Errors() {
+			if d, ok := d.(interface{ Path() path.Path }); !ok || !d.Path().Equal(req.Path) {
+				t.Errorf("%q: error is not reported on %s", tc.expression, req.Path)
+			}
+		}
+	}
+}
@@ -47,6 +47,8 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	frameworkdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -126,6 +128,16 @@ func OldProvider() *oldSchema.Provider {
 				//ValidateFunc: validation.IsUUID,
 				Description: "A key for using coralogix APIs (Auto Generated), appropriate for the defined environment. environment variable 'CORALOGIX_API_KEY' can be defined instead.",
 			},
+			"validate_queries": {
+				Type:        oldSchema.TypeBool,
+				Optional:    true,
+				Description: "Check the PromQL syntax of `coralogix_dashboard` widget queries while planning, including the widgets of `content_json`. environment variable 'CORALOGIX_VALIDATE_QUERIES' can be defined instead.",
+			},
+			"query_metadata_source": {
+				Type:        oldSchema.TypeString,
+				Optional:    true,
+				Description: "Verifies that the metrics and labels referenced by `coralogix_dashboard` queries and variables exist when `validate_queries` is set. Either `coralogix` to look them up in the Coralogix metrics API, or the path of a JSON file mapping metric names to their label names. environment variable 'CORALOGIX_QUERY_METADATA_SOURCE' can be defined instead.",
+			},
 		},
 
 		DataSourcesMap: map[string]*oldSchema.Resource{
@@ -188,13 +200,16 @@ func OldProvider() *oldSchema.Provider {
 }
 
 type coralogixProviderModel struct {
-	Env    types.String `tfsdk:"env"`
-	Domain types.String `tfsdk:"domain"`
-	ApiKey types.String `tfsdk:"api_key"`
+	Env                 types.String `tfsdk:"env"`
+	Domain              types.String `tfsdk:"domain"`
+	ApiKey              types.String `tfsdk:"api_key"`
+	ValidateQueries     types.Bool   `tfsdk:"validate_queries"`
+	QueryMetadataSource types.String `tfsdk:"query_metadata_source"`
 }
 
 var (
-	_ provider.Provider = &coralogixProvider{}
+	_ provider.Provider              = &coralogixProvider{}
+	_ provider.ProviderWithFunctions = &coralogixProvider{}
 )
 
 func NewCoralogixProvider() provider.Provider {
@@ -230,6 +245,14 @@ func (p *coralogixProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Sensitive:   true,
 				Description: "A key for using coralogix APIs (Auto Generated), appropriate for the defined environment. environment variable 'CORALOGIX_API_KEY' can be defined instead.",
 			},
+			"validate_queries": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Check the PromQL syntax of `coralogix_dashboard` widget queries while planning, including the widgets of `content_json`. environment variable 'CORALOGIX_VALIDATE_QUERIES' can be defined instead.",
+			},
+			"query_metadata_source": schema.StringAttribute{
+				Optional:    true,
+				Description: "Verifies that the metrics and labels referenced by `coralogix_dashboard` queries and variables exist when `validate_queries` is set. Either `coralogix` to look them up in the Coralogix metrics API, or the path of a JSON file mapping metric names to their label names. environment variable 'CORALOGIX_QUERY_METADATA_SOURCE' can be defined instead.",
+			},
 		},
 	}
 }
@@ -353,23 +376,60 @@ func (p *coralogixProvider) Configure(ctx context.Context, req provider.Configur
 		sdkEnvironment = domain
 	}
 	clientSet := clientset.NewClientSet(sdkEnvironment, apiKey, targetUrl)
+	queryValidation, diags := configureQueryValidation(config, clientSet)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clientSet.SetQueryValidation(queryValidation)
 	resp.DataSourceData = clientSet
 	resp.ResourceData = clientSet
 }
 
+func configureQueryValidation(config coralogixProviderModel, clientSet *clientset.ClientSet) (clientset.QueryValidation, frameworkdiag.Diagnostics) {
+	var diags frameworkdiag.Diagnostics
+	validateQueries := strings.EqualFold(os.Getenv("CORALOGIX_VALIDATE_QUERIES"), "true")
+	if !config.ValidateQueries.IsNull() && !config.ValidateQueries.IsUnknown() {
+		validateQueries = config.ValidateQueries.ValueBool()
+	}
+	metadataSource := os.Getenv("CORALOGIX_QUERY_METADATA_SOURCE")
+	if !config.QueryMetadataSource.IsNull() && !config.QueryMetadataSource.IsUnknown() {
+		metadataSource = config.QueryMetadataSource.ValueString()
+	}
+
+	queryValidation := clientset.QueryValidation{Enabled: validateQueries}
+	switch {
+	case !validateQueries || metadataSource == "":
+	case metadataSource == "coralogix":
+		queryValidation.Metadata = clientset.NewCachedMetricsMetadataSource(clientSet.Metrics())
+	default:
+		source, err := clientset.NewFileMetricsMetadataSource(metadataSource)
+		if err != nil {
+			diags.AddAttributeError(path.Root("query_metadata_source"), "Invalid query metadata source", err.Error())
+			return queryValidation, diags
+		}
+		queryValidation.Metadata = clientset.NewCachedMetricsMetadataSource(source)
+	}
+	return queryValidation, diags
+}
+
 func (p *coralogixProvider) DataSources(context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		events2metrics.NewEvents2MetricDataSource,
+		events2metrics.NewEvents2MetricCardinalityDataSource,
 		actions.NewActionDataSource,
 		dataplans.NewTCOPoliciesLogsDataSource,
 		dataplans.NewTCOPoliciesTracesDataSource,
 		dataplans.NewTCOPoliciesRumDataSource,
 		dataplans.NewQuotaAllocationRuleSetDataSource,
 		dashboards.NewDashboardDataSource,
+		dashboards.NewDashboardQueriesDataSource,
 		integrations.NewWebhookDataSource,
 		recording_rules.NewRecordingRuleGroupSetDataSource,
 		dataengine.NewArchiveRetentionsDataSource,
 		metrics.NewArchiveMetricsDataSource,
+		metrics.NewMetricNamesDataSource,
+		metrics.NewMetricLabelValuesDataSource,
 		logs.NewArchiveLogsDataSource,
 		alerts.NewAlertsSchedulerDataSource,
 		apm.NewSLODataSource,
@@ -383,17 +443,29 @@ func (p *coralogixProvider) DataSources(context.Context) []func() datasource.Dat
 		aaa.NewIpAccessDataSource,
 		integrations.NewIntegrationDataSource,
 		alerts.NewAlertDataSource,
+		alerts.NewAlertTestDataSource,
 		notifications.NewConnectorDataSource,
 		notifications.NewGlobalRouterDataSource,
 		notifications.NewPresetDataSource,
 		parsing_rules.NewParsingRulesDataSource,
+		parsing_rules.NewParsingRulesSimulationDataSource,
 		enrichment_rules.NewDataEnrichmentDataSource,
 	}
 }
 
+func (p *coralogixProvider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		alerts.NewBusinessHoursScheduleFunction,
+		alerts.NewPriorityFromSeverityFunction,
+		dashboards.NewGrafanaToDashboardJSONFunction,
+		dashboards.NewRenderDashboardWidgetFunction,
+	}
+}
+
 func (p *coralogixProvider) Resources(context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		events2metrics.NewEvents2MetricResource,
+		events2metrics.NewEvents2MetricsSetResource,
 		actions.NewActionResource,
 		ai.NewAIEvaluationResource,
 		ai.NewAICustomEvaluationResource,
@@ -408,9 +480,13 @@ func (p *coralogixProvider) Resources(context.Context) []func() resource.Resourc
 		metrics.NewArchiveMetricsResource,
 		logs.NewArchiveLogsResource,
 		alerts.NewAlertsSchedulerResource,
+		alerts.NewMaintenanceWindowResource,
 		apm.NewSLOResource,
 		slo_mgmt.NewSLOV2Resource,
 		dashboards.NewDashboardsFolderResource,
+		dashboards.NewDashboardsFolderTreeResource,
+		dashboards.NewDashboardWidgetTemplateResource,
+		dashboards.NewDashboardVersionResource,
 		aaa.NewApiKeyResource,
 		aaa.NewCustomRoleSource,
 		aaa.NewGroupResource,
@@ -421,10 +497,13 @@ func (p *coralogixProvider) Resources(context.Context) []func() resource.Resourc
 		aaa.NewTeamResource,
 		integrations.NewIntegrationResource,
 		alerts.NewAlertResource,
+		alerts.NewAlertsSetResource,
+		alerts.NewPrometheusAlertRulesResource,
 		notifications.NewConnectorResource,
 		notifications.NewGlobalRouterResource,
 		notifications.NewPresetResource,
 		parsing_rules.NewParsingRulesResource,
+		parsing_rules.NewParsingRulesOrderResource,
 		enrichment_rules.NewDataEnrichmentsResource,
 	}
 }
@@ -27,6 +27,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ datasource.DataSourceWithConfigure = &RecordingRuleGroupSetDataSource{}
@@ -39,6 +40,15 @@ type RecordingRuleGroupSetDataSource struct {
 	client *recRuless.RecordingRulesServiceAPIService
 }
 
+// RecordingRuleGroupSetDataSourceModel is RecordingRuleGroupSetResourceModel
+// without tests_yaml and lint, which only check the rules a resource is given.
+type RecordingRuleGroupSetDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	YamlContent types.String `tfsdk:"yaml_content"`
+	Groups      types.Set    `tfsdk:"groups"` //RecordingRuleGroupModel
+	Name        types.String `tfsdk:"name"`
+}
+
 func (d *RecordingRuleGroupSetDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_recording_rules_groups_set"
 }
@@ -66,10 +76,12 @@ func (d *RecordingRuleGroupSetDataSource) Schema(ctx context.Context, _ datasour
 	r.Schema(ctx, resource.SchemaRequest{}, &resourceResp)
 
 	resp.Schema = utils.FrameworkDatasourceSchemaFromFrameworkResourceSchema(resourceResp.Schema)
+	delete(resp.Schema.Attributes, "tests_yaml")
+	delete(resp.Schema.Attributes, "lint")
 }
 
 func (d *RecordingRuleGroupSetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data *RecordingRuleGroupSetResourceModel
+	var data RecordingRuleGroupSetDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -95,11 +107,17 @@ func (d *RecordingRuleGroupSetDataSource) Read(ctx context.Context, req datasour
 		return
 	}
 
-	state, diags := flattenRecordingRuleGroupSet(ctx, data, result)
+	state, diags := flattenRecordingRuleGroupSet(ctx, &RecordingRuleGroupSetResourceModel{YamlContent: data.YamlContent}, result)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	data = RecordingRuleGroupSetDataSourceModel{
+		ID:          state.ID,
+		YamlContent: state.YamlContent,
+		Groups:      state.Groups,
+		Name:        state.Name,
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
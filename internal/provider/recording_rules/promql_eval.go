@@ -0,0 +1,1467 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording_rules
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+)
+
+// promLookbackDelta is how far back an instant vector selector looks for the
+// latest sample of a series, as in Prometheus.
+const promLookbackDelta = 5 * time.Minute
+
+// promLabels is the label set of a series, including __name__.
+type promLabels map[string]string
+
+// key identifies a label set, independently of the order of its labels.
+func (l promLabels) key() string {
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(l)) {
+		b.WriteString(name)
+		b.WriteByte(0xff)
+		b.WriteString(l[name])
+		b.WriteByte(0xff)
+	}
+	return b.String()
+}
+
+func (l promLabels) without(names ...string) promLabels {
+	result := maps.Clone(l)
+	for _, name := range names {
+		delete(result, name)
+	}
+	return result
+}
+
+func (l promLabels) keep(names ...string) promLabels {
+	result := promLabels{}
+	for _, name := range names {
+		if value, ok := l[name]; ok {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+// String formats the labels in the series notation of promtool, such as
+// http_requests_total{job="api"}.
+func (l promLabels) String() string {
+	var pairs []string
+	for _, name := range slices.Sorted(maps.Keys(l)) {
+		if name != "__name__" {
+			pairs = append(pairs, fmt.Sprintf("%s=%q", name, l[name]))
+		}
+	}
+	return l["__name__"] + "{" + strings.Join(pairs, ", ") + "}"
+}
+
+type promSample struct {
+	t     int64 // milliseconds
+	v     float64
+	stale bool
+}
+
+type promSeries struct {
+	labels  promLabels
+	samples []promSample
+}
+
+// promStorage holds the series the rules are evaluated against. Samples of a
+// series are appended in time order.
+type promStorage struct {
+	series map[string]*promSeries
+	order  []string
+}
+
+func newPromStorage() *promStorage {
+	return &promStorage{series: map[string]*promSeries{}}
+}
+
+func (s *promStorage) append(labels promLabels, sample promSample) {
+	key := labels.key()
+	series, ok := s.series[key]
+	if !ok {
+		series = &promSeries{labels: labels}
+		s.series[key] = series
+		s.order = append(s.order, key)
+	}
+	series.samples = append(series.samples, sample)
+}
+
+type promScalar float64
+
+type promString string
+
+type promVectorSample struct {
+	labels promLabels
+	t      int64
+	v      float64
+}
+
+type promVector []promVectorSample
+
+type promMatrixSeries struct {
+	labels  promLabels
+	samples []promSample
+}
+
+type promMatrix []promMatrixSeries
+
+// promEngine evaluates PromQL expressions over a promStorage, the way an
+// instant query of Prometheus does.
+type promEngine struct {
+	storage *promStorage
+	// defaultStep is the step of subqueries that don't set one.
+	defaultStep time.Duration
+}
+
+func (e *promEngine) eval(expr utils.PromQLExpr, ts int64) (any, error) {
+	switch expr := expr.(type) {
+	case *utils.PromQLNumberLiteral:
+		return promScalar(expr.Value), nil
+	case *utils.PromQLStringLiteral:
+		return promString(expr.Value), nil
+	case *utils.PromQLVariable:
+		return nil, fmt.Errorf("variable %s can't be evaluated", expr.Name)
+	case *utils.PromQLParenExpr:
+		return e.eval(expr.Expr, ts)
+	case *utils.PromQLUnaryExpr:
+		value, err := e.eval(expr.Expr, ts)
+		if err != nil {
+			return nil, err
+		}
+		switch value := value.(type) {
+		case promScalar:
+			return -value, nil
+		case promVector:
+			result := make(promVector, len(value))
+			for i, sample := range value {
+				result[i] = promVectorSample{labels: sample.labels.without("__name__"), t: sample.t, v: -sample.v}
+			}
+			return result, nil
+		}
+		return nil, fmt.Errorf("unary expression only allowed on expressions of type scalar or instant vector, got %s", promValueType(value))
+	case *utils.PromQLVectorSelector:
+		return e.selectVector(expr, ts)
+	case *utils.PromQLMatrixSelector:
+		return e.selectMatrix(expr, ts)
+	case *utils.PromQLSubqueryExpr:
+		return e.evalSubquery(expr, ts)
+	case *utils.PromQLBinaryExpr:
+		return e.evalBinary(expr, ts)
+	case *utils.PromQLAggregateExpr:
+		return e.evalAggregate(expr, ts)
+	case *utils.PromQLCall:
+		return e.evalCall(expr, ts)
+	}
+	return nil, fmt.Errorf("unsupported expression %T", expr)
+}
+
+func promValueType(value any) string {
+	switch value.(type) {
+	case promScalar:
+		return "scalar"
+	case promString:
+		return "string"
+	case promVector:
+		return "instant vector"
+	case promMatrix:
+		return "range vector"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func (e *promEngine) evalVector(expr utils.PromQLExpr, ts int64) (promVector, error) {
+	value, err := e.eval(expr, ts)
+	if err != nil {
+		return nil, err
+	}
+	vector, ok := value.(promVector)
+	if !ok {
+		return nil, fmt.Errorf("expected instant vector, got %s", promValueType(value))
+	}
+	return vector, nil
+}
+
+func (e *promEngine) evalMatrix(expr utils.PromQLExpr, ts int64) (promMatrix, error) {
+	value, err := e.eval(expr, ts)
+	if err != nil {
+		return nil, err
+	}
+	matrix, ok := value.(promMatrix)
+	if !ok {
+		return nil, fmt.Errorf("expected range vector, got %s", promValueType(value))
+	}
+	return matrix, nil
+}
+
+func (e *promEngine) evalScalar(expr utils.PromQLExpr, ts int64) (float64, error) {
+	value, err := e.eval(expr, ts)
+	if err != nil {
+		return 0, err
+	}
+	scalar, ok := value.(promScalar)
+	if !ok {
+		return 0, fmt.Errorf("expected scalar, got %s", promValueType(value))
+	}
+	return float64(scalar), nil
+}
+
+func (e *promEngine) evalString(expr utils.PromQLExpr, ts int64) (string, error) {
+	value, err := e.eval(expr, ts)
+	if err != nil {
+		return "", err
+	}
+	s, ok := value.(promString)
+	if !ok {
+		return "", fmt.Errorf("expected string, got %s", promValueType(value))
+	}
+	return string(s), nil
+}
+
+// referenceTime applies the offset and @ modifiers of a selector or subquery
+// to the evaluation time.
+func referenceTime(offset, at string, ts int64) (int64, error) {
+	if at != "" && at != "start()" && at != "end()" {
+		seconds, err := strconv.ParseFloat(at, 64)
+		if err != nil {
+			return 0, fmt.Errorf("bad @ modifier %q", at)
+		}
+		ts = int64(seconds * 1000)
+	}
+	if offset != "" {
+		duration, err := utils.ParsePromQLDuration(strings.TrimPrefix(offset, "-"))
+		if err != nil {
+			return 0, err
+		}
+		if strings.HasPrefix(offset, "-") {
+			duration = -duration
+		}
+		ts -= duration.Milliseconds()
+	}
+	return ts, nil
+}
+
+func promDurationMillis(text string) (int64, error) {
+	duration, err := utils.ParsePromQLDuration(text)
+	if err != nil {
+		return 0, err
+	}
+	return duration.Milliseconds(), nil
+}
+
+func (e *promEngine) selectSeries(selector *utils.PromQLVectorSelector) ([]*promSeries, error) {
+	matchers := selector.Matchers
+	if selector.Metric != "" {
+		matchers = append([]utils.PromQLMatcher{{Name: "__name__", Type: "=", Value: selector.Metric}}, matchers...)
+	}
+	matches := make([]func(string) bool, len(matchers))
+	for i, matcher := range matchers {
+		switch matcher.Type {
+		case "=":
+			matches[i] = func(value string) bool { return value == matcher.Value }
+		case "!=":
+			matches[i] = func(value string) bool { return value != matcher.Value }
+		case "=~", "!~":
+			re, err := regexp.Compile("^(?:" + matcher.Value + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %s", matcher.Value, err)
+			}
+			negate := matcher.Type == "!~"
+			matches[i] = func(value string) bool { return re.MatchString(value) != negate }
+		}
+	}
+
+	var result []*promSeries
+	for _, key := range e.storage.order {
+		series := e.storage.series[key]
+		if promSeriesMatches(series.labels, matchers, matches) {
+			result = append(result, series)
+		}
+	}
+	return result, nil
+}
+
+func promSeriesMatches(labels promLabels, matchers []utils.PromQLMatcher, matches []func(string) bool) bool {
+	for i, matcher := range matchers {
+		// A missing label matches like an empty one.
+		if !matches[i](labels[matcher.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *promEngine) selectVector(selector *utils.PromQLVectorSelector, ts int64) (promVector, error) {
+	ref, err := referenceTime(selector.Offset, selector.At, ts)
+	if err != nil {
+		return nil, err
+	}
+	series, err := e.selectSeries(selector)
+	if err != nil {
+		return nil, err
+	}
+	var result promVector
+	for _, s := range series {
+		i, _ := slices.BinarySearchFunc(s.samples, ref+1, func(sample promSample, t int64) int { return cmp.Compare(sample.t, t) })
+		if i == 0 {
+			continue
+		}
+		sample := s.samples[i-1]
+		if sample.stale || sample.t <= ref-promLookbackDelta.Milliseconds() {
+			continue
+		}
+		result = append(result, promVectorSample{labels: s.labels, t: ts, v: sample.v})
+	}
+	return result, nil
+}
+
+func (e *promEngine) selectMatrix(matrix *utils.PromQLMatrixSelector, ts int64) (promMatrix, error) {
+	ref, err := referenceTime(matrix.Selector.Offset, matrix.Selector.At, ts)
+	if err != nil {
+		return nil, err
+	}
+	window, err := promDurationMillis(matrix.Range)
+	if err != nil {
+		return nil, err
+	}
+	series, err := e.selectSeries(matrix.Selector)
+	if err != nil {
+		return nil, err
+	}
+	var result promMatrix
+	for _, s := range series {
+		var samples []promSample
+		for _, sample := range s.samples {
+			if sample.t > ref-window && sample.t <= ref && !sample.stale {
+				samples = append(samples, sample)
+			}
+		}
+		if len(samples) > 0 {
+			result = append(result, promMatrixSeries{labels: s.labels, samples: samples})
+		}
+	}
+	return result, nil
+}
+
+func (e *promEngine) evalSubquery(subquery *utils.PromQLSubqueryExpr, ts int64) (promMatrix, error) {
+	ref, err := referenceTime(subquery.Offset, subquery.At, ts)
+	if err != nil {
+		return nil, err
+	}
+	window, err := promDurationMillis(subquery.Range)
+	if err != nil {
+		return nil, err
+	}
+	step := e.defaultStep.Milliseconds()
+	if subquery.Step != "" {
+		if step, err = promDurationMillis(subquery.Step); err != nil {
+			return nil, err
+		}
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("zero or negative subquery step %q", subquery.Step)
+	}
+
+	// Like Prometheus, the steps are aligned to multiples of the step.
+	start := ref - window
+	start -= start % step
+	if start <= ref-window {
+		start += step
+	}
+	var result promMatrix
+	index := map[string]int{}
+	for t := start; t <= ref; t += step {
+		value, err := e.eval(subquery.Expr, t)
+		if err != nil {
+			return nil, err
+		}
+		var vector promVector
+		switch value := value.(type) {
+		case promScalar:
+			vector = promVector{{labels: promLabels{}, t: t, v: float64(value)}}
+		case promVector:
+			vector = value
+		default:
+			return nil, fmt.Errorf("subquery is only allowed on instant vector or scalar, got %s", promValueType(value))
+		}
+		for _, sample := range vector {
+			key := sample.labels.key()
+			i, ok := index[key]
+			if !ok {
+				i = len(result)
+				index[key] = i
+				result = append(result, promMatrixSeries{labels: sample.labels})
+			}
+			result[i].samples = append(result[i].samples, promSample{t: t, v: sample.v})
+		}
+	}
+	return result, nil
+}
+
+var promComparisonOperators = []string{"==", "!=", ">", "<", ">=", "<="}
+
+func promBinaryOp(op string, l, r float64) (float64, bool, error) {
+	switch op {
+	case "+":
+		return l + r, true, nil
+	case "-":
+		return l - r, true, nil
+	case "*":
+		return l * r, true, nil
+	case "/":
+		return l / r, true, nil
+	case "%":
+		return math.Mod(l, r), true, nil
+	case "^":
+		return math.Pow(l, r), true, nil
+	case "atan2":
+		return math.Atan2(l, r), true, nil
+	case "==":
+		return l, l == r, nil
+	case "!=":
+		return l, l != r, nil
+	case ">":
+		return l, l > r, nil
+	case "<":
+		return l, l < r, nil
+	case ">=":
+		return l, l >= r, nil
+	case "<=":
+		return l, l <= r, nil
+	}
+	return 0, false, fmt.Errorf("operator %q not allowed for scalar or instant vector operands", op)
+}
+
+func promBool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (e *promEngine) evalBinary(binary *utils.PromQLBinaryExpr, ts int64) (any, error) {
+	lhs, err := e.eval(binary.LHS, ts)
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := e.eval(binary.RHS, ts)
+	if err != nil {
+		return nil, err
+	}
+	comparison := slices.Contains(promComparisonOperators, binary.Op)
+	setOperation := slices.Contains([]string{"and", "or", "unless"}, binary.Op)
+
+	lv, lVector := lhs.(promVector)
+	rv, rVector := rhs.(promVector)
+	ls, lScalar := lhs.(promScalar)
+	rs, rScalar := rhs.(promScalar)
+	switch {
+	case setOperation:
+		if !lVector || !rVector {
+			return nil, fmt.Errorf("set operator %q not allowed in binary scalar expression", binary.Op)
+		}
+		return promSetOperation(binary, lv, rv), nil
+	case lScalar && rScalar:
+		if comparison && !binary.ReturnBool {
+			return nil, fmt.Errorf("comparisons between scalars must use BOOL modifier")
+		}
+		value, keep, err := promBinaryOp(binary.Op, float64(ls), float64(rs))
+		if comparison {
+			value = promBool(keep)
+		}
+		return promScalar(value), err
+	case lVector && rScalar:
+		return promVectorScalarOperation(binary, lv, float64(rs), false)
+	case lScalar && rVector:
+		return promVectorScalarOperation(binary, rv, float64(ls), true)
+	case lVector && rVector:
+		return promVectorOperation(binary, lv, rv)
+	}
+	return nil, fmt.Errorf("binary expression must contain only scalar and instant vector types, got %s and %s", promValueType(lhs), promValueType(rhs))
+}
+
+func promVectorScalarOperation(binary *utils.PromQLBinaryExpr, vector promVector, scalar float64, swap bool) (promVector, error) {
+	comparison := slices.Contains(promComparisonOperators, binary.Op)
+	var result promVector
+	for _, sample := range vector {
+		l, r := sample.v, scalar
+		if swap {
+			l, r = r, l
+		}
+		value, keep, err := promBinaryOp(binary.Op, l, r)
+		if err != nil {
+			return nil, err
+		}
+		labels := sample.labels
+		switch {
+		case comparison && binary.ReturnBool:
+			value = promBool(keep)
+			labels = labels.without("__name__")
+		case comparison:
+			if !keep {
+				continue
+			}
+			value = sample.v
+		default:
+			labels = labels.without("__name__")
+		}
+		result = append(result, promVectorSample{labels: labels, t: sample.t, v: value})
+	}
+	return result, nil
+}
+
+// promMatchingSignature returns the labels identifying the samples matched
+// across the sides of a vector operation.
+func promMatchingSignature(matching *utils.PromQLVectorMatching) func(promLabels) string {
+	return func(labels promLabels) string {
+		if matching != nil && matching.On {
+			return labels.keep(matching.Labels...).key()
+		}
+		ignored := []string{"__name__"}
+		if matching != nil {
+			ignored = append(ignored, matching.Labels...)
+		}
+		return labels.without(ignored...).key()
+	}
+}
+
+func promSetOperation(binary *utils.PromQLBinaryExpr, lhs, rhs promVector) promVector {
+	signature := promMatchingSignature(binary.Matching)
+	rightSignatures := map[string]bool{}
+	for _, sample := range rhs {
+		rightSignatures[signature(sample.labels)] = true
+	}
+	var result promVector
+	switch binary.Op {
+	case "and":
+		for _, sample := range lhs {
+			if rightSignatures[signature(sample.labels)] {
+				result = append(result, sample)
+			}
+		}
+	case "unless":
+		for _, sample := range lhs {
+			if !rightSignatures[signature(sample.labels)] {
+				result = append(result, sample)
+			}
+		}
+	case "or":
+		leftSignatures := map[string]bool{}
+		for _, sample := range lhs {
+			leftSignatures[signature(sample.labels)] = true
+			result = append(result, sample)
+		}
+		for _, sample := range rhs {
+			if !leftSignatures[signature(sample.labels)] {
+				result = append(result, sample)
+			}
+		}
+	}
+	return result
+}
+
+func promVectorOperation(binary *utils.PromQLBinaryExpr, lhs, rhs promVector) (promVector, error) {
+	matching := binary.Matching
+	if matching == nil {
+		matching = &utils.PromQLVectorMatching{}
+	}
+	comparison := slices.Contains(promComparisonOperators, binary.Op)
+	signature := promMatchingSignature(binary.Matching)
+
+	// The "many" side is iterated and the "one" side is looked up.
+	many, one, swapped := lhs, rhs, false
+	if matching.GroupRight {
+		many, one, swapped = rhs, lhs, true
+	}
+	oneBySignature := map[string]promVectorSample{}
+	for _, sample := range one {
+		s := signature(sample.labels)
+		if _, ok := oneBySignature[s]; ok {
+			return nil, fmt.Errorf("found duplicate series for the match group %s on the %s hand-side of the operation; many-to-many matching not allowed: matching labels must be unique on one side", sample.labels.without("__name__"), map[bool]string{false: "right", true: "left"}[swapped])
+		}
+		oneBySignature[s] = sample
+	}
+
+	oneToOne := !matching.GroupLeft && !matching.GroupRight
+	matched := map[string]bool{}
+	var result promVector
+	for _, sample := range many {
+		s := signature(sample.labels)
+		other, ok := oneBySignature[s]
+		if !ok {
+			continue
+		}
+		l, r := sample.v, other.v
+		if swapped {
+			l, r = r, l
+		}
+		value, keep, err := promBinaryOp(binary.Op, l, r)
+		if err != nil {
+			return nil, err
+		}
+		if comparison {
+			if binary.ReturnBool {
+				value = promBool(keep)
+			} else if !keep {
+				continue
+			}
+		}
+
+		labels := maps.Clone(sample.labels)
+		if !comparison || binary.ReturnBool {
+			delete(labels, "__name__")
+		}
+		if oneToOne {
+			if matching.On {
+				labels = labels.keep(matching.Labels...)
+			} else {
+				labels = labels.without(matching.Labels...)
+			}
+		}
+		for _, name := range matching.Include {
+			if value := other.labels[name]; value != "" {
+				labels[name] = value
+			} else {
+				delete(labels, name)
+			}
+		}
+
+		key := s
+		if !oneToOne {
+			key = labels.key()
+		}
+		if matched[key] {
+			if oneToOne {
+				return nil, fmt.Errorf("multiple matches for labels: many-to-one matching must be explicit (group_left/group_right)")
+			}
+			return nil, fmt.Errorf("multiple matches for labels: grouping labels must ensure unique matches")
+		}
+		matched[key] = true
+		result = append(result, promVectorSample{labels: labels, t: sample.t, v: value})
+	}
+	return result, nil
+}
+
+func (e *promEngine) evalAggregate(aggregate *utils.PromQLAggregateExpr, ts int64) (promVector, error) {
+	vector, err := e.evalVector(aggregate.Expr, ts)
+	if err != nil {
+		return nil, err
+	}
+	var param float64
+	var label string
+	switch aggregate.Op {
+	case "topk", "bottomk", "quantile":
+		if param, err = e.evalScalar(aggregate.Param, ts); err != nil {
+			return nil, err
+		}
+	case "count_values":
+		if label, err = e.evalString(aggregate.Param, ts); err != nil {
+			return nil, err
+		}
+	case "limitk", "limit_ratio":
+		return nil, fmt.Errorf("aggregation %q is not supported by the offline evaluator", aggregate.Op)
+	}
+
+	groupLabels := func(labels promLabels) promLabels {
+		if aggregate.Without {
+			return labels.without(append([]string{"__name__"}, aggregate.Grouping...)...)
+		}
+		return labels.keep(aggregate.Grouping...)
+	}
+	type group struct {
+		labels  promLabels
+		samples promVector
+	}
+	var groups []*group
+	index := map[string]*group{}
+	for _, sample := range vector {
+		labels := groupLabels(sample.labels)
+		if aggregate.Op == "count_values" {
+			labels[label] = strconv.FormatFloat(sample.v, 'f', -1, 64)
+		}
+		key := labels.key()
+		g, ok := index[key]
+		if !ok {
+			g = &group{labels: labels}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.samples = append(g.samples, sample)
+	}
+
+	var result promVector
+	for _, g := range groups {
+		values := make([]float64, len(g.samples))
+		for i, sample := range g.samples {
+			values[i] = sample.v
+		}
+		var value float64
+		switch aggregate.Op {
+		case "sum":
+			for _, v := range values {
+				value += v
+			}
+		case "avg":
+			for _, v := range values {
+				value += v
+			}
+			value /= float64(len(values))
+		case "min", "max":
+			value = values[0]
+			for _, v := range values[1:] {
+				if math.IsNaN(value) || (aggregate.Op == "min" && v < value) || (aggregate.Op == "max" && v > value) {
+					value = v
+				}
+			}
+		case "count", "count_values":
+			value = float64(len(values))
+		case "group":
+			value = 1
+		case "stddev", "stdvar":
+			value = promVariance(values)
+			if aggregate.Op == "stddev" {
+				value = math.Sqrt(value)
+			}
+		case "quantile":
+			value = promQuantile(param, values)
+		case "topk", "bottomk":
+			samples := slices.Clone(g.samples)
+			slices.SortStableFunc(samples, func(a, b promVectorSample) int {
+				switch {
+				case math.IsNaN(a.v) && math.IsNaN(b.v):
+					return 0
+				case math.IsNaN(a.v):
+					return 1
+				case math.IsNaN(b.v):
+					return -1
+				case aggregate.Op == "topk":
+					return cmp.Compare(b.v, a.v)
+				default:
+					return cmp.Compare(a.v, b.v)
+				}
+			})
+			k := int(param)
+			if k < 0 {
+				k = 0
+			}
+			result = append(result, samples[:min(k, len(samples))]...)
+			continue
+		default:
+			return nil, fmt.Errorf("aggregation %q is not supported by the offline evaluator", aggregate.Op)
+		}
+		result = append(result, promVectorSample{labels: g.labels, t: ts, v: value})
+	}
+	return result, nil
+}
+
+func promVariance(values []float64) float64 {
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return variance / float64(len(values))
+}
+
+// promQuantile interpolates the q-quantile of values like Prometheus does.
+func promQuantile(q float64, values []float64) float64 {
+	switch {
+	case len(values) == 0 || math.IsNaN(q):
+		return math.NaN()
+	case q < 0:
+		return math.Inf(-1)
+	case q > 1:
+		return math.Inf(1)
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	n := float64(len(sorted))
+	rank := q * (n - 1)
+	lower := math.Max(0, math.Floor(rank))
+	upper := math.Min(n-1, lower+1)
+	weight := rank - math.Floor(rank)
+	return sorted[int(lower)]*(1-weight) + sorted[int(upper)]*weight
+}
+
+// promNamePreservingFunctions keep the metric name of their input.
+var promNamePreservingFunctions = []string{"last_over_time", "label_join", "label_replace", "sort", "sort_desc"}
+
+var promMathFunctions = map[string]func(float64) float64{
+	"abs": math.Abs, "ceil": math.Ceil, "floor": math.Floor, "exp": math.Exp, "sqrt": math.Sqrt,
+	"ln": math.Log, "log2": math.Log2, "log10": math.Log10,
+	"sin": math.Sin, "cos": math.Cos, "tan": math.Tan, "asin": math.Asin, "acos": math.Acos, "atan": math.Atan,
+	"sinh": math.Sinh, "cosh": math.Cosh, "tanh": math.Tanh, "asinh": math.Asinh, "acosh": math.Acosh, "atanh": math.Atanh,
+	"deg": func(v float64) float64 { return v * 180 / math.Pi },
+	"rad": func(v float64) float64 { return v * math.Pi / 180 },
+	"sgn": func(v float64) float64 {
+		switch {
+		case v < 0:
+			return -1
+		case v > 0:
+			return 1
+		}
+		return v
+	},
+}
+
+var promTimeFunctions = map[string]func(time.Time) float64{
+	"day_of_month": func(t time.Time) float64 { return float64(t.Day()) },
+	"day_of_week":  func(t time.Time) float64 { return float64(t.Weekday()) },
+	"day_of_year":  func(t time.Time) float64 { return float64(t.YearDay()) },
+	"days_in_month": func(t time.Time) float64 {
+		return float64(time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day())
+	},
+	"hour":   func(t time.Time) float64 { return float64(t.Hour()) },
+	"minute": func(t time.Time) float64 { return float64(t.Minute()) },
+	"month":  func(t time.Time) float64 { return float64(t.Month()) },
+	"year":   func(t time.Time) float64 { return float64(t.Year()) },
+}
+
+// promRangeFunctions reduce the samples of a range to a value. ts and window
+// are the end and length of the range, in milliseconds. ok is false when the
+// samples are not enough to compute a value.
+var promRangeFunctions = map[string]func(samples []promSample, ts, window int64) (value float64, ok bool){
+	"rate": func(s []promSample, ts, window int64) (float64, bool) {
+		return promExtrapolatedRate(s, ts, window, true, true)
+	},
+	"increase": func(s []promSample, ts, window int64) (float64, bool) {
+		return promExtrapolatedRate(s, ts, window, true, false)
+	},
+	"delta": func(s []promSample, ts, window int64) (float64, bool) {
+		return promExtrapolatedRate(s, ts, window, false, false)
+	},
+	"irate":  func(s []promSample, _, _ int64) (float64, bool) { return promInstantRate(s, true) },
+	"idelta": func(s []promSample, _, _ int64) (float64, bool) { return promInstantRate(s, false) },
+	"deriv": func(s []promSample, _, _ int64) (float64, bool) {
+		if len(s) < 2 {
+			return 0, false
+		}
+		slope, _ := promLinearRegression(s, s[0].t)
+		return slope, true
+	},
+	"changes": func(s []promSample, _, _ int64) (float64, bool) {
+		var changes float64
+		for i := 1; i < len(s); i++ {
+			if s[i].v != s[i-1].v && !(math.IsNaN(s[i].v) && math.IsNaN(s[i-1].v)) {
+				changes++
+			}
+		}
+		return changes, true
+	},
+	"resets": func(s []promSample, _, _ int64) (float64, bool) {
+		var resets float64
+		for i := 1; i < len(s); i++ {
+			if s[i].v < s[i-1].v {
+				resets++
+			}
+		}
+		return resets, true
+	},
+	"avg_over_time": func(s []promSample, _, _ int64) (float64, bool) {
+		var sum float64
+		for _, sample := range s {
+			sum += sample.v
+		}
+		return sum / float64(len(s)), true
+	},
+	"sum_over_time": func(s []promSample, _, _ int64) (float64, bool) {
+		var sum float64
+		for _, sample := range s {
+			sum += sample.v
+		}
+		return sum, true
+	},
+	"min_over_time": func(s []promSample, _, _ int64) (float64, bool) {
+		value := s[0].v
+		for _, sample := range s[1:] {
+			if sample.v < value || math.IsNaN(value) {
+				value = sample.v
+			}
+		}
+		return value, true
+	},
+	"max_over_time": func(s []promSample, _, _ int64) (float64, bool) {
+		value := s[0].v
+		for _, sample := range s[1:] {
+			if sample.v > value || math.IsNaN(value) {
+				value = sample.v
+			}
+		}
+		return value, true
+	},
+	"count_over_time":   func(s []promSample, _, _ int64) (float64, bool) { return float64(len(s)), true },
+	"last_over_time":    func(s []promSample, _, _ int64) (float64, bool) { return s[len(s)-1].v, true },
+	"present_over_time": func(s []promSample, _, _ int64) (float64, bool) { return 1, true },
+	"stddev_over_time": func(s []promSample, _, _ int64) (float64, bool) {
+		return math.Sqrt(promVariance(promSampleValues(s))), true
+	},
+	"stdvar_over_time": func(s []promSample, _, _ int64) (float64, bool) {
+		return promVariance(promSampleValues(s)), true
+	},
+}
+
+func promSampleValues(samples []promSample) []float64 {
+	values := make([]float64, len(samples))
+	for i, sample := range samples {
+		values[i] = sample.v
+	}
+	return values
+}
+
+// promExtrapolatedRate implements rate, increase and delta, extrapolating the
+// samples to the edges of the range like Prometheus does.
+func promExtrapolatedRate(samples []promSample, ts, window int64, isCounter, isRate bool) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	first, last := samples[0], samples[len(samples)-1]
+	result := last.v - first.v
+	if isCounter {
+		previous := first.v
+		for _, sample := range samples[1:] {
+			if sample.v < previous {
+				result += previous
+			}
+			previous = sample.v
+		}
+	}
+
+	durationToStart := float64(first.t-(ts-window)) / 1000
+	durationToEnd := float64(ts-last.t) / 1000
+	sampledInterval := float64(last.t-first.t) / 1000
+	averageDurationBetweenSamples := sampledInterval / float64(len(samples)-1)
+	if isCounter && result > 0 && first.v >= 0 {
+		// Counters can't go below zero, so don't extrapolate past the time
+		// the counter would have been zero.
+		durationToZero := sampledInterval * (first.v / result)
+		if durationToZero < durationToStart {
+			durationToStart = durationToZero
+		}
+	}
+
+	threshold := averageDurationBetweenSamples * 1.1
+	extrapolateToInterval := sampledInterval
+	if durationToStart < threshold {
+		extrapolateToInterval += durationToStart
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+	if durationToEnd < threshold {
+		extrapolateToInterval += durationToEnd
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+	result *= extrapolateToInterval / sampledInterval
+	if isRate {
+		result /= float64(window) / 1000
+	}
+	return result, true
+}
+
+func promInstantRate(samples []promSample, isRate bool) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	last, previous := samples[len(samples)-1], samples[len(samples)-2]
+	result := last.v - previous.v
+	if !isRate {
+		return result, true
+	}
+	if last.v < previous.v {
+		result = last.v
+	}
+	return result / (float64(last.t-previous.t) / 1000), true
+}
+
+// promLinearRegression fits the samples to a line, returning its slope per
+// second and its value at interceptTime.
+func promLinearRegression(samples []promSample, interceptTime int64) (slope, intercept float64) {
+	var n, sumX, sumY, sumXY, sumX2 float64
+	for _, sample := range samples {
+		x := float64(sample.t-interceptTime) / 1000
+		n++
+		sumX += x
+		sumY += sample.v
+		sumXY += x * sample.v
+		sumX2 += x * x
+	}
+	covXY := sumXY - sumX*sumY/n
+	varX := sumX2 - sumX*sumX/n
+	slope = covXY / varX
+	intercept = sumY/n - slope*sumX/n
+	return slope, intercept
+}
+
+func (e *promEngine) evalCall(call *utils.PromQLCall, ts int64) (any, error) {
+	name := call.Func
+	dropName := func(labels promLabels) promLabels {
+		if slices.Contains(promNamePreservingFunctions, name) {
+			return labels
+		}
+		return labels.without("__name__")
+	}
+	wantArgs := func(counts ...int) error {
+		if !slices.Contains(counts, len(call.Args)) {
+			return fmt.Errorf("wrong number of arguments for function %q", name)
+		}
+		return nil
+	}
+
+	if reduce, ok := promRangeFunctions[name]; ok {
+		if err := wantArgs(1); err != nil {
+			return nil, err
+		}
+		return e.evalRangeFunction(call.Args[0], ts, dropName, reduce)
+	}
+	if f, ok := promMathFunctions[name]; ok {
+		if err := wantArgs(1); err != nil {
+			return nil, err
+		}
+		return e.mapVector(call.Args[0], ts, dropName, f)
+	}
+	if f, ok := promTimeFunctions[name]; ok {
+		if err := wantArgs(0, 1); err != nil {
+			return nil, err
+		}
+		if len(call.Args) == 0 {
+			return promVector{{labels: promLabels{}, t: ts, v: f(time.UnixMilli(ts).UTC())}}, nil
+		}
+		return e.mapVector(call.Args[0], ts, dropName, func(v float64) float64 {
+			return f(time.UnixMilli(int64(v * 1000)).UTC())
+		})
+	}
+
+	switch name {
+	case "time":
+		return promScalar(float64(ts) / 1000), wantArgs(0)
+	case "pi":
+		return promScalar(math.Pi), wantArgs(0)
+	case "vector":
+		if err := wantArgs(1); err != nil {
+			return nil, err
+		}
+		value, err := e.evalScalar(call.Args[0], ts)
+		if err != nil {
+			return nil, err
+		}
+		return promVector{{labels: promLabels{}, t: ts, v: value}}, nil
+	case "scalar":
+		if err := wantArgs(1); err != nil {
+			return nil, err
+		}
+		vector, err := e.evalVector(call.Args[0], ts)
+		if err != nil {
+			return nil, err
+		}
+		if len(vector) != 1 {
+			return promScalar(math.NaN()), nil
+		}
+		return promScalar(vector[0].v), nil
+	case "timestamp":
+		if err := wantArgs(1); err != nil {
+			return nil, err
+		}
+		return e.evalTimestamp(call.Args[0], ts)
+	case "round":
+		if err := wantArgs(1, 2); err != nil {
+			return nil, err
+		}
+		toNearest := 1.0
+		if len(call.Args) == 2 {
+			var err error
+			if toNearest, err = e.evalScalar(call.Args[1], ts); err != nil {
+				return nil, err
+			}
+		}
+		inverse := 1 / toNearest
+		return e.mapVector(call.Args[0], ts, dropName, func(v float64) float64 {
+			return math.Floor(v*inverse+0.5) / inverse
+		})
+	case "clamp", "clamp_min", "clamp_max":
+		counts := map[string]int{"clamp": 3, "clamp_min": 2, "clamp_max": 2}
+		if err := wantArgs(counts[name]); err != nil {
+			return nil, err
+		}
+		bounds := make([]float64, len(call.Args)-1)
+		for i, arg := range call.Args[1:] {
+			var err error
+			if bounds[i], err = e.evalScalar(arg, ts); err != nil {
+				return nil, err
+			}
+		}
+		low, high := math.Inf(-1), math.Inf(1)
+		switch name {
+		case "clamp":
+			low, high = bounds[0], bounds[1]
+			if low > high {
+				return promVector{}, nil
+			}
+		case "clamp_min":
+			low = bounds[0]
+		case "clamp_max":
+			high = bounds[0]
+		}
+		return e.mapVector(call.Args[0], ts, dropName, func(v float64) float64 {
+			return math.Max(low, math.Min(high, v))
+		})
+	case "quantile_over_time":
+		if err := wantArgs(2); err != nil {
+			return nil, err
+		}
+		q, err := e.evalScalar(call.Args[0], ts)
+		if err != nil {
+			return nil, err
+		}
+		return e.evalRangeFunction(call.Args[1], ts, dropName, func(s []promSample, _, _ int64) (float64, bool) {
+			return promQuantile(q, promSampleValues(s)), true
+		})
+	case "predict_linear":
+		if err := wantArgs(2); err != nil {
+			return nil, err
+		}
+		seconds, err := e.evalScalar(call.Args[1], ts)
+		if err != nil {
+			return nil, err
+		}
+		return e.evalRangeFunction(call.Args[0], ts, dropName, func(s []promSample, ts, _ int64) (float64, bool) {
+			if len(s) < 2 {
+				return 0, false
+			}
+			slope, intercept := promLinearRegression(s, ts)
+			return slope*seconds + intercept, true
+		})
+	case "absent", "absent_over_time":
+		if err := wantArgs(1); err != nil {
+			return nil, err
+		}
+		return e.evalAbsent(call.Args[0], ts)
+	case "sort", "sort_desc":
+		if err := wantArgs(1); err != nil {
+			return nil, err
+		}
+		vector, err := e.evalVector(call.Args[0], ts)
+		if err != nil {
+			return nil, err
+		}
+		vector = slices.Clone(vector)
+		slices.SortStableFunc(vector, func(a, b promVectorSample) int {
+			if name == "sort_desc" {
+				return cmp.Compare(b.v, a.v)
+			}
+			return cmp.Compare(a.v, b.v)
+		})
+		return vector, nil
+	case "histogram_quantile":
+		if err := wantArgs(2); err != nil {
+			return nil, err
+		}
+		return e.evalHistogramQuantile(call.Args[0], call.Args[1], ts)
+	case "label_replace":
+		if err := wantArgs(5); err != nil {
+			return nil, err
+		}
+		return e.evalLabelReplace(call.Args, ts)
+	case "label_join":
+		if len(call.Args) < 3 {
+			return nil, wantArgs(3)
+		}
+		return e.evalLabelJoin(call.Args, ts)
+	}
+	return nil, fmt.Errorf("function %q is not supported by the offline evaluator", name)
+}
+
+func (e *promEngine) mapVector(arg utils.PromQLExpr, ts int64, labels func(promLabels) promLabels, f func(float64) float64) (promVector, error) {
+	vector, err := e.evalVector(arg, ts)
+	if err != nil {
+		return nil, err
+	}
+	result := make(promVector, len(vector))
+	for i, sample := range vector {
+		result[i] = promVectorSample{labels: labels(sample.labels), t: sample.t, v: f(sample.v)}
+	}
+	return result, nil
+}
+
+func (e *promEngine) evalRangeFunction(arg utils.PromQLExpr, ts int64, labels func(promLabels) promLabels, reduce func([]promSample, int64, int64) (float64, bool)) (promVector, error) {
+	matrix, err := e.evalMatrix(arg, ts)
+	if err != nil {
+		return nil, err
+	}
+	// The range ends at the reference time of the selector or subquery.
+	end, window := ts, int64(0)
+	switch arg := arg.(type) {
+	case *utils.PromQLMatrixSelector:
+		if end, err = referenceTime(arg.Selector.Offset, arg.Selector.At, ts); err != nil {
+			return nil, err
+		}
+		window, err = promDurationMillis(arg.Range)
+	case *utils.PromQLSubqueryExpr:
+		if end, err = referenceTime(arg.Offset, arg.At, ts); err != nil {
+			return nil, err
+		}
+		window, err = promDurationMillis(arg.Range)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result promVector
+	for _, series := range matrix {
+		if value, ok := reduce(series.samples, end, window); ok {
+			result = append(result, promVectorSample{labels: labels(series.labels), t: ts, v: value})
+		}
+	}
+	return result, nil
+}
+
+func (e *promEngine) evalTimestamp(arg utils.PromQLExpr, ts int64) (promVector, error) {
+	selector, ok := arg.(*utils.PromQLVectorSelector)
+	if !ok {
+		vector, err := e.evalVector(arg, ts)
+		if err != nil {
+			return nil, err
+		}
+		result := make(promVector, len(vector))
+		for i, sample := range vector {
+			result[i] = promVectorSample{labels: sample.labels.without("__name__"), t: ts, v: float64(sample.t) / 1000}
+		}
+		return result, nil
+	}
+
+	// The timestamp of a selector is the one of the sample it selected.
+	ref, err := referenceTime(selector.Offset, selector.At, ts)
+	if err != nil {
+		return nil, err
+	}
+	series, err := e.selectSeries(selector)
+	if err != nil {
+		return nil, err
+	}
+	var result promVector
+	for _, s := range series {
+		for i := len(s.samples) - 1; i >= 0; i-- {
+			sample := s.samples[i]
+			if sample.t > ref {
+				continue
+			}
+			if !sample.stale && sample.t > ref-promLookbackDelta.Milliseconds() {
+				result = append(result, promVectorSample{labels: s.labels.without("__name__"), t: ts, v: float64(sample.t) / 1000})
+			}
+			break
+		}
+	}
+	return result, nil
+}
+
+func (e *promEngine) evalAbsent(arg utils.PromQLExpr, ts int64) (promVector, error) {
+	value, err := e.eval(arg, ts)
+	if err != nil {
+		return nil, err
+	}
+	switch value := value.(type) {
+	case promVector:
+		if len(value) > 0 {
+			return promVector{}, nil
+		}
+	case promMatrix:
+		if len(value) > 0 {
+			return promVector{}, nil
+		}
+	default:
+		return nil, fmt.Errorf("expected instant or range vector, got %s", promValueType(value))
+	}
+
+	// Like Prometheus, the result has the labels of the equality matchers of
+	// the selector.
+	labels := promLabels{}
+	var selector *utils.PromQLVectorSelector
+	switch arg := arg.(type) {
+	case *utils.PromQLVectorSelector:
+		selector = arg
+	case *utils.PromQLMatrixSelector:
+		selector = arg.Selector
+	}
+	if selector != nil {
+		seen := map[string]bool{}
+		for _, matcher := range selector.Matchers {
+			if matcher.Name == "__name__" {
+				continue
+			}
+			if matcher.Type == "=" && !seen[matcher.Name] {
+				labels[matcher.Name] = matcher.Value
+			} else {
+				delete(labels, matcher.Name)
+			}
+			seen[matcher.Name] = true
+		}
+	}
+	return promVector{{labels: labels, t: ts, v: 1}}, nil
+}
+
+func (e *promEngine) evalHistogramQuantile(phiArg, bucketsArg utils.PromQLExpr, ts int64) (promVector, error) {
+	phi, err := e.evalScalar(phiArg, ts)
+	if err != nil {
+		return nil, err
+	}
+	vector, err := e.evalVector(bucketsArg, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	type bucket struct{ upperBound, count float64 }
+	type histogram struct {
+		labels  promLabels
+		buckets []bucket
+	}
+	var histograms []*histogram
+	index := map[string]*histogram{}
+	for _, sample := range vector {
+		upperBound, err := strconv.ParseFloat(sample.labels["le"], 64)
+		if err != nil {
+			// Series without a valid le label are ignored, like in Prometheus.
+			continue
+		}
+		labels := sample.labels.without("__name__", "le")
+		key := labels.key()
+		h, ok := index[key]
+		if !ok {
+			h = &histogram{labels: labels}
+			index[key] = h
+			histograms = append(histograms, h)
+		}
+		h.buckets = append(h.buckets, bucket{upperBound, sample.v})
+	}
+
+	var result promVector
+	for _, h := range histograms {
+		buckets := h.buckets
+		slices.SortFunc(buckets, func(a, b bucket) int { return cmp.Compare(a.upperBound, b.upperBound) })
+		value := math.NaN()
+		switch {
+		case phi < 0:
+			value = math.Inf(-1)
+		case phi > 1:
+			value = math.Inf(1)
+		case len(buckets) >= 2 && math.IsInf(buckets[len(buckets)-1].upperBound, 1):
+			// Buckets are cumulative, so fix counts that went down.
+			for i := 1; i < len(buckets); i++ {
+				buckets[i].count = math.Max(buckets[i].count, buckets[i-1].count)
+			}
+			observations := buckets[len(buckets)-1].count
+			if observations == 0 {
+				break
+			}
+			rank := phi * observations
+			b, _ := slices.BinarySearchFunc(buckets[:len(buckets)-1], rank, func(b bucket, rank float64) int {
+				return cmp.Compare(b.count, rank)
+			})
+			switch {
+			case b == len(buckets)-1:
+				value = buckets[len(buckets)-2].upperBound
+			case b == 0 && buckets[0].upperBound <= 0:
+				value = buckets[0].upperBound
+			default:
+				bucketStart, bucketEnd, count := 0.0, buckets[b].upperBound, buckets[b].count
+				if b > 0 {
+					bucketStart = buckets[b-1].upperBound
+					count -= buckets[b-1].count
+					rank -= buckets[b-1].count
+				}
+				value = bucketStart + (bucketEnd-bucketStart)*(rank/count)
+			}
+		}
+		result = append(result, promVectorSample{labels: h.labels, t: ts, v: value})
+	}
+	return result, nil
+}
+
+func (e *promEngine) evalLabelReplace(args []utils.PromQLExpr, ts int64) (promVector, error) {
+	vector, err := e.evalVector(args[0], ts)
+	if err != nil {
+		return nil, err
+	}
+	var strs [4]string
+	for i, arg := range args[1:] {
+		if strs[i], err = e.evalString(arg, ts); err != nil {
+			return nil, err
+		}
+	}
+	destination, replacement, source, expression := strs[0], strs[1], strs[2], strs[3]
+	re, err := regexp.Compile("^(?s:" + expression + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression in label_replace(): %s", expression)
+	}
+
+	result := make(promVector, len(vector))
+	for i, sample := range vector {
+		labels := sample.labels
+		if match := re.FindStringSubmatchIndex(labels[source]); match != nil {
+			value := string(re.ExpandString(nil, replacement, labels[source], match))
+			labels = maps.Clone(labels)
+			if value == "" {
+				delete(labels, destination)
+			} else {
+				labels[destination] = value
+			}
+		}
+		result[i] = promVectorSample{labels: labels, t: sample.t, v: sample.v}
+	}
+	return result, nil
+}
+
+func (e *promEngine) evalLabelJoin(args []utils.PromQLExpr, ts int64) (promVector, error) {
+	vector, err := e.evalVector(args[0], ts)
+	if err != nil {
+		return nil, err
+	}
+	strs := make([]string, len(args)-1)
+	for i, arg := range args[1:] {
+		if strs[i], err = e.evalString(arg, ts); err != nil {
+			return nil, err
+		}
+	}
+	destination, separator, sources := strs[0], strs[1], strs[2:]
+
+	result := make(promVector, len(vector))
+	for i, sample := range vector {
+		values := make([]string, len(sources))
+		for j, source := range sources {
+			values[j] = sample.labels[source]
+		}
+		labels := maps.Clone(sample.labels)
+		if value := strings.Join(values, separator); value == "" {
+			delete(labels, destination)
+		} else {
+			labels[destination] = value
+		}
+		result[i] = promVectorSample{labels: labels, t: sample.t, v: sample.v}
+	}
+	return result, nil
+}
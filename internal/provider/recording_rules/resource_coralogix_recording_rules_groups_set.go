@@ -46,9 +46,10 @@ import (
 )
 
 var (
-	_ resource.ResourceWithConfigure    = &RecordingRuleGroupSetResource{}
-	_ resource.ResourceWithImportState  = &RecordingRuleGroupSetResource{}
-	_ resource.ResourceWithUpgradeState = &RecordingRuleGroupSetResource{}
+	_ resource.ResourceWithConfigure      = &RecordingRuleGroupSetResource{}
+	_ resource.ResourceWithImportState    = &RecordingRuleGroupSetResource{}
+	_ resource.ResourceWithUpgradeState   = &RecordingRuleGroupSetResource{}
+	_ resource.ResourceWithValidateConfig = &RecordingRuleGroupSetResource{}
 )
 
 type RecordingRuleGroupSetResourceModel struct {
@@ -56,6 +57,8 @@ type RecordingRuleGroupSetResourceModel struct {
 	YamlContent types.String `tfsdk:"yaml_content"`
 	Groups      types.Set    `tfsdk:"groups"` //RecordingRuleGroupModel
 	Name        types.String `tfsdk:"name"`
+	TestsYaml   types.String `tfsdk:"tests_yaml"`
+	Lint        types.Bool   `tfsdk:"lint"`
 }
 
 type RecordingRuleGroupModel struct {
@@ -174,6 +177,8 @@ func upgradeRecordingRuleGroupSetStateV0ToV1(ctx context.Context, req resource.U
 		YamlContent: priorStateData.YamlContent,
 		Name:        priorStateData.Name,
 		Groups:      groups,
+		TestsYaml:   types.StringNull(),
+		Lint:        types.BoolNull(),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, upgradedStateData)...)
@@ -320,6 +325,19 @@ func (r *RecordingRuleGroupSetResource) Schema(ctx context.Context, _ resource.S
 				},
 				MarkdownDescription: "The name of the rule group. Overrides the name specified in the YAML if provided.",
 			},
+			"tests_yaml": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Unit tests of the rules in the promtool test file format, with `input_series`, `promql_expr_test` and `alert_rule_test`. " +
+					"The tests run at plan time against the rules of `yaml_content` or `groups` with an embedded PromQL engine, and failing tests fail the plan. " +
+					"Groups missing from `group_eval_order` are evaluated after the listed ones, sorted by name. " +
+					"`rule_files` is ignored, and as recording rule groups have no alerting rules, `alert_rule_test` only passes when no alerts are expected.",
+			},
+			"lint": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "When set, every `record` must follow the `level:metric:operations` naming convention. " +
+					"Regardless of this setting, rule expressions are parsed at plan time, cycles between recording rules fail the plan, " +
+					"and rules reading a metric recorded by a later group with a longer `interval` or calling an unknown function are reported as warnings.",
+			},
 		},
 		MarkdownDescription: "Coralogix recording rules group set. Recording rules pre-compute frequently used or computationally heavy PromQL expressions into new metrics. For more info please review - https://coralogix.com/docs/user-guides/data-transformation/metric-rules/recording-rules/.",
 	}
@@ -388,6 +406,45 @@ func recordingRulesSchema() schema.NestedAttributeObject {
 	}
 }
 
+// ValidateConfig checks the dependencies between the rules and runs
+// tests_yaml against them, so that broken chains of recording rules and
+// failing tests block the apply.
+func (r *RecordingRuleGroupSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config RecordingRuleGroupSetResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.YamlContent.IsUnknown() || (config.YamlContent.IsNull() && config.Groups.IsNull()) {
+		return
+	}
+	if groups, err := config.Groups.ToTerraformValue(ctx); err != nil || !groups.IsFullyKnown() {
+		return
+	}
+
+	set, diags := expandRecordingRulesGroupsSet(ctx, &config)
+	if diags.HasError() {
+		return
+	}
+	attrPath := path.Root("groups")
+	if !config.YamlContent.IsNull() {
+		attrPath = path.Root("yaml_content")
+	}
+	resp.Diagnostics.Append(validateRecordingRuleGroups(set.Groups, config.Lint.ValueBool(), attrPath)...)
+	if resp.Diagnostics.HasError() || config.TestsYaml.IsNull() || config.TestsYaml.IsUnknown() {
+		return
+	}
+
+	failures, err := runRecordingRulesTests(set.Groups, config.TestsYaml.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("tests_yaml"), "Invalid tests_yaml", err.Error())
+		return
+	}
+	for _, failure := range failures {
+		resp.Diagnostics.AddAttributeError(path.Root("tests_yaml"), "Recording rules test failed", failure)
+	}
+}
+
 func (r *RecordingRuleGroupSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan *RecordingRuleGroupSetResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -552,6 +609,8 @@ func flattenRecordingRuleGroupSet(ctx context.Context, plan *RecordingRuleGroupS
 			YamlContent: types.StringValue(plan.YamlContent.ValueString()),
 			Name:        types.StringValue(resp.GetName()),
 			Groups:      groups,
+			TestsYaml:   plan.TestsYaml,
+			Lint:        plan.Lint,
 		}, nil
 	}
 
@@ -565,6 +624,8 @@ func flattenRecordingRuleGroupSet(ctx context.Context, plan *RecordingRuleGroupS
 		Name:        types.StringValue(resp.GetName()),
 		Groups:      groups,
 		YamlContent: types.StringNull(),
+		TestsYaml:   plan.TestsYaml,
+		Lint:        plan.Lint,
 	}, nil
 }
 
@@ -0,0 +1,196 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording_rules
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	recRuless "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/recording_rules_service"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// recordingRuleNameRegex is the level:metric:operations naming convention of
+// recording rules.
+var recordingRuleNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*:[a-zA-Z_][a-zA-Z0-9_]*:[a-zA-Z0-9_]+$`)
+
+// recordingRuleRef is the position of a rule in the groups of a set.
+type recordingRuleRef struct {
+	group int
+	rule  int
+}
+
+// recordingRulesGraph links every rule to the rules recording the metrics
+// its expression reads, and keeps the unknown functions the expression calls.
+type recordingRulesGraph struct {
+	groups           []recRuless.InRuleGroup
+	dependsOn        map[recordingRuleRef][]recordingRuleRef
+	unknownFunctions map[recordingRuleRef][]string
+}
+
+func (g *recordingRulesGraph) rule(ref recordingRuleRef) recRuless.InRule {
+	return g.groups[ref.group].Rules[ref.rule]
+}
+
+func (g *recordingRulesGraph) interval(group int) int64 {
+	if interval := g.groups[group].Interval; interval != nil && *interval > 0 {
+		return *interval
+	}
+	return 60
+}
+
+func (g *recordingRulesGraph) refs() []recordingRuleRef {
+	var refs []recordingRuleRef
+	for i, group := range g.groups {
+		for j := range group.Rules {
+			refs = append(refs, recordingRuleRef{group: i, rule: j})
+		}
+	}
+	return refs
+}
+
+// validateRecordingRuleGroups parses the expression of every rule and checks
+// the chains of recording rules: rules depending on each other in a cycle
+// are errors, and rules reading a metric recorded by a later group with a
+// longer interval are warnings, as they see gaps in that metric. Calls of
+// unknown functions are warnings too. When lint is set, records must also
+// follow the level:metric:operations convention.
+func validateRecordingRuleGroups(groups []recRuless.InRuleGroup, lint bool, attrPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	graph, err := buildRecordingRulesGraph(groups)
+	if err != nil {
+		diags.AddAttributeError(attrPath, "Invalid recording rule expression", err.Error())
+		return diags
+	}
+
+	for _, ref := range graph.refs() {
+		if functions := graph.unknownFunctions[ref]; len(functions) > 0 {
+			diags.AddAttributeWarning(attrPath, "Recording rule calls an unknown function",
+				fmt.Sprintf("Rule %q of group %q calls %q, which are not known PromQL functions. Coralogix rejects the rule if it does not support them either.",
+					graph.rule(ref).Record, groups[ref.group].Name, functions))
+		}
+	}
+
+	for _, cycle := range graph.cycles() {
+		records := make([]string, 0, len(cycle)+1)
+		for _, ref := range cycle {
+			records = append(records, graph.rule(ref).Record)
+		}
+		records = append(records, records[0])
+		diags.AddAttributeError(attrPath, "Recording rules dependency cycle",
+			fmt.Sprintf("The recording rules %s read each other, so none of them gets a value.", strings.Join(records, " -> ")))
+	}
+
+	for _, ref := range graph.refs() {
+		for _, dependency := range graph.dependsOn[ref] {
+			if dependency.group <= ref.group || graph.interval(dependency.group) <= graph.interval(ref.group) {
+				continue
+			}
+			diags.AddAttributeWarning(attrPath, "Recording rule reads a slower rule group",
+				fmt.Sprintf("Rule %q of group %q, evaluated every %ds, reads %q, recorded by the later group %q every %ds. "+
+					"Its results have gaps between the evaluations of %q; move it to a group after %q with the same or a longer interval.",
+					graph.rule(ref).Record, groups[ref.group].Name, graph.interval(ref.group),
+					graph.rule(dependency).Record, groups[dependency.group].Name, graph.interval(dependency.group),
+					groups[dependency.group].Name, groups[dependency.group].Name))
+		}
+	}
+
+	if lint {
+		for _, ref := range graph.refs() {
+			if record := graph.rule(ref).Record; !recordingRuleNameRegex.MatchString(record) {
+				diags.AddAttributeError(attrPath, "Recording rule name does not follow the naming convention",
+					fmt.Sprintf("Rule %q of group %q must be named level:metric:operations, such as job:http_requests_total:rate5m.",
+						record, groups[ref.group].Name))
+			}
+		}
+	}
+
+	return diags
+}
+
+func buildRecordingRulesGraph(groups []recRuless.InRuleGroup) (*recordingRulesGraph, error) {
+	graph := &recordingRulesGraph{
+		groups:           groups,
+		dependsOn:        map[recordingRuleRef][]recordingRuleRef{},
+		unknownFunctions: map[recordingRuleRef][]string{},
+	}
+	recordedBy := map[string][]recordingRuleRef{}
+	for _, ref := range graph.refs() {
+		record := graph.rule(ref).Record
+		recordedBy[record] = append(recordedBy[record], ref)
+	}
+
+	for _, ref := range graph.refs() {
+		rule := graph.rule(ref)
+		selectors, unknownFunctions, err := utils.ParsePromQL(rule.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("group %q, rule %q: %s", groups[ref.group].Name, rule.Record, err)
+		}
+		if len(unknownFunctions) > 0 {
+			graph.unknownFunctions[ref] = unknownFunctions
+		}
+		for _, selector := range selectors {
+			for _, dependency := range recordedBy[selector.Metric] {
+				if !slices.Contains(graph.dependsOn[ref], dependency) {
+					graph.dependsOn[ref] = append(graph.dependsOn[ref], dependency)
+				}
+			}
+		}
+	}
+	return graph, nil
+}
+
+// cycles returns the cycles found by a depth-first walk of the rules in set
+// order. Each cycle is listed in reading order, from a rule to the rules it
+// reads.
+func (g *recordingRulesGraph) cycles() [][]recordingRuleRef {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[recordingRuleRef]int{}
+	var stack []recordingRuleRef
+	var cycles [][]recordingRuleRef
+
+	var visit func(ref recordingRuleRef)
+	visit = func(ref recordingRuleRef) {
+		state[ref] = visiting
+		stack = append(stack, ref)
+		for _, dependency := range g.dependsOn[ref] {
+			switch state[dependency] {
+			case unvisited:
+				visit(dependency)
+			case visiting:
+				start := slices.Index(stack, dependency)
+				cycles = append(cycles, slices.Clone(stack[start:]))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[ref] = visited
+	}
+
+	for _, ref := range g.refs() {
+		if state[ref] == unvisited {
+			visit(ref)
+		}
+	}
+	return cycles
+}
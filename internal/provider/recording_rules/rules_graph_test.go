@@ -0,0 +1,179 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording_rules
+
+import (
+	"strings"
+	"testing"
+
+	recRuless "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/recording_rules_service"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"gopkg.in/yaml.v3"
+)
+
+func recordingRuleGroupsFromYaml(t *testing.T, content string) []recRuless.InRuleGroup {
+	t.Helper()
+	var set recRuless.CreateRuleGroupSet
+	if err := yaml.Unmarshal([]byte(content), &set); err != nil {
+		t.Fatal(err)
+	}
+	return set.Groups
+}
+
+func diagnosticDetails(diags diag.Diagnostics, severity diag.Severity) []string {
+	var details []string
+	for _, d := range diags {
+		if d.Severity() == severity {
+			details = append(details, d.Summary()+": "+d.Detail())
+		}
+	}
+	return details
+}
+
+func TestValidateRecordingRuleGroups(t *testing.T) {
+	cases := []struct {
+		name     string
+		groups   string
+		lint     bool
+		errors   []string
+		warnings []string
+	}{
+		{
+			name: "valid chain",
+			groups: `
+groups:
+  - name: fast
+    interval: 60
+    rules:
+      - record: job:http_requests_total:rate5m
+        expr: sum by (job) (rate(http_requests_total[5m]))
+  - name: slow
+    interval: 300
+    rules:
+      - record: job:http_requests_total:rate5m_avg1h
+        expr: avg_over_time(job:http_requests_total:rate5m[1h])
+`,
+			lint: true,
+		},
+		{
+			name: "cycle",
+			groups: `
+groups:
+  - name: a
+    rules:
+      - record: job:a:sum
+        expr: sum by (job) (job:b:sum)
+      - record: job:b:sum
+        expr: sum by (job) (job:c:sum) + on (job) up
+  - name: b
+    rules:
+      - record: job:c:sum
+        expr: job:a:sum * 2
+      - record: job:d:sum
+        expr: job:d:sum offset 1m
+`,
+			errors: []string{
+				"Recording rules dependency cycle: The recording rules job:a:sum -> job:b:sum -> job:c:sum -> job:a:sum read each other",
+				"Recording rules dependency cycle: The recording rules job:d:sum -> job:d:sum read each other",
+			},
+		},
+		{
+			name: "slower later group",
+			groups: `
+groups:
+  - name: slo
+    interval: 60
+    rules:
+      - record: job:slo_errors:ratio_rate1h
+        expr: job:errors:rate1h / job:requests:rate1h
+  - name: rates
+    interval: 600
+    rules:
+      - record: job:errors:rate1h
+        expr: sum by (job) (rate(errors_total[1h]))
+      - record: job:requests:rate1h
+        expr: sum by (job) (rate(requests_total[1h]))
+`,
+			warnings: []string{
+				`Recording rule reads a slower rule group: Rule "job:slo_errors:ratio_rate1h" of group "slo", evaluated every 60s, reads "job:errors:rate1h", recorded by the later group "rates" every 600s.`,
+				`Recording rule reads a slower rule group: Rule "job:slo_errors:ratio_rate1h" of group "slo", evaluated every 60s, reads "job:requests:rate1h", recorded by the later group "rates" every 600s.`,
+			},
+		},
+		{
+			name: "naming convention",
+			groups: `
+groups:
+  - name: names
+    rules:
+      - record: http_requests_total:rate5m
+        expr: rate(http_requests_total[5m])
+      - record: job:http_requests_total:rate5m
+        expr: sum by (job) (http_requests_total:rate5m)
+`,
+			lint:   true,
+			errors: []string{`Recording rule name does not follow the naming convention: Rule "http_requests_total:rate5m" of group "names"`},
+		},
+		{
+			name: "naming convention without lint",
+			groups: `
+groups:
+  - name: names
+    rules:
+      - record: http_requests_total:rate5m
+        expr: rate(http_requests_total[5m])
+`,
+		},
+		{
+			name: "invalid expression",
+			groups: `
+groups:
+  - name: broken
+    rules:
+      - record: job:up:sum
+        expr: sum by (job) (up
+`,
+			errors: []string{`Invalid recording rule expression: group "broken", rule "job:up:sum": parse error at char 17: unexpected end of input`},
+		},
+		{
+			name: "unknown function",
+			groups: `
+groups:
+  - name: vendor
+    rules:
+      - record: job:up:smoothed
+        expr: sum by (job) (smooth_over_time(up[5m]))
+`,
+			warnings: []string{`Recording rule calls an unknown function: Rule "job:up:smoothed" of group "vendor" calls ["smooth_over_time"]`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := validateRecordingRuleGroups(recordingRuleGroupsFromYaml(t, tc.groups), tc.lint, path.Root("yaml_content"))
+			for severity, want := range map[diag.Severity][]string{diag.SeverityError: tc.errors, diag.SeverityWarning: tc.warnings} {
+				got := diagnosticDetails(diags, severity)
+				if len(got) != len(want) {
+					t.Fatalf("got %d diagnostics of severity %s, want %d:\n%s", len(got), severity, len(want), strings.Join(got, "\n"))
+				}
+				for i := range want {
+					if !strings.HasPrefix(got[i], want[i]) {
+						t.Errorf("diagnostic =\n%s\nwant prefix\n%s", got[i], want[i])
+					}
+				}
+			}
+		})
+	}
+}
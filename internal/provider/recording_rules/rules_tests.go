@@ -0,0 +1,475 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording_rules
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/utils"
+
+	recRuless "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/recording_rules_service"
+	"gopkg.in/yaml.v3"
+)
+
+// rulesTestFile is a unit test file of promtool test rules. rule_files is
+// accepted for compatibility, but the rules under test are always the ones
+// of the resource.
+type rulesTestFile struct {
+	RuleFiles          []string        `yaml:"rule_files"`
+	EvaluationInterval string          `yaml:"evaluation_interval"`
+	GroupEvalOrder     []string        `yaml:"group_eval_order"`
+	Tests              []rulesTestCase `yaml:"tests"`
+}
+
+type rulesTestCase struct {
+	Name            string                `yaml:"name"`
+	Interval        string                `yaml:"interval"`
+	InputSeries     []rulesTestSeries     `yaml:"input_series"`
+	AlertRuleTests  []rulesTestAlertRule  `yaml:"alert_rule_test"`
+	PromQLExprTests []rulesTestPromQLExpr `yaml:"promql_expr_test"`
+	ExternalLabels  map[string]string     `yaml:"external_labels"`
+	ExternalURL     string                `yaml:"external_url"`
+}
+
+type rulesTestSeries struct {
+	Series string `yaml:"series"`
+	Values string `yaml:"values"`
+}
+
+type rulesTestAlertRule struct {
+	EvalTime  string `yaml:"eval_time"`
+	Alertname string `yaml:"alertname"`
+	ExpAlerts []struct {
+		ExpLabels      map[string]string `yaml:"exp_labels"`
+		ExpAnnotations map[string]string `yaml:"exp_annotations"`
+	} `yaml:"exp_alerts"`
+}
+
+type rulesTestPromQLExpr struct {
+	Expr       string `yaml:"expr"`
+	EvalTime   string `yaml:"eval_time"`
+	ExpSamples []struct {
+		Labels string `yaml:"labels"`
+		Value  string `yaml:"value"`
+	} `yaml:"exp_samples"`
+}
+
+// runRecordingRulesTests runs the tests of a promtool test file against rule
+// groups, evaluating them with an embedded PromQL engine. It returns a
+// message per failed assertion, and an error when the test file itself is
+// invalid.
+func runRecordingRulesTests(groups []recRuless.InRuleGroup, content string) ([]string, error) {
+	var file rulesTestFile
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	evaluationInterval := time.Minute
+	if file.EvaluationInterval != "" {
+		var err error
+		if evaluationInterval, err = utils.ParsePromQLDuration(file.EvaluationInterval); err != nil {
+			return nil, fmt.Errorf("evaluation_interval: %s", err)
+		}
+	}
+	if evaluationInterval <= 0 {
+		return nil, fmt.Errorf("evaluation_interval must be positive")
+	}
+	ordered, err := orderRuleGroups(groups, file.GroupEvalOrder)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := parseRuleGroups(ordered)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []string
+	for i, test := range file.Tests {
+		name := fmt.Sprintf("tests[%d]", i)
+		if test.Name != "" {
+			name = fmt.Sprintf("%s (%s)", name, test.Name)
+		}
+		testFailures, err := runRulesTest(rules, test, evaluationInterval)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+		for _, failure := range testFailures {
+			failures = append(failures, name+": "+failure)
+		}
+	}
+	return failures, nil
+}
+
+// orderRuleGroups evaluates the groups of group_eval_order first, in that
+// order, followed by the other groups sorted by name. The groups of a set have
+// no order of their own, so sorting them keeps the results of tests that
+// depend on it the same between plans.
+func orderRuleGroups(groups []recRuless.InRuleGroup, order []string) ([]recRuless.InRuleGroup, error) {
+	var ordered, rest []recRuless.InRuleGroup
+	for _, name := range order {
+		i := slices.IndexFunc(groups, func(group recRuless.InRuleGroup) bool { return group.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("group_eval_order: group %q is not defined", name)
+		}
+		ordered = append(ordered, groups[i])
+	}
+	for _, group := range groups {
+		if !slices.Contains(order, group.Name) {
+			rest = append(rest, group)
+		}
+	}
+	slices.SortStableFunc(rest, func(a, b recRuless.InRuleGroup) int { return cmp.Compare(a.Name, b.Name) })
+	return append(ordered, rest...), nil
+}
+
+type parsedRecordingRule struct {
+	record string
+	expr   utils.PromQLExpr
+	labels map[string]string
+}
+
+func parseRuleGroups(groups []recRuless.InRuleGroup) ([][]parsedRecordingRule, error) {
+	result := make([][]parsedRecordingRule, len(groups))
+	for i, group := range groups {
+		for _, rule := range group.Rules {
+			expr, err := utils.ParsePromQLExpr(rule.Expr)
+			if err != nil {
+				return nil, fmt.Errorf("group %q, rule %q: %s", group.Name, rule.Record, err)
+			}
+			parsed := parsedRecordingRule{record: rule.Record, expr: expr}
+			if rule.Labels != nil {
+				parsed.labels = *rule.Labels
+			}
+			result[i] = append(result[i], parsed)
+		}
+	}
+	return result, nil
+}
+
+func runRulesTest(groups [][]parsedRecordingRule, test rulesTestCase, evaluationInterval time.Duration) ([]string, error) {
+	interval := evaluationInterval
+	if test.Interval != "" {
+		var err error
+		if interval, err = utils.ParsePromQLDuration(test.Interval); err != nil {
+			return nil, fmt.Errorf("interval: %s", err)
+		}
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+
+	storage := newPromStorage()
+	for i, input := range test.InputSeries {
+		labels, err := parseSeriesNotation(input.Series)
+		if err != nil {
+			return nil, fmt.Errorf("input_series[%d]: %s", i, err)
+		}
+		samples, err := expandSeriesValues(input.Values, interval.Milliseconds())
+		if err != nil {
+			return nil, fmt.Errorf("input_series[%d]: %s", i, err)
+		}
+		for _, sample := range samples {
+			storage.append(labels, sample)
+		}
+	}
+
+	var maxEvalTime int64
+	evalTimes := map[string]int64{}
+	readEvalTime := func(text string) error {
+		if text == "" {
+			text = "0"
+		}
+		duration, err := utils.ParsePromQLDuration(text)
+		if err != nil {
+			return err
+		}
+		evalTimes[text] = duration.Milliseconds()
+		maxEvalTime = max(maxEvalTime, duration.Milliseconds())
+		return nil
+	}
+	for i, exprTest := range test.PromQLExprTests {
+		if err := readEvalTime(exprTest.EvalTime); err != nil {
+			return nil, fmt.Errorf("promql_expr_test[%d].eval_time: %s", i, err)
+		}
+	}
+	for i, alertTest := range test.AlertRuleTests {
+		if err := readEvalTime(alertTest.EvalTime); err != nil {
+			return nil, fmt.Errorf("alert_rule_test[%d].eval_time: %s", i, err)
+		}
+	}
+
+	engine := &promEngine{storage: storage, defaultStep: evaluationInterval}
+	var failures []string
+
+	// Like promtool, every group is evaluated at every evaluation interval,
+	// and each rule sees the series recorded by the rules before it.
+	previous := map[[2]int]map[string]promLabels{}
+	for ts := int64(0); ts <= maxEvalTime; ts += evaluationInterval.Milliseconds() {
+		for i, rules := range groups {
+			for j, rule := range rules {
+				recorded, err := evalRecordingRule(engine, rule, ts)
+				if err != nil {
+					failures = append(failures, fmt.Sprintf("rule %q at %s: %s", rule.record, formatTestTime(ts), err))
+					return failures, nil
+				}
+				current := map[string]promLabels{}
+				for _, sample := range recorded {
+					key := sample.labels.key()
+					if _, ok := current[key]; ok {
+						failures = append(failures, fmt.Sprintf("rule %q at %s: vector contains metrics with the same labelset after applying rule labels", rule.record, formatTestTime(ts)))
+						return failures, nil
+					}
+					current[key] = sample.labels
+					storage.append(sample.labels, promSample{t: ts, v: sample.v})
+				}
+				// Series the rule no longer records are marked stale.
+				for key, labels := range previous[[2]int{i, j}] {
+					if _, ok := current[key]; !ok {
+						storage.append(labels, promSample{t: ts, stale: true})
+					}
+				}
+				previous[[2]int{i, j}] = current
+			}
+		}
+	}
+
+	for i, exprTest := range test.PromQLExprTests {
+		failure, err := runPromQLExprTest(engine, exprTest, evalTimes[cmp.Or(exprTest.EvalTime, "0")])
+		if err != nil {
+			return nil, fmt.Errorf("promql_expr_test[%d]: %s", i, err)
+		}
+		if failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+	for _, alertTest := range test.AlertRuleTests {
+		// Recording rule groups don't have alerting rules, so no alert ever
+		// fires, as promtool would report for rules without the alert.
+		if len(alertTest.ExpAlerts) > 0 {
+			failures = append(failures, fmt.Sprintf("alertname: %s, time: %s,\n    exp: %d alerts\n    got: []\n(recording rule groups have no alerting rules)",
+				alertTest.Alertname, formatTestTime(evalTimes[cmp.Or(alertTest.EvalTime, "0")]), len(alertTest.ExpAlerts)))
+		}
+	}
+	return failures, nil
+}
+
+// evalRecordingRule evaluates a rule at ts and returns the series it records.
+func evalRecordingRule(engine *promEngine, rule parsedRecordingRule, ts int64) (promVector, error) {
+	value, err := engine.eval(rule.expr, ts)
+	if err != nil {
+		return nil, err
+	}
+	var vector promVector
+	switch value := value.(type) {
+	case promScalar:
+		vector = promVector{{labels: promLabels{}, t: ts, v: float64(value)}}
+	case promVector:
+		vector = value
+	default:
+		return nil, fmt.Errorf("rule result is a %s, not an instant vector or scalar", promValueType(value))
+	}
+
+	result := make(promVector, len(vector))
+	for i, sample := range vector {
+		labels := maps.Clone(sample.labels)
+		labels["__name__"] = rule.record
+		for name, value := range rule.labels {
+			if value == "" {
+				delete(labels, name)
+			} else {
+				labels[name] = value
+			}
+		}
+		result[i] = promVectorSample{labels: labels, t: ts, v: sample.v}
+	}
+	return result, nil
+}
+
+func runPromQLExprTest(engine *promEngine, exprTest rulesTestPromQLExpr, ts int64) (string, error) {
+	expected := make(promVector, len(exprTest.ExpSamples))
+	for i, sample := range exprTest.ExpSamples {
+		labels, err := parseSeriesNotation(sample.Labels)
+		if err != nil {
+			return "", fmt.Errorf("exp_samples[%d].labels: %s", i, err)
+		}
+		value, err := strconv.ParseFloat(sample.Value, 64)
+		if err != nil {
+			return "", fmt.Errorf("exp_samples[%d].value: %s", i, err)
+		}
+		expected[i] = promVectorSample{labels: labels, v: value}
+	}
+
+	expr, err := utils.ParsePromQLExpr(exprTest.Expr)
+	if err != nil {
+		return "", fmt.Errorf("expr %q: %s", exprTest.Expr, err)
+	}
+	got := promVector{}
+	value, err := engine.eval(expr, ts)
+	if err == nil {
+		switch value := value.(type) {
+		case promScalar:
+			got = promVector{{labels: promLabels{}, v: float64(value)}}
+		case promVector:
+			got = value
+		default:
+			err = fmt.Errorf("expression result is a %s, not an instant vector or scalar", promValueType(value))
+		}
+	}
+	if err != nil {
+		return fmt.Sprintf("expr: %q, time: %s, err: %s", exprTest.Expr, formatTestTime(ts), err), nil
+	}
+
+	sortSamples := func(samples promVector) {
+		slices.SortFunc(samples, func(a, b promVectorSample) int { return strings.Compare(a.labels.key(), b.labels.key()) })
+	}
+	sortSamples(expected)
+	sortSamples(got)
+	if !slices.EqualFunc(expected, got, func(a, b promVectorSample) bool {
+		return a.labels.key() == b.labels.key() && promAlmostEqual(a.v, b.v)
+	}) {
+		return fmt.Sprintf("expr: %q, time: %s,\n    exp: %s\n    got: %s", exprTest.Expr, formatTestTime(ts), formatSamples(expected), formatSamples(got)), nil
+	}
+	return "", nil
+}
+
+// promAlmostEqual compares values the way promtool does, with NaN equal to
+// NaN.
+func promAlmostEqual(a, b float64) bool {
+	const epsilon, minNormal = 1e-6, 2.2250738585072014e-308
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	if a == b {
+		return true
+	}
+	absSum := math.Abs(a) + math.Abs(b)
+	diff := math.Abs(a - b)
+	if a == 0 || b == 0 || absSum < minNormal {
+		return diff < epsilon*minNormal
+	}
+	return diff/math.Min(absSum, math.MaxFloat64) < epsilon
+}
+
+func formatSamples(samples promVector) string {
+	formatted := make([]string, len(samples))
+	for i, sample := range samples {
+		formatted[i] = fmt.Sprintf("%s %s", sample.labels, strconv.FormatFloat(sample.v, 'g', -1, 64))
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+func formatTestTime(ts int64) string {
+	return (time.Duration(ts) * time.Millisecond).String()
+}
+
+// parseSeriesNotation reads the labels of a series written as
+// name{label="value"}. An empty string has no labels.
+func parseSeriesNotation(series string) (promLabels, error) {
+	series = strings.TrimSpace(series)
+	if series == "" || series == "{}" {
+		return promLabels{}, nil
+	}
+	expr, err := utils.ParsePromQLExpr(series)
+	if err != nil {
+		return nil, err
+	}
+	selector, ok := expr.(*utils.PromQLVectorSelector)
+	if !ok || selector.Offset != "" || selector.At != "" {
+		return nil, fmt.Errorf("%q is not a series", series)
+	}
+	labels := promLabels{}
+	if selector.Metric != "" {
+		labels["__name__"] = selector.Metric
+	}
+	for _, matcher := range selector.Matchers {
+		if matcher.Type != "=" {
+			return nil, fmt.Errorf("%q is not a series, labels must use =", series)
+		}
+		labels[matcher.Name] = matcher.Value
+	}
+	return labels, nil
+}
+
+// expandSeriesValues expands the values of an input series, such as
+// "0+10x5 _ stale 3x2", into samples interval milliseconds apart.
+func expandSeriesValues(values string, interval int64) ([]promSample, error) {
+	var samples []promSample
+	step := int64(0)
+	for _, item := range strings.Fields(values) {
+		switch {
+		case item == "_":
+			step++
+			continue
+		case item == "stale":
+			samples = append(samples, promSample{t: step * interval, stale: true})
+			step++
+			continue
+		}
+
+		base, times, expanding := strings.Cut(item, "x")
+		count := int64(0)
+		if expanding {
+			var err error
+			if count, err = strconv.ParseInt(times, 10, 64); err != nil || count < 0 {
+				return nil, fmt.Errorf("invalid value %q", item)
+			}
+		}
+		if base == "_" {
+			// _xN is N missing samples.
+			step += count
+			continue
+		}
+		start, increment, err := parseSeriesIncrement(base)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", item)
+		}
+		for i := int64(0); i <= count; i++ {
+			samples = append(samples, promSample{t: step * interval, v: start + float64(i)*increment})
+			step++
+		}
+	}
+	return samples, nil
+}
+
+// parseSeriesIncrement reads a+b or a-b, or a with no increment.
+func parseSeriesIncrement(text string) (float64, float64, error) {
+	for i := 1; i < len(text); i++ {
+		if (text[i] == '+' || text[i] == '-') && text[i-1] != 'e' && text[i-1] != 'E' {
+			start, err := strconv.ParseFloat(text[:i], 64)
+			if err != nil {
+				return 0, 0, err
+			}
+			increment, err := strconv.ParseFloat(text[i+1:], 64)
+			if err != nil {
+				return 0, 0, err
+			}
+			if text[i] == '-' {
+				increment = -increment
+			}
+			return start, increment, nil
+		}
+	}
+	start, err := strconv.ParseFloat(text, 64)
+	return start, 0, err
+}
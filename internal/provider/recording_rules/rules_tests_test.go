@@ -0,0 +1,248 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording_rules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	recRuless "github.com/coralogix/coralogix-management-sdk/go/openapi/gen/recording_rules_service"
+	"gopkg.in/yaml.v3"
+)
+
+const rulesTestGroups = `
+groups:
+  - name: requests
+    rules:
+      - record: job:http_requests:rate5m
+        expr: sum by (job) (rate(http_requests_total[5m]))
+      - record: job:http_errors:ratio5m
+        expr: sum by (job) (rate(http_requests_total{code=~"5.."}[5m])) / job:http_requests:rate5m
+        labels:
+          team: platform
+  - name: latency
+    rules:
+      - record: job:request_duration_seconds:p90
+        expr: histogram_quantile(0.9, sum by (job, le) (rate(request_duration_seconds_bucket[5m])))
+`
+
+func rulesTestRuleGroups(t *testing.T) []recRuless.InRuleGroup {
+	t.Helper()
+	var set recRuless.CreateRuleGroupSet
+	if err := yaml.Unmarshal([]byte(rulesTestGroups), &set); err != nil {
+		t.Fatal(err)
+	}
+	return set.Groups
+}
+
+func TestRunRecordingRulesTests(t *testing.T) {
+	groups := rulesTestRuleGroups(t)
+
+	t.Run("passing", func(t *testing.T) {
+		failures, err := runRecordingRulesTests(groups, `
+evaluation_interval: 1m
+tests:
+  - interval: 1m
+    input_series:
+      - series: 'http_requests_total{job="api", code="200"}'
+        values: '0+60x10'
+      - series: 'http_requests_total{job="api", code="500"}'
+        values: '0+6x10'
+      - series: 'request_duration_seconds_bucket{job="api", le="0.1"}'
+        values: '0+30x10'
+      - series: 'request_duration_seconds_bucket{job="api", le="1"}'
+        values: '0+60x10'
+      - series: 'request_duration_seconds_bucket{job="api", le="+Inf"}'
+        values: '0+60x10'
+    promql_expr_test:
+      - expr: job:http_requests:rate5m
+        eval_time: 10m
+        exp_samples:
+          - labels: 'job:http_requests:rate5m{job="api"}'
+            value: 1.1
+      - expr: job:http_errors:ratio5m
+        eval_time: 10m
+        exp_samples:
+          - labels: 'job:http_errors:ratio5m{job="api", team="platform"}'
+            value: 0.0909090909
+      - expr: job:request_duration_seconds:p90
+        eval_time: 10m
+        exp_samples:
+          - labels: 'job:request_duration_seconds:p90{job="api"}'
+            value: 0.82
+      - expr: absent(job:http_requests:rate5m{job="checkout"})
+        eval_time: 10m
+        exp_samples:
+          - labels: '{job="checkout"}'
+            value: 1
+    alert_rule_test:
+      - alertname: HighErrorRate
+        eval_time: 10m
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(failures) > 0 {
+			t.Fatalf("failures:\n%s", strings.Join(failures, "\n"))
+		}
+	})
+
+	t.Run("failing", func(t *testing.T) {
+		failures, err := runRecordingRulesTests(groups, `
+tests:
+  - name: wrong rate
+    input_series:
+      - series: 'http_requests_total{job="api", code="200"}'
+        values: '0+60x10'
+    promql_expr_test:
+      - expr: job:http_requests:rate5m
+        eval_time: 10m
+        exp_samples:
+          - labels: 'job:http_requests:rate5m{job="api"}'
+            value: 2
+    alert_rule_test:
+      - alertname: HighErrorRate
+        eval_time: 10m
+        exp_alerts:
+          - exp_labels:
+              severity: page
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(failures) != 2 {
+			t.Fatalf("got %d failures, want 2:\n%s", len(failures), strings.Join(failures, "\n"))
+		}
+		want := "tests[0] (wrong rate): expr: \"job:http_requests:rate5m\", time: 10m0s,\n" +
+			"    exp: [job:http_requests:rate5m{job=\"api\"} 2]\n" +
+			"    got: [job:http_requests:rate5m{job=\"api\"} 1]"
+		if failures[0] != want {
+			t.Errorf("failure =\n%s\nwant\n%s", failures[0], want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"unknown field":       "tests:\n  - input_serie: []\n",
+			"unknown group":       "group_eval_order: [missing]\n",
+			"bad series":          "tests:\n  - input_series:\n      - series: 'rate(x[5m])'\n        values: '1'\n",
+			"bad values":          "tests:\n  - input_series:\n      - series: 'x'\n        values: '1+ax2'\n",
+			"bad eval_time":       "tests:\n  - promql_expr_test:\n      - expr: x\n        eval_time: 5 minutes\n",
+			"bad expected labels": "tests:\n  - promql_expr_test:\n      - expr: x\n        exp_samples:\n          - labels: 'x{a=~\"b\"}'\n            value: 1\n",
+		} {
+			if _, err := runRecordingRulesTests(groups, content); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+}
+
+func TestOrderRuleGroups(t *testing.T) {
+	groups := []recRuless.InRuleGroup{{Name: "c"}, {Name: "a"}, {Name: "d"}, {Name: "b"}}
+
+	ordered, err := orderRuleGroups(groups, []string{"d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, group := range ordered {
+		names = append(names, group.Name)
+	}
+	if want := []string{"d", "a", "b", "c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("orderRuleGroups = %v, want %v", names, want)
+	}
+}
+
+func TestExpandSeriesValues(t *testing.T) {
+	samples, err := expandSeriesValues("1 _ 3+2x2 _x2 -1-1x1 stale 4x1 1e3", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []promSample{
+		{t: 0, v: 1},
+		{t: 2000, v: 3}, {t: 3000, v: 5}, {t: 4000, v: 7},
+		{t: 7000, v: -1}, {t: 8000, v: -2},
+		{t: 9000, stale: true},
+		{t: 10000, v: 4}, {t: 11000, v: 4},
+		{t: 12000, v: 1000},
+	}
+	if !reflect.DeepEqual(samples, want) {
+		t.Errorf("expandSeriesValues() =\n%v\nwant\n%v", samples, want)
+	}
+}
+
+func TestPromEngine(t *testing.T) {
+	storage := newPromStorage()
+	add := func(series string, values string) {
+		labels, err := parseSeriesNotation(series)
+		if err != nil {
+			t.Fatal(err)
+		}
+		samples, err := expandSeriesValues(values, 60000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, sample := range samples {
+			storage.append(labels, sample)
+		}
+	}
+	add(`up{job="api", instance="a"}`, "1x10")
+	add(`up{job="api", instance="b"}`, "0x10")
+	add(`up{job="db", instance="c"}`, "1x3 stale")
+	add(`build_info{job="api", version="1.2"}`, "1x10")
+	add(`cpu{instance="a"}`, "0+1x10")
+	add(`cpu{instance="b"}`, "10-1x10")
+
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{`up`, `[up{instance="a", job="api"} 1, up{instance="b", job="api"} 0]`},
+		{`up offset 8m`, `[up{instance="a", job="api"} 1, up{instance="b", job="api"} 0, up{instance="c", job="db"} 1]`},
+		{`sum by (job) (up)`, `[{job="api"} 1]`},
+		{`count without (instance) (up == 1)`, `[{job="api"} 1]`},
+		{`up == bool 1`, `[{instance="a", job="api"} 1, {instance="b", job="api"} 0]`},
+		{`-2 ^ 2`, `[{} -4]`},
+		{`up * on (job) group_left (version) build_info`, `[{instance="a", job="api", version="1.2"} 1, {instance="b", job="api", version="1.2"} 0]`},
+		{`topk(1, cpu)`, `[cpu{instance="a"} 10]`},
+		{`bottomk(1, cpu)`, `[cpu{instance="b"} 0]`},
+		{`quantile(0.5, cpu)`, `[{} 5]`},
+		{`max_over_time(cpu[5m])`, `[{instance="a"} 10, {instance="b"} 4]`},
+		{`last_over_time(cpu[5m])`, `[cpu{instance="a"} 10, cpu{instance="b"} 0]`},
+		{`max_over_time(sum(cpu)[10m:2m])`, `[{} 10]`},
+		{`label_replace(up{instance="a"}, "host", "host-$1", "instance", "(.*)")`, `[up{host="host-a", instance="a", job="api"} 1]`},
+		{`cpu{instance="a"} and on (instance) up`, `[cpu{instance="a"} 10]`},
+		{`cpu unless on (instance) up == 0`, `[cpu{instance="a"} 10]`},
+		{`deriv(cpu{instance="b"}[5m]) * 60`, `[{instance="b"} -1]`},
+		{`changes(up{instance="a"}[10m])`, `[{instance="a", job="api"} 0]`},
+		{`clamp_max(cpu, 5)`, `[{instance="a"} 5, {instance="b"} 0]`},
+		{`time()`, `[{} 600]`},
+	}
+	engine := &promEngine{storage: storage, defaultStep: time.Minute}
+	for _, tc := range cases {
+		failure, err := runPromQLExprTest(engine, rulesTestPromQLExpr{Expr: tc.expr}, 600000)
+		if err != nil {
+			t.Errorf("%s: %s", tc.expr, err)
+			continue
+		}
+		// The expectation is compared through the failure message of a test
+		// expecting no samples.
+		if !strings.HasSuffix(failure, "got: "+tc.want) && !(tc.want == "[]" && failure == "") {
+			t.Errorf("%s:\n%s\nwant got: %s", tc.expr, failure, tc.want)
+		}
+	}
+}
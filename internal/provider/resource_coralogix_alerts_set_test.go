@@ -0,0 +1,123 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+var alertsSetResourceName = "coralogix_alerts_set.test"
+
+func TestAccCoralogixResourceAlertsSet(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAlertsSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourceAlertsSet(`
+errors:
+  name: "tf-acc alerts set errors"
+  priority: P2
+  type_definition:
+    logs_immediate:
+      logs_filter:
+        simple_filter:
+          lucene_query: "level:error"
+warnings:
+  name: "tf-acc alerts set warnings"
+  type_definition:
+    logs_immediate:
+      logs_filter:
+        simple_filter:
+          lucene_query: "level:warning"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(alertsSetResourceName, "id"),
+					resource.TestCheckResourceAttr(alertsSetResourceName, "alerts.%", "2"),
+					resource.TestCheckResourceAttrSet(alertsSetResourceName, "alerts.errors"),
+					resource.TestCheckResourceAttrSet(alertsSetResourceName, "alerts.warnings"),
+				),
+			},
+			{
+				Config: testAccCoralogixResourceAlertsSet(`
+errors:
+  name: "tf-acc alerts set errors updated"
+  priority: P1
+  type_definition:
+    logs_immediate:
+      logs_filter:
+        simple_filter:
+          lucene_query: "level:error"
+critical:
+  name: "tf-acc alerts set critical"
+  type_definition:
+    logs_immediate:
+      logs_filter:
+        simple_filter:
+          lucene_query: "level:critical"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(alertsSetResourceName, "alerts.%", "2"),
+					resource.TestCheckResourceAttrSet(alertsSetResourceName, "alerts.errors"),
+					resource.TestCheckResourceAttrSet(alertsSetResourceName, "alerts.critical"),
+					resource.TestCheckNoResourceAttr(alertsSetResourceName, "alerts.warnings"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAlertsSetDestroy(s *terraform.State) error {
+	clientSet, err := testAccAlertClientSet()
+	if err != nil {
+		return err
+	}
+	client := clientSet.Alerts()
+
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "coralogix_alerts_set" {
+			continue
+		}
+
+		for key, id := range rs.Primary.Attributes {
+			if !strings.HasPrefix(key, "alerts.") || key == "alerts.%" {
+				continue
+			}
+			if _, _, err := client.AlertDefsServiceGetAlertDef(ctx, id).Execute(); err == nil {
+				return fmt.Errorf("alert %s of coralogix_alerts_set still exists: %s", key, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCoralogixResourceAlertsSet(document string) string {
+	return fmt.Sprintf(`resource "coralogix_alerts_set" "test" {
+  yaml_content = <<EOT
+%s
+EOT
+}
+`, document)
+}
@@ -114,6 +114,31 @@ func TestAccCoralogixResourceDashboardContentJSONProtobufSpellings(t *testing.T)
 	})
 }
 
+func TestAccCoralogixResourceDashboardContentJSONEquivalentPlan(t *testing.T) {
+	fixture := "TestAccCoralogixResourceDashboardContentJSONEquivalentPlan"
+	canonical := dashboardContentJSONNamedFixtureFor(t, "content_json_canonical.json", dashboardOpenAPIFixtureName(fixture))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDashboardDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				// The IDs and defaults the API adds do not show up in the plan.
+				Config:           dashboardContentJSONConfig(canonical.path),
+				Check:            resource.TestCheckResourceAttr(dashboardResourceName, "content_json", canonical.content),
+				ConfigPlanChecks: dashboardContentJSONPlanChecks(false),
+			},
+			{
+				// An equivalent document is planned as configured.
+				Config:             dashboardContentJSONReformattedConfig(canonical.path),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func TestAccCoralogixResourceDashboardContentJSONFolderOverride(t *testing.T) {
 	ctx := context.Background()
 	var client *dashboardservice.DashboardServiceAPIService
@@ -264,6 +289,14 @@ resource "coralogix_dashboard" "test" {
 `, fixturePath)
 }
 
+func dashboardContentJSONReformattedConfig(fixturePath string) string {
+	return fmt.Sprintf(`
+resource "coralogix_dashboard" "test" {
+  content_json = jsonencode(jsondecode(file(%q)))
+}
+`, fixturePath)
+}
+
 func dashboardContentJSONFolderOverrideConfig(fixturePath, folderName string) string {
 	return fmt.Sprintf(`
 resource "coralogix_dashboards_folder" "test_folder" {
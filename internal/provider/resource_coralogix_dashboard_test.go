@@ -174,6 +174,59 @@ func testAccDashboardVariablesV2StaticConfig(name, staticValue, selected string)
 }`
 }
 
+func TestAccCoralogixResourceDashboardVariablesV2CSV(t *testing.T) {
+	name := dashboardOpenAPIFixtureName(t.Name())
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDashboardDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardVariablesV2CSVConfig(name, `service,team\ncheckout,payments\ncatalog,search\n`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dashboardResourceName, "variables_v2.0.source.csv.value_column", "service"),
+					resource.TestCheckNoResourceAttr(dashboardResourceName, "variables_v2.0.source.static"),
+					testAccCheckDashboardVariablesV2StaticLabelOnAPI(dashboardResourceName, "payments"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				// The options follow the CSV.
+				Config: testAccDashboardVariablesV2CSVConfig(name, `service,team\nsearch-api,search\ncheckout,payments\n`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDashboardVariablesV2StaticLabelOnAPI(dashboardResourceName, "search"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func testAccDashboardVariablesV2CSVConfig(name, content string) string {
+	return testAccDashboardVariablesV2Layout(name) + `
+  variables_v2 = [{
+    name         = "service"
+    display_name = "Service"
+    source = {
+      csv = {
+        content        = "` + content + `"
+        value_column   = "service"
+        label_column   = "team"
+        default_values = ["checkout"]
+        all_option     = { include_all = true }
+      }
+    }
+    value = {
+      multi_string = { all = {} }
+    }
+  }]
+}`
+}
+
 // testAccCheckDashboardVariablesV2StaticLabelOnAPI asserts expand defaulted the
 // omitted static label onto the first static value in the live dashboard.
 func testAccCheckDashboardVariablesV2StaticLabelOnAPI(resourceName, wantLabel string) resource.TestCheckFunc {
@@ -1361,6 +1414,88 @@ resource "coralogix_dashboard" "test" {
 `, sourceName, consumerName)
 }
 
+func TestAccCoralogixResourceDashboardAutoLayout(t *testing.T) {
+	name := dashboardOpenAPIFixtureName(t.Name())
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDashboardDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourceDashboardAutoLayout(name, "large"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dashboardResourceName, "id"),
+					resource.TestCheckNoResourceAttr(dashboardResourceName, "layout.sections.#"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "auto_layout.widgets.#", "3"),
+					resource.TestCheckResourceAttrSet(dashboardResourceName, "auto_layout.widgets.0.id"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "auto_layout.widgets.0.size", "large"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "auto_layout.widgets.1.height", "19"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "auto_layout.widgets.2.section", "Notes"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "auto_layout.widgets.2.height", "10"),
+				),
+			},
+			{
+				Config: testAccCoralogixResourceDashboardAutoLayout(name, "full"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dashboardResourceName, "auto_layout.widgets.0.size", "full"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "auto_layout.widgets.1.title", "Errors"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCoralogixResourceDashboardAutoLayout(name, size string) string {
+	return fmt.Sprintf(`resource "coralogix_dashboard" test {
+  name = %q
+  auto_layout = {
+    widgets = [
+      {
+        title = "Requests"
+        size  = %q
+        definition = {
+          line_chart = {
+            query_definitions = [{
+              query = {
+                metrics = {
+                  promql_query = "sum(rate(http_requests_total[5m]))"
+                }
+              }
+            }]
+          }
+        }
+      },
+      {
+        title = "Errors"
+        size  = "large"
+        definition = {
+          line_chart = {
+            query_definitions = [{
+              query = {
+                metrics = {
+                  promql_query = "sum(rate(http_requests_total{code=~\"5..\"}[5m]))"
+                }
+              }
+            }]
+          }
+        }
+      },
+      {
+        section = "Notes"
+        size    = "full"
+        height  = 10
+        definition = {
+          markdown = {
+            markdown_text = "See the runbook."
+          }
+        }
+      },
+    ]
+  }
+}
+`, name, size)
+}
+
 func TestAccCoralogixResourceDashboardGaugeWidgetThresholdType(t *testing.T) {
 	name := dashboardOpenAPIFixtureName(t.Name())
 	resource.ParallelTest(t, resource.TestCase{
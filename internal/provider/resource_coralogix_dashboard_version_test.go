@@ -0,0 +1,88 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const dashboardVersionResourceName = "coralogix_dashboard_version.test"
+
+func TestAccCoralogixResourceDashboardVersion(t *testing.T) {
+	name := dashboardOpenAPIFixtureName(t.Name())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDashboardDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourceDashboardVersion(name, "1", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dashboardVersionResourceName, "id", dashboardResourceName, "id"),
+					resource.TestCheckResourceAttr(dashboardVersionResourceName, "keep_versions", "10"),
+					resource.TestCheckResourceAttr(dashboardVersionResourceName, "versions.#", "1"),
+					resource.TestCheckResourceAttr(dashboardVersionResourceName, "versions.0.version", "1"),
+					resource.TestCheckResourceAttrSet(dashboardVersionResourceName, "versions.0.snapshot_id"),
+					resource.TestMatchResourceAttr(dashboardVersionResourceName, "versions.0.content_json", regexp.MustCompile(`"name":"`+regexp.QuoteMeta(name)+`"`)),
+				),
+			},
+			{
+				Config: testAccCoralogixResourceDashboardVersion(name+" updated", "2", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dashboardVersionResourceName, "versions.#", "2"),
+					resource.TestCheckResourceAttr(dashboardVersionResourceName, "versions.1.version", "2"),
+					resource.TestMatchResourceAttr(dashboardVersionResourceName, "versions.1.content_json", regexp.MustCompile(`updated`)),
+				),
+			},
+			{
+				Config:      testAccCoralogixResourceDashboardVersion(name+" updated", "2", "rollback_to = 7"),
+				ExpectError: regexp.MustCompile(`version 7 is not kept`),
+			},
+		},
+	})
+}
+
+func testAccCoralogixResourceDashboardVersion(name, release, rollback string) string {
+	return fmt.Sprintf(`resource "coralogix_dashboard" test {
+  name = %q
+  layout = {
+    sections = [{
+      rows = [{
+        height = 19
+        widgets = [{
+          definition = {
+            markdown = {
+              markdown_text = "curated"
+            }
+          }
+        }]
+      }]
+    }]
+  }
+}
+
+resource "coralogix_dashboard_version" "test" {
+  dashboard_id = coralogix_dashboard.test.id
+  triggers = {
+    release = %q
+  }
+  %s
+}
+`, name, release, rollback)
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const dashboardWidgetTemplateResourceName = "coralogix_dashboard_widget_template.test"
+
+func TestAccCoralogixResourceDashboardWidgetTemplate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDashboardDestroy(t),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourceDashboardWidgetTemplate("0.99"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dashboardWidgetTemplateResourceName, "id"),
+					resource.TestCheckResourceAttr(dashboardWidgetTemplateResourceName, "parameters.quantile.default", "0.99"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "layout.sections.0.rows.0.widgets.0.title", "checkout p0.99 latency"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "layout.sections.0.rows.0.widgets.1.title", "billing p0.99 latency"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "layout.sections.0.rows.0.widgets.0.definition.line_chart.query_definitions.0.query.metrics.promql_query",
+						`histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket{service="checkout"}[5m])))`),
+				),
+			},
+			{
+				Config: testAccCoralogixResourceDashboardWidgetTemplate("0.5"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dashboardResourceName, "layout.sections.0.rows.0.widgets.0.title", "checkout p0.5 latency"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "layout.sections.0.rows.0.widgets.1.definition.line_chart.query_definitions.0.query.metrics.promql_query",
+						`histogram_quantile(0.5, sum by (le) (rate(http_request_duration_seconds_bucket{service="billing"}[5m])))`),
+				),
+			},
+			{
+				Config: `resource "coralogix_dashboard_widget_template" "test" {
+  name  = "latency"
+  title = "{{ .service }} latency"
+  definition = {
+    line_chart = {}
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`undeclared parameter "service"`),
+			},
+		},
+	})
+}
+
+func testAccCoralogixResourceDashboardWidgetTemplate(quantile string) string {
+	return `resource "coralogix_dashboard_widget_template" "test" {
+  name  = "latency"
+  title = "{{ .service }} p{{ .quantile }} latency"
+  parameters = {
+    service = {}
+    quantile = {
+      default = "` + quantile + `"
+    }
+  }
+  definition = {
+    line_chart = {
+      query_definitions = [
+        {
+          query = {
+            metrics = {
+              promql_query = "histogram_quantile({{ .quantile }}, sum by (le) (rate(http_request_duration_seconds_bucket{service=\"{{ .service }}\"}[5m])))"
+            }
+          }
+        }
+      ]
+    }
+  }
+}
+
+resource "coralogix_dashboard" "test" {
+  name = "widget template dashboard"
+  layout = {
+    sections = [
+      {
+        rows = [
+          {
+            height = 19
+            widgets = [
+              provider::coralogix::render_dashboard_widget(coralogix_dashboard_widget_template.test, { service = "checkout" }),
+              provider::coralogix::render_dashboard_widget(coralogix_dashboard_widget_template.test, { service = "billing" }),
+            ]
+          }
+        ]
+      }
+    ]
+  }
+}
+`
+}
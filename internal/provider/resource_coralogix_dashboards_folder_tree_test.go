@@ -0,0 +1,83 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var dashboardsFolderTreeResourceName = "coralogix_dashboards_folder_tree.test"
+
+func TestAccCoralogixResourceDashboardsFolderTree(t *testing.T) {
+	root := acctest.RandomWithPrefix("tf-acc-dashboards-folder-tree")
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDashboardDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourceDashboardsFolderTree(root, `"k8s/nodes"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dashboardsFolderTreeResourceName, "id"),
+					resource.TestCheckResourceAttr(dashboardsFolderTreeResourceName, "folders.%", "3"),
+					resource.TestCheckResourceAttr(dashboardsFolderTreeResourceName, "folders."+root+"/k8s/nodes.managed", "true"),
+					resource.TestCheckResourceAttrPair(dashboardsFolderTreeResourceName, "folders."+root+"/k8s/nodes.parent_id", dashboardsFolderTreeResourceName, "folders."+root+"/k8s.id"),
+					resource.TestCheckResourceAttr(dashboardResourceName, "folder.path", root+"/k8s/nodes"),
+				),
+			},
+			{
+				Config: testAccCoralogixResourceDashboardsFolderTree(root, `"k8s/nodes", "k8s/pods"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dashboardsFolderTreeResourceName, "folders.%", "4"),
+					resource.TestCheckResourceAttrSet(dashboardsFolderTreeResourceName, "folders."+root+"/k8s/pods.id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCoralogixResourceDashboardsFolderTree(root, paths string) string {
+	return fmt.Sprintf(`resource "coralogix_dashboards_folder_tree" "test" {
+  paths = [for path in [%s] : "%s/${path}"]
+}
+
+resource "coralogix_dashboard" "test" {
+  name = "%s"
+  folder = {
+    path = "%s/k8s/nodes"
+  }
+  layout = {
+    sections = [{
+      rows = [{
+        height = 19
+        widgets = [{
+          definition = {
+            markdown = {
+              markdown_text = "nodes"
+            }
+          }
+        }]
+      }]
+    }]
+  }
+
+  depends_on = [coralogix_dashboards_folder_tree.test]
+}
+`, paths, root, root, root)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
@@ -95,6 +96,82 @@ func TestAccCoralogixResourceCustomDataEnrichmentsWithUploadedFile(t *testing.T)
 	})
 }
 
+func TestAccCoralogixResourceCustomDataEnrichmentsWithSourceFile(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	description := acctest.RandomWithPrefix("tf-acc-test")
+	filePath := filepath.Join(t.TempDir(), "ip-to-team.csv")
+	writeCsv := func(content string) {
+		if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeCsv("ip,team\n10.0.0.1,payments\n10.0.0.2,search\n")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourceCustomDataEnrichmentsSourceFile(name, description, filePath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataEnrichmentResourceName, "custom.custom_enrichment_data.id"),
+					resource.TestCheckNoResourceAttr(dataEnrichmentResourceName, "custom.custom_enrichment_data.contents"),
+					resource.TestCheckResourceAttr(dataEnrichmentResourceName, "custom.custom_enrichment_data.source_sha256", "772f49d8054a10250e253c9c7bc44f29d73c4b94c2dcab5785a9de6a9da31bb7"),
+					resource.TestCheckResourceAttr(dataEnrichmentResourceName, "custom.custom_enrichment_data.source_rows", "2"),
+					resource.TestCheckResourceAttr(dataEnrichmentResourceName, "custom.custom_enrichment_data.version", "1"),
+				),
+			},
+			{
+				PreConfig: func() { writeCsv("ip,team\n10.0.0.1,payments\n10.0.0.2,search\n10.0.0.3,billing\n") },
+				Config:    testAccCoralogixResourceCustomDataEnrichmentsSourceFile(name, description, filePath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataEnrichmentResourceName, "custom.custom_enrichment_data.source_rows", "3"),
+					resource.TestCheckResourceAttr(dataEnrichmentResourceName, "custom.custom_enrichment_data.version", "2"),
+				),
+			},
+			{
+				PlanOnly: true,
+				Config:   testAccCoralogixResourceCustomDataEnrichmentsSourceFile(name, description, filePath),
+			},
+		},
+	})
+}
+
+func TestAccCoralogixResourceCustomDataEnrichmentsWithDeclaredColumns(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	const table = `ip,team\n10.0.0.1,payments\n10.0.0.2,search\n`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PlanOnly:    true,
+				Config:      testAccCoralogixResourceCustomDataEnrichmentsColumns(name, `ip,owner\n10.0.0.1,payments\n`, "team"),
+				ExpectError: regexp.MustCompile(`the\s+header\s+row\s+is\s+\["ip"\s+"owner"\],\s+columns\s+declares`),
+			},
+			{
+				PlanOnly:    true,
+				Config:      testAccCoralogixResourceCustomDataEnrichmentsColumns(name, `ip,team\n10.0.0.1,payments\n10.0.0.1,search\n`, "team"),
+				ExpectError: regexp.MustCompile(`line\s+3:\s+duplicate\s+key\s+"10.0.0.1"`),
+			},
+			{
+				PlanOnly:    true,
+				Config:      testAccCoralogixResourceCustomDataEnrichmentsColumns(name, table, "owner"),
+				ExpectError: regexp.MustCompile(`"owner"\s+is\s+not\s+a\s+column`),
+			},
+			{
+				Config: testAccCoralogixResourceCustomDataEnrichmentsColumns(name, table, "team"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataEnrichmentResourceName, "custom.custom_enrichment_data.columns.#", "2"),
+					resource.TestCheckResourceAttr(dataEnrichmentResourceName, "custom.custom_enrichment_data.key_column", "ip"),
+					resource.TestCheckResourceAttr(dataEnrichmentResourceName, "custom.fields.0.selected_columns.0", "team"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCoralogixResourceGeoIpDataEnrichment(t *testing.T) {
 	fieldName := "coralogix.metadata.sdkId"
 	resource.Test(t, resource.TestCase{
@@ -417,3 +494,38 @@ func testAccCoralogixResourceCustomDataEnrichments(name, description, fileConten
     }
 	`, name, description, fileContents)
 }
+
+func testAccCoralogixResourceCustomDataEnrichmentsSourceFile(name, description, sourceFile string) string {
+	return fmt.Sprintf(`
+	resource "coralogix_data_enrichments" test{
+        custom = {
+            custom_enrichment_data = {
+				name         = "%s"
+				description  = "%s"
+				source_file  = %q
+			}
+            fields = []
+        }
+    }
+	`, name, description, sourceFile)
+}
+
+func testAccCoralogixResourceCustomDataEnrichmentsColumns(name, contents, selectedColumn string) string {
+	return fmt.Sprintf(`
+	resource "coralogix_data_enrichments" test{
+        custom = {
+            custom_enrichment_data = {
+				name        = "%s"
+				contents    = "%s"
+				columns     = ["ip", "team"]
+				key_column  = "ip"
+			}
+            fields = [{
+				name                = "ip"
+				enriched_field_name = "ip_team"
+				selected_columns    = ["%s"]
+			}]
+        }
+    }
+	`, name, contents, selectedColumn)
+}
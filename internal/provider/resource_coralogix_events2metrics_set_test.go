@@ -0,0 +1,136 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+var events2MetricsSetResourceName = "coralogix_events2metrics_set.test"
+
+func TestAccCoralogixResourceEvents2MetricsSet(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEvents2MetricsSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourceEvents2MetricsSet(`
+tf_acc_e2m_set_latency:
+  logs_query:
+    lucene: "path:/api/*"
+  metric_fields:
+    latency:
+      source_field: request_time
+      aggregations:
+        avg:
+          enable: true
+        histogram:
+          enable: true
+          buckets: [0.1, 0.5, 1]
+  metric_labels:
+    status: status
+tf_acc_e2m_set_spans:
+  spans_query:
+    services: ["checkout"]
+  metric_fields:
+    duration:
+      source_field: duration
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(events2MetricsSetResourceName, "id"),
+					resource.TestCheckResourceAttr(events2MetricsSetResourceName, "events2metrics.%", "2"),
+					resource.TestCheckResourceAttrSet(events2MetricsSetResourceName, "events2metrics.tf_acc_e2m_set_latency"),
+					resource.TestCheckResourceAttrSet(events2MetricsSetResourceName, "events2metrics.tf_acc_e2m_set_spans"),
+				),
+			},
+			{
+				Config: testAccCoralogixResourceEvents2MetricsSet(`
+tf_acc_e2m_set_latency:
+  description: updated
+  logs_query:
+    lucene: "path:/api/*"
+  metric_fields:
+    latency:
+      source_field: request_time
+      aggregations:
+        max:
+          enable: true
+  metric_labels:
+    status: status
+    method: method
+tf_acc_e2m_set_errors:
+  logs_query:
+    severities: ["Error"]
+  metric_fields:
+    code:
+      source_field: status
+      aggregations:
+        samples:
+          enable: true
+          type: Max
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(events2MetricsSetResourceName, "events2metrics.%", "2"),
+					resource.TestCheckResourceAttrSet(events2MetricsSetResourceName, "events2metrics.tf_acc_e2m_set_latency"),
+					resource.TestCheckResourceAttrSet(events2MetricsSetResourceName, "events2metrics.tf_acc_e2m_set_errors"),
+					resource.TestCheckNoResourceAttr(events2MetricsSetResourceName, "events2metrics.tf_acc_e2m_set_spans"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckEvents2MetricsSetDestroy(s *terraform.State) error {
+	clients, err := testAccNewClientSet()
+	if err != nil {
+		return err
+	}
+	client := clients.Events2Metrics()
+
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "coralogix_events2metrics_set" {
+			continue
+		}
+
+		for key, id := range rs.Primary.Attributes {
+			if !strings.HasPrefix(key, "events2metrics.") || key == "events2metrics.%" {
+				continue
+			}
+			if _, _, err := client.Events2MetricServiceGetE2M(ctx, id).Execute(); err == nil {
+				return fmt.Errorf("events2metric %s of coralogix_events2metrics_set still exists: %s", key, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCoralogixResourceEvents2MetricsSet(document string) string {
+	return fmt.Sprintf(`resource "coralogix_events2metrics_set" "test" {
+  yaml_content = <<EOT
+%s
+EOT
+}
+`, document)
+}
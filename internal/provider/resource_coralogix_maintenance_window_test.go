@@ -0,0 +1,110 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
+
+	terraform2 "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+var maintenanceWindowResourceName = "coralogix_maintenance_window.test"
+
+func TestAccCoralogixResourceMaintenanceWindow(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMaintenanceWindowDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourceMaintenanceWindowOneTime(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(maintenanceWindowResourceName, "id"),
+					resource.TestCheckResourceAttr(maintenanceWindowResourceName, "selector.service", "checkout"),
+					resource.TestCheckResourceAttr(maintenanceWindowResourceName, "duration", "30m"),
+					resource.TestCheckResourceAttr(maintenanceWindowResourceName, "what_expression", "source logs | filter true"),
+				),
+			},
+			{
+				Config: testAccCoralogixResourceMaintenanceWindowRecurring(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(maintenanceWindowResourceName, "id"),
+					resource.TestCheckResourceAttr(maintenanceWindowResourceName, "recurrence.cron", "30 22 * * SAT"),
+					resource.TestCheckResourceAttr(maintenanceWindowResourceName, "duration", "2h"),
+					resource.TestCheckResourceAttr(maintenanceWindowResourceName, "what_expression", "source logs | filter $d.region == 'eu-west-1'"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMaintenanceWindowDestroy(s *terraform.State) error {
+	testAccProvider = OldProvider()
+	rc := terraform2.ResourceConfig{}
+	testAccProvider.Configure(context.Background(), &rc)
+	client := testAccProvider.Meta().(*clientset.ClientSet).AlertSchedulers()
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "coralogix_maintenance_window" {
+			continue
+		}
+
+		if _, _, err := client.AlertSchedulerRuleServiceGetAlertSchedulerRule(ctx, rs.Primary.ID).Execute(); err == nil {
+			return fmt.Errorf("maintenance window still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCoralogixResourceMaintenanceWindowOneTime() string {
+	return `resource "coralogix_maintenance_window" "test" {
+  name        = "tf-acc checkout deploy"
+  description = "Mute checkout alerts during a deploy"
+  selector = {
+    service = "checkout"
+  }
+  start_time = "2030-01-01T10:00:00Z"
+  duration   = "30m"
+}
+`
+}
+
+func testAccCoralogixResourceMaintenanceWindowRecurring() string {
+	return `resource "coralogix_maintenance_window" "test" {
+  name = "tf-acc weekly maintenance"
+  selector = {
+    service = "checkout"
+    team    = ""
+  }
+  entity_selector = {
+    region = "eu-west-1"
+  }
+  start_time = "2030-01-01T00:00:00Z"
+  duration   = "2h"
+  recurrence = {
+    cron  = "30 22 * * SAT"
+    until = "2030-12-31T00:00:00Z"
+  }
+}
+`
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+var parsingRulesOrderResourceName = "coralogix_parsing_rules_order.test"
+
+func TestAccCoralogixResourceParsingRulesOrderResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckParsingRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourceParsingRulesOrder(name, "coralogix_parsing_rules.second.id", "coralogix_parsing_rules.first.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(parsingRulesOrderResourceName, "id"),
+					resource.TestCheckResourceAttr(parsingRulesOrderResourceName, "first_order", "1"),
+					resource.TestCheckResourceAttrPair(parsingRulesOrderResourceName, "rule_group_ids.0", "coralogix_parsing_rules.second", "id"),
+					resource.TestCheckResourceAttrPair(parsingRulesOrderResourceName, "rule_group_ids.1", "coralogix_parsing_rules.first", "id"),
+				),
+			},
+			{
+				ResourceName:            parsingRulesOrderResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"id"},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[parsingRulesOrderResourceName]
+					return fmt.Sprintf("%s,%s", rs.Primary.Attributes["rule_group_ids.0"], rs.Primary.Attributes["rule_group_ids.1"]), nil
+				},
+			},
+			{
+				Config: testAccCoralogixResourceParsingRulesOrder(name, "coralogix_parsing_rules.first.id", "coralogix_parsing_rules.second.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(parsingRulesOrderResourceName, "rule_group_ids.0", "coralogix_parsing_rules.first", "id"),
+					resource.TestCheckResourceAttrPair(parsingRulesOrderResourceName, "rule_group_ids.1", "coralogix_parsing_rules.second", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCoralogixResourceParsingRulesOrder(name string, ids ...string) string {
+	return fmt.Sprintf(`resource "coralogix_parsing_rules" "first" {
+  name = "%[1]s-first"
+  rule_subgroups = [{
+    rules = [{
+      block = {
+        name               = "block debug"
+        source_field       = "text"
+        regular_expression = "debug"
+        keep_blocked_logs  = false
+      }
+    }]
+  }]
+}
+
+resource "coralogix_parsing_rules" "second" {
+  name = "%[1]s-second"
+  rule_subgroups = [{
+    rules = [{
+      block = {
+        name               = "block trace"
+        source_field       = "text"
+        regular_expression = "trace"
+        keep_blocked_logs  = false
+      }
+    }]
+  }]
+}
+
+resource "coralogix_parsing_rules_order" "test" {
+  rule_group_ids = [%[2]s, %[3]s]
+}
+`, name, ids[0], ids[1])
+}
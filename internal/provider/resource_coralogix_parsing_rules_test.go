@@ -17,6 +17,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -280,6 +281,32 @@ func TestAccCoralogixResourceParsingRules_jsonExtractDestinationFieldCaseInsensi
 	})
 }
 
+func TestAccCoralogixResourceParsingRules_invalidRegularExpression(t *testing.T) {
+	r := getRandomParsingRule()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCoralogixResourceParsingRulesReplace(r, `(?<=user=)\\w+`, "***"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid regular expression`),
+			},
+			{
+				Config:      testAccCoralogixResourceParsingRulesExtract(r, `level=\\w+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`has no named capture\s+group`),
+			},
+			{
+				Config:      testAccCoralogixResourceParsingRulesJsonExtract(r, "message", "Text"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`destination_field_text is required\s+when\s+destination_field\s+is\s+text`),
+			},
+		},
+	})
+}
+
 func TestAccCoralogixResourceParsingRules_replace(t *testing.T) {
 	r := getRandomParsingRule()
 
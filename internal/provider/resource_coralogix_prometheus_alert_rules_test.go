@@ -0,0 +1,112 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+var prometheusAlertRulesResourceName = "coralogix_prometheus_alert_rules.test"
+
+func TestAccCoralogixResourcePrometheusAlertRules(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPrometheusAlertRulesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCoralogixResourcePrometheusAlertRules(`
+groups:
+  - name: tf-acc
+    rules:
+      - alert: TfAccHighErrorRate
+        expr: sum(rate(http_requests_total{code=~"5.."}[5m])) > 5
+        for: 10m
+        labels:
+          severity: critical
+      - alert: TfAccTargetDown
+        expr: up == 0
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(prometheusAlertRulesResourceName, "id"),
+					resource.TestCheckResourceAttr(prometheusAlertRulesResourceName, "severity_label", "severity"),
+					resource.TestCheckResourceAttr(prometheusAlertRulesResourceName, "alerts.%", "2"),
+					resource.TestCheckResourceAttr(prometheusAlertRulesResourceName, "drifted_alerts.#", "0"),
+					resource.TestCheckResourceAttrSet(prometheusAlertRulesResourceName, "alerts.tf-acc/TfAccHighErrorRate"),
+					resource.TestCheckResourceAttrSet(prometheusAlertRulesResourceName, "alerts.tf-acc/TfAccTargetDown"),
+				),
+			},
+			{
+				Config: testAccCoralogixResourcePrometheusAlertRules(`
+groups:
+  - name: tf-acc
+    rules:
+      - alert: TfAccHighErrorRate
+        expr: sum(rate(http_requests_total{code=~"5.."}[5m])) > 10
+        for: 15m
+        labels:
+          severity: warning
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(prometheusAlertRulesResourceName, "alerts.%", "1"),
+					resource.TestCheckResourceAttrSet(prometheusAlertRulesResourceName, "alerts.tf-acc/TfAccHighErrorRate"),
+					resource.TestCheckNoResourceAttr(prometheusAlertRulesResourceName, "alerts.tf-acc/TfAccTargetDown"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPrometheusAlertRulesDestroy(s *terraform.State) error {
+	clientSet, err := testAccAlertClientSet()
+	if err != nil {
+		return err
+	}
+	client := clientSet.Alerts()
+
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "coralogix_prometheus_alert_rules" {
+			continue
+		}
+
+		for key, id := range rs.Primary.Attributes {
+			if !strings.HasPrefix(key, "alerts.") || key == "alerts.%" {
+				continue
+			}
+			if _, _, err := client.AlertDefsServiceGetAlertDef(ctx, id).Execute(); err == nil {
+				return fmt.Errorf("alert %s of coralogix_prometheus_alert_rules still exists: %s", key, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCoralogixResourcePrometheusAlertRules(rules string) string {
+	return fmt.Sprintf(`resource "coralogix_prometheus_alert_rules" "test" {
+  yaml_content = <<EOT
+%s
+EOT
+}
+`, rules)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/coralogix/terraform-provider-coralogix/internal/clientset"
@@ -224,6 +225,58 @@ func TestAccCoralogixRecordingRulesGroupsExplicit(t *testing.T) {
 	})
 }
 
+func TestAccCoralogixRecordingRulesGroupsSetWithTests(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	parent := filepath.Dir(filepath.Dir(wd))
+	filePath := parent + "/examples/resources/coralogix_recording_rules_groups_set/rule-group-set.yaml"
+	testsPath := parent + "/examples/resources/coralogix_recording_rules_groups_set/tests.yaml"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRecordingRulesGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCoralogixResourceRecordingRulesGroupsSetWithFailingTests(filePath),
+				ExpectError: regexp.MustCompile("Recording\\s+rules\\s+test\\s+failed"),
+			},
+			{
+				Config: testAccCoralogixResourceRecordingRulesGroupsSetWithTests(filePath, testsPath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(recordingRulesGroupsSetResourceName, "id"),
+					resource.TestCheckResourceAttrSet(recordingRulesGroupsSetResourceName, "tests_yaml"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCoralogixRecordingRulesGroupsSetLint(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	parent := filepath.Dir(filepath.Dir(wd))
+	filePath := parent + "/examples/resources/coralogix_recording_rules_groups_set/rule-group-set.yaml"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRecordingRulesGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCoralogixResourceRecordingRulesGroupsSetFromYamlWithLint(filePath),
+				ExpectError: regexp.MustCompile("Recording\\s+rule\\s+name\\s+does\\s+not\\s+follow\\s+the\\s+naming\\s+convention"),
+			},
+			{
+				Config:      testAccCoralogixResourceRecordingRulesGroupsSetWithCycle(),
+				ExpectError: regexp.MustCompile("Recording\\s+rules\\s+dependency\\s+cycle"),
+			},
+		},
+	})
+}
+
 func testAccCheckRecordingRulesGroupDestroy(s *terraform.State) error {
 	meta := testAccProvider.Meta()
 	if meta == nil {
@@ -274,6 +327,65 @@ func testAccCoralogixResourceRecordingRulesGroupsSetFromYaml(filePath string) st
 `, filePath)
 }
 
+func testAccCoralogixResourceRecordingRulesGroupsSetWithTests(filePath, testsPath string) string {
+	return fmt.Sprintf(
+		`resource "coralogix_recording_rules_groups_set" "test" {
+					yaml_content = file("%s")
+					tests_yaml   = file("%s")
+				}
+`, filePath, testsPath)
+}
+
+func testAccCoralogixResourceRecordingRulesGroupsSetWithFailingTests(filePath string) string {
+	return fmt.Sprintf(
+		`resource "coralogix_recording_rules_groups_set" "test" {
+					yaml_content = file("%s")
+					tests_yaml   = <<EOT
+tests:
+  - input_series:
+      - series: 'http_requests_total{job="api"}'
+        values: '0+60x10'
+    promql_expr_test:
+      - expr: job:http_requests_total:sum
+        eval_time: 10m
+        exp_samples:
+          - labels: 'job:http_requests_total:sum{job="api"}'
+            value: 2
+EOT
+				}
+`, filePath)
+}
+
+func testAccCoralogixResourceRecordingRulesGroupsSetFromYamlWithLint(filePath string) string {
+	return fmt.Sprintf(
+		`resource "coralogix_recording_rules_groups_set" "test" {
+					yaml_content = file("%s")
+					lint         = true
+				}
+`, filePath)
+}
+
+func testAccCoralogixResourceRecordingRulesGroupsSetWithCycle() string {
+	return `resource "coralogix_recording_rules_groups_set" "test" {
+            groups = [
+              {
+                name  = "Cycle"
+                rules = [
+                  {
+                    record = "job:a:sum"
+                    expr   = "sum by (job) (job:b:sum)"
+                  },
+                  {
+                    record = "job:b:sum"
+                    expr   = "sum by (job) (job:a:sum)"
+                  },
+                ]
+              },
+            ]
+          }
+`
+}
+
 func testAccCoralogixResourceRecordingRulesGroupsSetExplicit(name string) string {
 	return fmt.Sprintf(`resource "coralogix_recording_rules_groups_set" "test" {
             name   = %q
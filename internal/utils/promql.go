@@ -0,0 +1,820 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// PromQLSelector is a vector selector of a PromQL query. Metric is empty when
+// the selector only has label matchers.
+type PromQLSelector struct {
+	Metric string
+	Labels []string
+}
+
+var (
+	promQLAggregations = []string{
+		"avg", "bottomk", "count", "count_values", "group", "limit_ratio", "limitk", "max", "min",
+		"quantile", "stddev", "stdvar", "sum", "topk",
+	}
+	promQLFunctions = []string{
+		"abs", "absent", "absent_over_time", "acos", "acosh", "asin", "asinh", "atan", "atanh",
+		"avg_over_time", "ceil", "changes", "clamp", "clamp_max", "clamp_min", "cos", "cosh",
+		"count_over_time", "day_of_month", "day_of_week", "day_of_year", "days_in_month", "deg",
+		"delta", "deriv", "double_exponential_smoothing", "exp", "floor", "histogram_avg",
+		"histogram_count", "histogram_fraction", "histogram_quantile", "histogram_stddev",
+		"histogram_stdvar", "histogram_sum", "holt_winters", "hour", "idelta", "increase", "info",
+		"irate", "label_join", "label_replace", "last_over_time", "ln", "log10", "log2",
+		"mad_over_time", "max_over_time", "min_over_time", "minute", "month", "pi",
+		"predict_linear", "present_over_time", "quantile_over_time", "rad", "rate", "resets",
+		"round", "scalar", "sgn", "sin", "sinh", "sort", "sort_by_label", "sort_by_label_desc",
+		"sort_desc", "sqrt", "stddev_over_time", "stdvar_over_time", "sum_over_time", "tan", "tanh",
+		"time", "timestamp", "vector", "year",
+	}
+	// promQLBinaryPrecedence lists the binary operators from the loosest to
+	// the tightest binding.
+	promQLBinaryPrecedence = [][]string{
+		{"or"},
+		{"and", "unless"},
+		{"==", "!=", "<=", "<", ">=", ">"},
+		{"+", "-"},
+		{"*", "/", "%", "atan2"},
+		{"^"},
+	}
+)
+
+type promQLTokenKind int
+
+const (
+	promQLEOF promQLTokenKind = iota
+	promQLIdentifier
+	promQLNumber
+	promQLDuration
+	promQLString
+	promQLVariable
+	promQLPunctuation
+)
+
+type promQLToken struct {
+	kind promQLTokenKind
+	text string
+	pos  int
+}
+
+// PromQLExpr is a node of the syntax tree of a PromQL query. Durations are
+// kept as written, since they may be dashboard variables, and are read with
+// ParsePromQLDuration.
+type PromQLExpr interface {
+	promQLExpr()
+}
+
+type PromQLNumberLiteral struct {
+	Value float64
+}
+
+type PromQLStringLiteral struct {
+	Value string
+}
+
+// PromQLVariable is a dashboard variable used in place of a number or string.
+type PromQLVariable struct {
+	Name string
+}
+
+// PromQLMatcher is a label matcher. Type is one of =, !=, =~ and !~.
+type PromQLMatcher struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+type PromQLVectorSelector struct {
+	Metric   string
+	Matchers []PromQLMatcher
+	Offset   string
+	At       string
+}
+
+type PromQLMatrixSelector struct {
+	Selector *PromQLVectorSelector
+	Range    string
+}
+
+type PromQLSubqueryExpr struct {
+	Expr   PromQLExpr
+	Range  string
+	Step   string
+	Offset string
+	At     string
+}
+
+type PromQLCall struct {
+	Func string
+	Args []PromQLExpr
+}
+
+type PromQLAggregateExpr struct {
+	Op       string
+	Grouping []string
+	Without  bool
+	Param    PromQLExpr
+	Expr     PromQLExpr
+}
+
+// PromQLVectorMatching is the on/ignoring and group_left/group_right clause
+// of a binary operation.
+type PromQLVectorMatching struct {
+	On         bool
+	Labels     []string
+	GroupLeft  bool
+	GroupRight bool
+	Include    []string
+}
+
+type PromQLBinaryExpr struct {
+	Op         string
+	LHS        PromQLExpr
+	RHS        PromQLExpr
+	ReturnBool bool
+	Matching   *PromQLVectorMatching
+}
+
+type PromQLUnaryExpr struct {
+	Op   string
+	Expr PromQLExpr
+}
+
+type PromQLParenExpr struct {
+	Expr PromQLExpr
+}
+
+func (*PromQLNumberLiteral) promQLExpr()  {}
+func (*PromQLStringLiteral) promQLExpr()  {}
+func (*PromQLVariable) promQLExpr()       {}
+func (*PromQLVectorSelector) promQLExpr() {}
+func (*PromQLMatrixSelector) promQLExpr() {}
+func (*PromQLSubqueryExpr) promQLExpr()   {}
+func (*PromQLCall) promQLExpr()           {}
+func (*PromQLAggregateExpr) promQLExpr()  {}
+func (*PromQLBinaryExpr) promQLExpr()     {}
+func (*PromQLUnaryExpr) promQLExpr()      {}
+func (*PromQLParenExpr) promQLExpr()      {}
+
+var promQLDurationPattern = regexp.MustCompile(`^(\d+(ms|s|m|h|d|w|y))+$`)
+
+// ParsePromQLDuration reads a duration of a PromQL query, such as 1h30m or a
+// number of seconds.
+func ParsePromQLDuration(text string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(text, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	if !promQLDurationPattern.MatchString(text) {
+		return 0, fmt.Errorf("bad duration %q", text)
+	}
+	units := map[string]time.Duration{
+		"ms": time.Millisecond, "s": time.Second, "m": time.Minute, "h": time.Hour,
+		"d": 24 * time.Hour, "w": 7 * 24 * time.Hour, "y": 365 * 24 * time.Hour,
+	}
+	var duration time.Duration
+	for _, part := range regexp.MustCompile(`\d+(ms|s|m|h|d|w|y)`).FindAllStringSubmatch(text, -1) {
+		value, err := strconv.ParseInt(strings.TrimSuffix(part[0], part[1]), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("bad duration %q", text)
+		}
+		duration += time.Duration(value) * units[part[1]]
+	}
+	return duration, nil
+}
+
+// ParsePromQL checks the syntax of a PromQL query and returns its vector
+// selectors, and the names of the functions it calls that are not known
+// PromQL functions. Those are not syntax errors, as Coralogix may support
+// functions missing from the list, and callers report them as warnings.
+// Dashboard variables (`{{ name }}`, `$name` and `${name}`) are accepted
+// wherever a number, duration or string is.
+func ParsePromQL(query string) ([]PromQLSelector, []string, error) {
+	p, _, err := parsePromQL(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.selectors, p.unknownFunctions, nil
+}
+
+// ParsePromQLExpr parses a PromQL query into its syntax tree.
+func ParsePromQLExpr(query string) (PromQLExpr, error) {
+	_, expr, err := parsePromQL(query)
+	return expr, err
+}
+
+func parsePromQL(query string) (*promQLParser, PromQLExpr, error) {
+	tokens, err := lexPromQL(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	p := &promQLParser{tokens: tokens}
+	expr, err := p.parseExpression(0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if token := p.peek(); token.kind != promQLEOF {
+		return nil, nil, p.unexpected(token)
+	}
+	return p, expr, nil
+}
+
+func lexPromQL(query string) ([]promQLToken, error) {
+	var tokens []promQLToken
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '{' && i+1 < len(runes) && runes[i+1] == '{':
+			start := i
+			for i += 2; i+1 < len(runes) && !(runes[i] == '}' && runes[i+1] == '}'); i++ {
+			}
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("parse error at char %d: unclosed variable", start+1)
+			}
+			i += 2
+			tokens = append(tokens, promQLToken{promQLVariable, string(runes[start:i]), start})
+		case r == '$':
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '{' {
+				for i < len(runes) && runes[i] != '}' {
+					i++
+				}
+				if i == len(runes) {
+					return nil, fmt.Errorf("parse error at char %d: unclosed variable", start+1)
+				}
+				i++
+			} else {
+				for i < len(runes) && runes[i] != ':' && isPromQLIdentifierRune(runes[i], false) {
+					i++
+				}
+			}
+			if i == start+1 {
+				return nil, fmt.Errorf("parse error at char %d: unexpected character %q", start+1, r)
+			}
+			tokens = append(tokens, promQLToken{promQLVariable, string(runes[start:i]), start})
+		case r == '"' || r == '\'' || r == '`':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' && r != '`' {
+					i++
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("parse error at char %d: unterminated string", start+1)
+			}
+			i++
+			tokens = append(tokens, promQLToken{promQLString, string(runes[start:i]), start})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			kind := promQLNumber
+			if strings.HasPrefix(strings.ToLower(string(runes[i:])), "0x") {
+				i += 2
+				for i < len(runes) && strings.ContainsRune("0123456789abcdefABCDEF", runes[i]) {
+					i++
+				}
+			} else {
+				for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+					i++
+				}
+				if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') && i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '+' || runes[i+1] == '-') {
+					i += 2
+					for i < len(runes) && unicode.IsDigit(runes[i]) {
+						i++
+					}
+				}
+				for i < len(runes) && strings.ContainsRune("smhdwy", runes[i]) {
+					kind = promQLDuration
+					if runes[i] == 'm' && i+1 < len(runes) && runes[i+1] == 's' {
+						i++
+					}
+					i++
+					for i < len(runes) && unicode.IsDigit(runes[i]) {
+						i++
+					}
+				}
+			}
+			if i < len(runes) && runes[i] != ':' && isPromQLIdentifierRune(runes[i], false) {
+				return nil, fmt.Errorf("parse error at char %d: bad number or duration %q", start+1, string(runes[start:i+1]))
+			}
+			tokens = append(tokens, promQLToken{kind, string(runes[start:i]), start})
+		case isPromQLIdentifierRune(r, true):
+			start := i
+			for i < len(runes) && isPromQLIdentifierRune(runes[i], false) {
+				i++
+			}
+			tokens = append(tokens, promQLToken{promQLIdentifier, string(runes[start:i]), start})
+		default:
+			start := i
+			operator := string(r)
+			if i+1 < len(runes) {
+				if pair := string(runes[i : i+2]); slices.Contains([]string{"==", "!=", "<=", ">=", "=~", "!~"}, pair) {
+					operator = pair
+				}
+			}
+			if !strings.Contains("(){}[],:@+-*/%^=<>", operator) && !slices.Contains([]string{"==", "!=", "<=", ">=", "=~", "!~"}, operator) {
+				return nil, fmt.Errorf("parse error at char %d: unexpected character %q", start+1, r)
+			}
+			i += len([]rune(operator))
+			tokens = append(tokens, promQLToken{promQLPunctuation, operator, start})
+		}
+	}
+	return append(tokens, promQLToken{promQLEOF, "", len(runes)}), nil
+}
+
+func isPromQLIdentifierRune(r rune, first bool) bool {
+	return r == '_' || (r < unicode.MaxASCII && unicode.IsLetter(r)) || (!first && (r == ':' || unicode.IsDigit(r)))
+}
+
+type promQLParser struct {
+	tokens           []promQLToken
+	pos              int
+	selectors        []PromQLSelector
+	unknownFunctions []string
+}
+
+func (p *promQLParser) peek() promQLToken {
+	return p.tokens[p.pos]
+}
+
+func (p *promQLParser) next() promQLToken {
+	token := p.tokens[p.pos]
+	if token.kind != promQLEOF {
+		p.pos++
+	}
+	return token
+}
+
+// is reports whether the next token is text. Like in Prometheus, keywords
+// such as by, without and offset are case-insensitive.
+func (p *promQLParser) is(text string) bool {
+	token := p.peek()
+	switch token.kind {
+	case promQLPunctuation:
+		return token.text == text
+	case promQLIdentifier:
+		return strings.EqualFold(token.text, text)
+	}
+	return false
+}
+
+func (p *promQLParser) expect(text string) error {
+	if !p.is(text) {
+		return p.unexpected(p.peek())
+	}
+	p.next()
+	return nil
+}
+
+func (p *promQLParser) unexpected(token promQLToken) error {
+	if token.kind == promQLEOF {
+		return fmt.Errorf("parse error at char %d: unexpected end of input", token.pos+1)
+	}
+	return fmt.Errorf("parse error at char %d: unexpected %q", token.pos+1, token.text)
+}
+
+func (p *promQLParser) binaryOperator(level int) bool {
+	token := p.peek()
+	if token.kind != promQLPunctuation && token.kind != promQLIdentifier {
+		return false
+	}
+	return slices.Contains(promQLBinaryPrecedence[level], strings.ToLower(token.text))
+}
+
+func (p *promQLParser) parseExpression(level int) (PromQLExpr, error) {
+	if level == len(promQLBinaryPrecedence) {
+		return p.parseUnary()
+	}
+	lhs, err := p.parseExpression(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for p.binaryOperator(level) {
+		binary := &PromQLBinaryExpr{Op: strings.ToLower(p.next().text), LHS: lhs}
+		if err := p.parseBinaryModifiers(binary); err != nil {
+			return nil, err
+		}
+		// ^ is right associative, all other operators are left associative.
+		next := level + 1
+		if binary.Op == "^" {
+			next = level
+		}
+		if binary.RHS, err = p.parseExpression(next); err != nil {
+			return nil, err
+		}
+		lhs = binary
+	}
+	return lhs, nil
+}
+
+func (p *promQLParser) parseBinaryModifiers(binary *PromQLBinaryExpr) error {
+	if p.is("bool") {
+		if !slices.Contains(promQLBinaryPrecedence[2], binary.Op) {
+			return fmt.Errorf("parse error at char %d: bool modifier can only be used on comparison operators", p.peek().pos+1)
+		}
+		p.next()
+		binary.ReturnBool = true
+	}
+	if p.is("on") || p.is("ignoring") {
+		matching := &PromQLVectorMatching{On: strings.ToLower(p.next().text) == "on"}
+		var err error
+		if matching.Labels, err = p.parseLabelList(); err != nil {
+			return err
+		}
+		if p.is("group_left") || p.is("group_right") {
+			if strings.ToLower(p.next().text) == "group_left" {
+				matching.GroupLeft = true
+			} else {
+				matching.GroupRight = true
+			}
+			if p.is("(") {
+				if matching.Include, err = p.parseLabelList(); err != nil {
+					return err
+				}
+			}
+		}
+		binary.Matching = matching
+	}
+	return nil
+}
+
+// parseUnary parses a signed expression. Like in Prometheus, the sign binds
+// looser than ^, so -2 ^ 2 is -4.
+func (p *promQLParser) parseUnary() (PromQLExpr, error) {
+	if p.is("-") || p.is("+") {
+		operator := p.next().text
+		expr, err := p.parseExpression(len(promQLBinaryPrecedence) - 1)
+		if err != nil || operator == "+" {
+			return expr, err
+		}
+		return &PromQLUnaryExpr{Op: operator, Expr: expr}, nil
+	}
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return p.parsePostfix(expr)
+}
+
+func (p *promQLParser) parsePostfix(expr PromQLExpr) (PromQLExpr, error) {
+	for {
+		switch {
+		case p.is("["):
+			start := p.next()
+			rangeText, err := p.parseDuration()
+			if err != nil {
+				return nil, err
+			}
+			if p.is(":") {
+				p.next()
+				subquery := &PromQLSubqueryExpr{Expr: expr, Range: rangeText}
+				if !p.is("]") {
+					if subquery.Step, err = p.parseDuration(); err != nil {
+						return nil, err
+					}
+				}
+				expr = subquery
+			} else {
+				selector, ok := expr.(*PromQLVectorSelector)
+				if !ok {
+					return nil, fmt.Errorf("parse error at char %d: ranges only allowed for vector selectors", start.pos+1)
+				}
+				expr = &PromQLMatrixSelector{Selector: selector, Range: rangeText}
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+		case p.is("offset"):
+			start := p.next()
+			sign := ""
+			if p.is("-") {
+				p.next()
+				sign = "-"
+			}
+			offset, err := p.parseDuration()
+			if err != nil {
+				return nil, err
+			}
+			target := promQLModifierTarget(expr)
+			if target == nil {
+				return nil, fmt.Errorf("parse error at char %d: offset modifier must be preceded by an instant vector selector or range vector selector or a subquery", start.pos+1)
+			}
+			*target.offset = sign + offset
+		case p.is("@"):
+			start := p.next()
+			var at string
+			switch token := p.next(); {
+			case token.kind == promQLNumber || token.kind == promQLVariable:
+				at = token.text
+			case token.kind == promQLIdentifier && (strings.EqualFold(token.text, "start") || strings.EqualFold(token.text, "end")):
+				if err := p.expect("("); err != nil {
+					return nil, err
+				}
+				if err := p.expect(")"); err != nil {
+					return nil, err
+				}
+				at = strings.ToLower(token.text) + "()"
+			default:
+				return nil, p.unexpected(token)
+			}
+			target := promQLModifierTarget(expr)
+			if target == nil {
+				return nil, fmt.Errorf("parse error at char %d: @ modifier must be preceded by an instant vector selector or range vector selector or a subquery", start.pos+1)
+			}
+			*target.at = at
+		default:
+			return expr, nil
+		}
+	}
+}
+
+type promQLModifiers struct {
+	offset *string
+	at     *string
+}
+
+// promQLModifierTarget returns the offset and @ modifiers of the selector or
+// subquery expr, or nil when expr can't have modifiers.
+func promQLModifierTarget(expr PromQLExpr) *promQLModifiers {
+	switch expr := expr.(type) {
+	case *PromQLVectorSelector:
+		return &promQLModifiers{&expr.Offset, &expr.At}
+	case *PromQLMatrixSelector:
+		return &promQLModifiers{&expr.Selector.Offset, &expr.Selector.At}
+	case *PromQLSubqueryExpr:
+		return &promQLModifiers{&expr.Offset, &expr.At}
+	}
+	return nil
+}
+
+func (p *promQLParser) parseDuration() (string, error) {
+	token := p.next()
+	if token.kind != promQLDuration && token.kind != promQLNumber && token.kind != promQLVariable {
+		return "", p.unexpected(token)
+	}
+	return token.text, nil
+}
+
+func (p *promQLParser) parsePrimary() (PromQLExpr, error) {
+	token := p.peek()
+	switch token.kind {
+	case promQLNumber:
+		p.next()
+		return parsePromQLNumber(token)
+	case promQLDuration:
+		p.next()
+		duration, err := ParsePromQLDuration(token.text)
+		if err != nil {
+			return nil, fmt.Errorf("parse error at char %d: %s", token.pos+1, err)
+		}
+		return &PromQLNumberLiteral{Value: duration.Seconds()}, nil
+	case promQLString:
+		p.next()
+		value, err := unquotePromQLString(token)
+		if err != nil {
+			return nil, err
+		}
+		return &PromQLStringLiteral{Value: value}, nil
+	case promQLVariable:
+		p.next()
+		return &PromQLVariable{Name: token.text}, nil
+	case promQLIdentifier:
+		name := token.text
+		lower := strings.ToLower(name)
+		if lower == "inf" || lower == "nan" {
+			p.next()
+			return parsePromQLNumber(token)
+		}
+		if slices.Contains(promQLAggregations, lower) {
+			return p.parseAggregation()
+		}
+		p.next()
+		if p.is("(") {
+			if !slices.Contains(promQLFunctions, name) && !slices.Contains(p.unknownFunctions, name) {
+				p.unknownFunctions = append(p.unknownFunctions, name)
+			}
+			args, err := p.parseArguments()
+			if err != nil {
+				return nil, err
+			}
+			return &PromQLCall{Func: name, Args: args}, nil
+		}
+		return p.parseSelector(name)
+	case promQLPunctuation:
+		switch token.text {
+		case "(":
+			p.next()
+			expr, err := p.parseExpression(0)
+			if err != nil {
+				return nil, err
+			}
+			return &PromQLParenExpr{Expr: expr}, p.expect(")")
+		case "{":
+			return p.parseSelector("")
+		}
+	}
+	return nil, p.unexpected(token)
+}
+
+func parsePromQLNumber(token promQLToken) (PromQLExpr, error) {
+	var value float64
+	var err error
+	switch lower := strings.ToLower(token.text); {
+	case lower == "inf":
+		value = math.Inf(1)
+	case lower == "nan":
+		value = math.NaN()
+	case strings.HasPrefix(lower, "0x"):
+		var integer uint64
+		integer, err = strconv.ParseUint(lower[2:], 16, 64)
+		value = float64(integer)
+	default:
+		value, err = strconv.ParseFloat(token.text, 64)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse error at char %d: bad number %q", token.pos+1, token.text)
+	}
+	return &PromQLNumberLiteral{Value: value}, nil
+}
+
+func unquotePromQLString(token promQLToken) (string, error) {
+	text := token.text
+	if strings.HasPrefix(text, "'") {
+		// Go only quotes single characters with ', so requote the string.
+		text = `"` + strings.ReplaceAll(strings.ReplaceAll(text[1:len(text)-1], `\'`, `'`), `"`, `\"`) + `"`
+	}
+	value, err := strconv.Unquote(text)
+	if err != nil {
+		return "", fmt.Errorf("parse error at char %d: invalid string %s", token.pos+1, token.text)
+	}
+	return value, nil
+}
+
+func (p *promQLParser) parseAggregation() (PromQLExpr, error) {
+	name := p.next()
+	op := strings.ToLower(name.text)
+	aggregate := &PromQLAggregateExpr{Op: op}
+	grouped := false
+	var err error
+	if p.is("by") || p.is("without") {
+		aggregate.Without = strings.ToLower(p.next().text) == "without"
+		if aggregate.Grouping, err = p.parseLabelList(); err != nil {
+			return nil, err
+		}
+		grouped = true
+	}
+	if !p.is("(") {
+		return nil, fmt.Errorf("parse error at char %d: expected \"(\" after aggregation %q", p.peek().pos+1, op)
+	}
+	args, err := p.parseArguments()
+	if err != nil {
+		return nil, err
+	}
+	want := 1
+	if slices.Contains([]string{"bottomk", "count_values", "limit_ratio", "limitk", "quantile", "topk"}, op) {
+		want = 2
+	}
+	if len(args) != want {
+		return nil, fmt.Errorf("parse error at char %d: wrong number of arguments for aggregate expression provided, expected %d, got %d", name.pos+1, want, len(args))
+	}
+	if want == 2 {
+		aggregate.Param = args[0]
+	}
+	aggregate.Expr = args[want-1]
+	if !grouped && (p.is("by") || p.is("without")) {
+		aggregate.Without = strings.ToLower(p.next().text) == "without"
+		if aggregate.Grouping, err = p.parseLabelList(); err != nil {
+			return nil, err
+		}
+	}
+	return aggregate, nil
+}
+
+func (p *promQLParser) parseArguments() ([]PromQLExpr, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var args []PromQLExpr
+	for !p.is(")") {
+		arg, err := p.parseExpression(0)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if !p.is(",") {
+			break
+		}
+		p.next()
+	}
+	return args, p.expect(")")
+}
+
+func (p *promQLParser) parseLabelList() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var labels []string
+	for !p.is(")") {
+		token := p.next()
+		if token.kind != promQLIdentifier && token.kind != promQLString {
+			return nil, p.unexpected(token)
+		}
+		labels = append(labels, strings.Trim(token.text, "\"'`"))
+		if !p.is(",") {
+			break
+		}
+		p.next()
+	}
+	return labels, p.expect(")")
+}
+
+func (p *promQLParser) parseSelector(metric string) (PromQLExpr, error) {
+	selector := PromQLSelector{Metric: metric}
+	vector := &PromQLVectorSelector{Metric: metric}
+	if p.is("{") {
+		p.next()
+		for !p.is("}") {
+			label := p.next()
+			if label.kind == promQLString && (p.is(",") || p.is("}")) {
+				// A quoted metric name, {"http.requests"}.
+				selector.Metric = strings.Trim(label.text, "\"'`")
+				vector.Metric = selector.Metric
+			} else {
+				if label.kind != promQLIdentifier && label.kind != promQLString {
+					return nil, p.unexpected(label)
+				}
+				operator := p.next()
+				if operator.kind != promQLPunctuation || !slices.Contains([]string{"=", "!=", "=~", "!~"}, operator.text) {
+					return nil, p.unexpected(operator)
+				}
+				value := p.next()
+				if value.kind != promQLString && value.kind != promQLVariable {
+					return nil, p.unexpected(value)
+				}
+				matcher := PromQLMatcher{Name: strings.Trim(label.text, "\"'`"), Type: operator.text, Value: value.text}
+				if value.kind == promQLString {
+					var err error
+					if matcher.Value, err = unquotePromQLString(value); err != nil {
+						return nil, err
+					}
+				}
+				switch {
+				case matcher.Name == "__name__" && operator.text == "=" && value.kind == promQLString:
+					selector.Metric = matcher.Value
+					vector.Metric = matcher.Value
+				case matcher.Name == "__name__":
+					vector.Matchers = append(vector.Matchers, matcher)
+				default:
+					selector.Labels = append(selector.Labels, matcher.Name)
+					vector.Matchers = append(vector.Matchers, matcher)
+				}
+			}
+			if !p.is(",") {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect("}"); err != nil {
+			return nil, err
+		}
+		if selector.Metric == "" && len(vector.Matchers) == 0 {
+			return nil, fmt.Errorf("parse error at char %d: vector selector must contain at least one matcher", p.tokens[p.pos-1].pos+1)
+		}
+	} else if metric == "" {
+		return nil, p.unexpected(p.peek())
+	}
+	p.selectors = append(p.selectors, selector)
+	return vector, nil
+}
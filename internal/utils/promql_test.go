@@ -0,0 +1,154 @@
+// Copyright 2025 Coralogix Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePromQL(t *testing.T) {
+	cases := []struct {
+		query     string
+		selectors []PromQLSelector
+	}{
+		{`up`, []PromQLSelector{{Metric: "up"}}},
+		{`sum by (service) (rate(http_requests_total{namespace=~"{{ namespace }}", code!="500"}[$__rate_interval]))`,
+			[]PromQLSelector{{Metric: "http_requests_total", Labels: []string{"namespace", "code"}}}},
+		{`histogram_quantile({{ quantile }}, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))`,
+			[]PromQLSelector{{Metric: "http_request_duration_seconds_bucket"}}},
+		{`sum(rate(a[5m])) without (pod) / on (job) group_left (team) b > bool 0.5`,
+			[]PromQLSelector{{Metric: "a"}, {Metric: "b"}}},
+		{`job:rate5m{__name__="job:rate5m", job="api"} offset -1h30m @ end()`,
+			[]PromQLSelector{{Metric: "job:rate5m", Labels: []string{"job"}}}},
+		{`max_over_time(deriv(rate(x[1m])[5m:])[1h:30s]) and topk(5, y) or -2 ^ 2 ^ 1e-3`,
+			[]PromQLSelector{{Metric: "x"}, {Metric: "y"}}},
+		{`{"http.server.requests", service="checkout",}`,
+			[]PromQLSelector{{Metric: "http.server.requests", Labels: []string{"service"}}}},
+		{`count_values("version", build_info) # comment`, []PromQLSelector{{Metric: "build_info"}}},
+		{`vector(1) + time()`, nil},
+		{`SUM BY (job) (rate(x[5m])) / ON (job) GROUP_LEFT b > BOOL 0.5`,
+			[]PromQLSelector{{Metric: "x"}, {Metric: "b"}}},
+		{`Sum(x OFFSET 5m @ END()) Without (pod)`, []PromQLSelector{{Metric: "x"}}},
+	}
+	for _, tc := range cases {
+		selectors, unknownFunctions, err := ParsePromQL(tc.query)
+		if err != nil {
+			t.Errorf("ParsePromQL(%s) returned an error: %s", tc.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(selectors, tc.selectors) {
+			t.Errorf("ParsePromQL(%s) = %+v, want %+v", tc.query, selectors, tc.selectors)
+		}
+		if len(unknownFunctions) > 0 {
+			t.Errorf("ParsePromQL(%s) unknown functions = %q, want none", tc.query, unknownFunctions)
+		}
+	}
+}
+
+func TestParsePromQLUnknownFunctions(t *testing.T) {
+	selectors, unknownFunctions, err := ParsePromQL(`rat(x[5m]) + RATE(y[5m]) / rat(z[5m])`)
+	if err != nil {
+		t.Fatalf("ParsePromQL returned an error: %s", err)
+	}
+	if want := []PromQLSelector{{Metric: "x"}, {Metric: "y"}, {Metric: "z"}}; !reflect.DeepEqual(selectors, want) {
+		t.Errorf("selectors = %+v, want %+v", selectors, want)
+	}
+	if want := []string{"rat", "RATE"}; !reflect.DeepEqual(unknownFunctions, want) {
+		t.Errorf("unknown functions = %q, want %q", unknownFunctions, want)
+	}
+}
+
+func TestParsePromQLErrors(t *testing.T) {
+	cases := []struct {
+		query string
+		want  string
+	}{
+		{`sum(rate(x[5m])`, "unexpected end of input"},
+		{`x{job="a"`, "unexpected end of input"},
+		{`x{job=a}`, `unexpected "a"`},
+		{`x[5xyz]`, "bad number or duration"},
+		{`sum by job (x)`, `unexpected "job"`},
+		{`x + bool y`, "bool modifier can only be used on comparison operators"},
+		{`x{job="a}`, "unterminated string"},
+		{`x ! y`, "unexpected character"},
+		{`x y`, `unexpected "y"`},
+		{`{}`, "vector selector must contain at least one matcher"},
+	}
+	for _, tc := range cases {
+		if _, _, err := ParsePromQL(tc.query); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ParsePromQL(%s) error = %v, want %q", tc.query, err, tc.want)
+		}
+	}
+}
+
+func TestParsePromQLExpr(t *testing.T) {
+	cases := []struct {
+		query string
+		want  PromQLExpr
+	}{
+		{`-2 ^ 2`, &PromQLUnaryExpr{Op: "-", Expr: &PromQLBinaryExpr{
+			Op:  "^",
+			LHS: &PromQLNumberLiteral{Value: 2},
+			RHS: &PromQLNumberLiteral{Value: 2},
+		}}},
+		{`rate(x{__name__="x", job=~'a.*'}[5m] offset 1h)`, &PromQLCall{Func: "rate", Args: []PromQLExpr{
+			&PromQLMatrixSelector{
+				Selector: &PromQLVectorSelector{
+					Metric:   "x",
+					Matchers: []PromQLMatcher{{Name: "job", Type: "=~", Value: "a.*"}},
+					Offset:   "1h",
+				},
+				Range: "5m",
+			},
+		}}},
+		{`max_over_time(sum without (pod) (y)[1h:30s])`, &PromQLCall{Func: "max_over_time", Args: []PromQLExpr{
+			&PromQLSubqueryExpr{
+				Expr:  &PromQLAggregateExpr{Op: "sum", Grouping: []string{"pod"}, Without: true, Expr: &PromQLVectorSelector{Metric: "y"}},
+				Range: "1h",
+				Step:  "30s",
+			},
+		}}},
+		{`a / on (job) group_left (team) b`, &PromQLBinaryExpr{
+			Op:       "/",
+			LHS:      &PromQLVectorSelector{Metric: "a"},
+			RHS:      &PromQLVectorSelector{Metric: "b"},
+			Matching: &PromQLVectorMatching{On: true, Labels: []string{"job"}, GroupLeft: true, Include: []string{"team"}},
+		}},
+		{`TOPK(5, x) BY (job)`, &PromQLAggregateExpr{
+			Op:       "topk",
+			Grouping: []string{"job"},
+			Param:    &PromQLNumberLiteral{Value: 5},
+			Expr:     &PromQLVectorSelector{Metric: "x"},
+		}},
+	}
+	for _, tc := range cases {
+		got, err := ParsePromQLExpr(tc.query)
+		if err != nil {
+			t.Errorf("ParsePromQLExpr(%s) error = %v", tc.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ParsePromQLExpr(%s) = %#v, want %#v", tc.query, got, tc.want)
+		}
+	}
+
+	for _, query := range []string{`sum(x)[5m]`, `(x) offset 5m`, `topk(x)`} {
+		if _, err := ParsePromQLExpr(query); err == nil {
+			t.Errorf("ParsePromQLExpr(%s) expected an error", query)
+		}
+	}
+}
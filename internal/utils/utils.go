@@ -39,10 +39,13 @@ import (
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
@@ -996,3 +999,285 @@ func JSONStringsEqualPlanModifier(_ context.Context, plan planmodifier.StringReq
 	}
 	req.RequiresReplace = true
 }
+
+// DecodeJSONWithSchema decodes a JSON document shaped like a resource's HCL
+// attributes into target, a model struct for that schema. Attributes the
+// document leaves out are null, unless the schema declares a static default for
+// them, in which case the default is used the same way a plan would.
+func DecodeJSONWithSchema(ctx context.Context, s resourceschema.Schema, document []byte, target any) diag.Diagnostics {
+	value, diags := jsonValueWithSchema(ctx, s, document)
+	if diags.HasError() {
+		return diags
+	}
+
+	plan := tfsdk.Plan{Schema: s, Raw: value}
+	diags.Append(plan.Get(ctx, target)...)
+	return diags
+}
+
+func jsonValueWithSchema(ctx context.Context, s resourceschema.Schema, document []byte) (tftypes.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	value, err := tftypes.ValueFromJSONWithOpts(document, s.Type().TerraformType(ctx), tftypes.ValueFromJSONOpts{})
+	if err != nil {
+		diags.AddError("Invalid document", err.Error())
+		return value, diags
+	}
+
+	value, err = tftypes.Transform(value, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsNull() || len(p.Steps()) == 0 {
+			return v, nil
+		}
+		attribute, err := s.AttributeAtTerraformPath(ctx, p)
+		if err != nil {
+			// Collection elements are not attributes and have no defaults.
+			return v, nil
+		}
+		defaultValue, d := schemaAttributeDefault(ctx, attribute)
+		diags.Append(d...)
+		if defaultValue == nil {
+			return v, nil
+		}
+		return defaultValue.ToTerraformValue(ctx)
+	})
+	if err != nil {
+		diags.AddError("Invalid document", err.Error())
+	}
+	return value, diags
+}
+
+func schemaAttributeDefault(ctx context.Context, attribute any) (attr.Value, diag.Diagnostics) {
+	switch a := attribute.(type) {
+	case interface{ BoolDefaultValue() defaults.Bool }:
+		if d := a.BoolDefaultValue(); d != nil {
+			var resp defaults.BoolResponse
+			d.DefaultBool(ctx, defaults.BoolRequest{}, &resp)
+			return resp.PlanValue, resp.Diagnostics
+		}
+	case interface{ StringDefaultValue() defaults.String }:
+		if d := a.StringDefaultValue(); d != nil {
+			var resp defaults.StringResponse
+			d.DefaultString(ctx, defaults.StringRequest{}, &resp)
+			return resp.PlanValue, resp.Diagnostics
+		}
+	case interface{ Int64DefaultValue() defaults.Int64 }:
+		if d := a.Int64DefaultValue(); d != nil {
+			var resp defaults.Int64Response
+			d.DefaultInt64(ctx, defaults.Int64Request{}, &resp)
+			return resp.PlanValue, resp.Diagnostics
+		}
+	case interface{ Int32DefaultValue() defaults.Int32 }:
+		if d := a.Int32DefaultValue(); d != nil {
+			var resp defaults.Int32Response
+			d.DefaultInt32(ctx, defaults.Int32Request{}, &resp)
+			return resp.PlanValue, resp.Diagnostics
+		}
+	case interface{ Float64DefaultValue() defaults.Float64 }:
+		if d := a.Float64DefaultValue(); d != nil {
+			var resp defaults.Float64Response
+			d.DefaultFloat64(ctx, defaults.Float64Request{}, &resp)
+			return resp.PlanValue, resp.Diagnostics
+		}
+	case interface{ ListDefaultValue() defaults.List }:
+		if d := a.ListDefaultValue(); d != nil {
+			var resp defaults.ListResponse
+			d.DefaultList(ctx, defaults.ListRequest{}, &resp)
+			return resp.PlanValue, resp.Diagnostics
+		}
+	case interface{ SetDefaultValue() defaults.Set }:
+		if d := a.SetDefaultValue(); d != nil {
+			var resp defaults.SetResponse
+			d.DefaultSet(ctx, defaults.SetRequest{}, &resp)
+			return resp.PlanValue, resp.Diagnostics
+		}
+	case interface{ MapDefaultValue() defaults.Map }:
+		if d := a.MapDefaultValue(); d != nil {
+			var resp defaults.MapResponse
+			d.DefaultMap(ctx, defaults.MapRequest{}, &resp)
+			return resp.PlanValue, resp.Diagnostics
+		}
+	case interface{ ObjectDefaultValue() defaults.Object }:
+		if d := a.ObjectDefaultValue(); d != nil {
+			var resp defaults.ObjectResponse
+			d.DefaultObject(ctx, defaults.ObjectRequest{}, &resp)
+			return resp.PlanValue, resp.Diagnostics
+		}
+	}
+	return nil, nil
+}
+
+// DriftJSONWithSchema compares a JSON document decoded with DecodeJSONWithSchema
+// against remote, a model struct for the same schema flattened from the API.
+// Attributes the document leaves out are not compared, so values computed by
+// the API are not drift. When the remote values differ, it returns the document
+// with the remote values of the attributes it sets, and true.
+func DriftJSONWithSchema(ctx context.Context, s resourceschema.Schema, document []byte, remote any) ([]byte, bool, diag.Diagnostics) {
+	documentValue, diags := jsonValueWithSchema(ctx, s, document)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	diags.Append(state.Set(ctx, remote)...)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+
+	drifted, err := projectTerraformValue(documentValue, state.Raw)
+	if err == nil && drifted.Equal(documentValue) {
+		return nil, false, diags
+	}
+	var encoded []byte
+	if err == nil {
+		var decoded any
+		if decoded, err = terraformValueToJSON(drifted); err == nil {
+			encoded, err = json.Marshal(decoded)
+		}
+	}
+	if err != nil {
+		diags.AddError("Error comparing document", err.Error())
+		return nil, false, diags
+	}
+	return encoded, true, diags
+}
+
+// projectTerraformValue returns remote, without the attributes document leaves
+// null. Collections of a different length or keys are returned whole.
+func projectTerraformValue(document, remote tftypes.Value) (tftypes.Value, error) {
+	if document.IsNull() || !document.IsKnown() || remote.IsNull() || !remote.IsKnown() {
+		if document.IsNull() {
+			return document, nil
+		}
+		return remote, nil
+	}
+
+	switch document.Type().(type) {
+	case tftypes.Object, tftypes.Map:
+		var documentElements, remoteElements map[string]tftypes.Value
+		if err := document.As(&documentElements); err != nil {
+			return remote, err
+		}
+		if err := remote.As(&remoteElements); err != nil {
+			return remote, err
+		}
+		if _, isMap := document.Type().(tftypes.Map); isMap && !slices.Equal(slices.Sorted(maps.Keys(documentElements)), slices.Sorted(maps.Keys(remoteElements))) {
+			return remote, nil
+		}
+		projected := make(map[string]tftypes.Value, len(documentElements))
+		for name, element := range documentElements {
+			value, err := projectTerraformValue(element, remoteElements[name])
+			if err != nil {
+				return remote, err
+			}
+			projected[name] = value
+		}
+		return tftypes.NewValue(document.Type(), projected), nil
+	case tftypes.List, tftypes.Tuple:
+		var documentElements, remoteElements []tftypes.Value
+		if err := document.As(&documentElements); err != nil {
+			return remote, err
+		}
+		if err := remote.As(&remoteElements); err != nil {
+			return remote, err
+		}
+		if len(documentElements) != len(remoteElements) {
+			return remote, nil
+		}
+		projected := make([]tftypes.Value, len(documentElements))
+		for i := range documentElements {
+			value, err := projectTerraformValue(documentElements[i], remoteElements[i])
+			if err != nil {
+				return remote, err
+			}
+			projected[i] = value
+		}
+		return tftypes.NewValue(document.Type(), projected), nil
+	case tftypes.Set:
+		var documentElements, remoteElements []tftypes.Value
+		if err := document.As(&documentElements); err != nil {
+			return remote, err
+		}
+		if err := remote.As(&remoteElements); err != nil {
+			return remote, err
+		}
+		if len(documentElements) != len(remoteElements) {
+			return remote, nil
+		}
+		// Set elements have no position, so each must match a remote element.
+		for _, element := range documentElements {
+			i := slices.IndexFunc(remoteElements, func(candidate tftypes.Value) bool {
+				value, err := projectTerraformValue(element, candidate)
+				return err == nil && value.Equal(element)
+			})
+			if i < 0 {
+				return remote, nil
+			}
+			remoteElements = slices.Delete(remoteElements, i, i+1)
+		}
+		return document, nil
+	default:
+		return remote, nil
+	}
+}
+
+// terraformValueToJSON converts a value to its JSON encoding, leaving out null
+// object attributes.
+func terraformValueToJSON(value tftypes.Value) (any, error) {
+	if value.IsNull() || !value.IsKnown() {
+		return nil, nil
+	}
+
+	switch value.Type().(type) {
+	case tftypes.Object, tftypes.Map:
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		_, isObject := value.Type().(tftypes.Object)
+		result := make(map[string]any, len(elements))
+		for name, element := range elements {
+			if isObject && element.IsNull() {
+				continue
+			}
+			encoded, err := terraformValueToJSON(element)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = encoded
+		}
+		return result, nil
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		result := make([]any, len(elements))
+		for i, element := range elements {
+			encoded, err := terraformValueToJSON(element)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = encoded
+		}
+		return result, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case value.Type().Is(tftypes.Number):
+		var f big.Float
+		if err := value.As(&f); err != nil {
+			return nil, err
+		}
+		if f.IsInt() {
+			return json.Number(f.Text('f', 0)), nil
+		}
+		return json.Number(f.Text('g', -1)), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", value.Type())
+}
@@ -15,6 +15,7 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strings"
@@ -22,6 +23,10 @@ import (
 
 	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
 	cxsdkOpenapi "github.com/coralogix/coralogix-management-sdk/go/openapi/cxsdk"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -223,3 +228,78 @@ func TestCanonicalJSON(t *testing.T) {
 		})
 	}
 }
+
+type driftTestModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Labels  types.Map    `tfsdk:"labels"`
+	Rules   types.List   `tfsdk:"rules"`
+}
+
+func TestDriftJSONWithSchema(t *testing.T) {
+	ctx := context.Background()
+	s := resourceschema.Schema{
+		Attributes: map[string]resourceschema.Attribute{
+			"id":      resourceschema.StringAttribute{Computed: true},
+			"name":    resourceschema.StringAttribute{Required: true},
+			"enabled": resourceschema.BoolAttribute{Optional: true, Computed: true, Default: booldefault.StaticBool(true)},
+			"labels":  resourceschema.MapAttribute{Optional: true, ElementType: types.StringType},
+			"rules": resourceschema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: resourceschema.NestedAttributeObject{
+					Attributes: map[string]resourceschema.Attribute{
+						"query":     resourceschema.StringAttribute{Required: true},
+						"threshold": resourceschema.Float64Attribute{Optional: true, Computed: true},
+					},
+				},
+			},
+		},
+	}
+	ruleType := map[string]attr.Type{"query": types.StringType, "threshold": types.Float64Type}
+	remote := func(name string, enabled bool, threshold float64) driftTestModel {
+		return driftTestModel{
+			ID:      types.StringValue("abc"),
+			Name:    types.StringValue(name),
+			Enabled: types.BoolValue(enabled),
+			Labels:  types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("a")}),
+			Rules: types.ListValueMust(types.ObjectType{AttrTypes: ruleType}, []attr.Value{
+				types.ObjectValueMust(ruleType, map[string]attr.Value{"query": types.StringValue("up"), "threshold": types.Float64Value(threshold)}),
+			}),
+		}
+	}
+	document := `{"name": "a", "labels": {"team": "a"}, "rules": [{"query": "up"}]}`
+
+	tests := []struct {
+		name   string
+		remote driftTestModel
+		want   string
+	}{
+		{
+			name:   "computed values are not drift",
+			remote: remote("a", true, 2.5),
+		},
+		{
+			name:   "changed attribute",
+			remote: remote("b", true, 2.5),
+			want:   `{"enabled":true,"labels":{"team":"a"},"name":"b","rules":[{"query":"up"}]}`,
+		},
+		{
+			name:   "changed default",
+			remote: remote("a", false, 2.5),
+			want:   `{"enabled":false,"labels":{"team":"a"},"name":"a","rules":[{"query":"up"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, drifted, diags := DriftJSONWithSchema(ctx, s, []byte(document), &tt.remote)
+			if diags.HasError() {
+				t.Fatalf("DriftJSONWithSchema returned diagnostics: %v", diags)
+			}
+			if drifted != (tt.want != "") || string(got) != tt.want {
+				t.Fatalf("DriftJSONWithSchema() = %s, %v, want %s", got, drifted, tt.want)
+			}
+		})
+	}
+}